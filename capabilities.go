@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+// capabilityCount is the number of capabilities in the bitmap, as for the C
+// and Python libraries.
+const capabilityCount = 512
+
+var capabilityNames = &enum{typeName: "CapabilityType", names: []enumName{
+	{int64(CapVolumes), "Volumes"},
+	{int64(CapVolumeCreate), "VolumeCreate"},
+	{int64(CapVolumeCResize), "VolumeCResize"},
+	{int64(CapVolumeCReplicate), "VolumeCReplicate"},
+	{int64(CapVolumeCReplicateClone), "VolumeCReplicateClone"},
+	{int64(CapVolumeCReplicateCopy), "VolumeCReplicateCopy"},
+	{int64(CapVolumeCReplicateMirrorAsync), "VolumeCReplicateMirrorAsync"},
+	{int64(CapVolumeCReplicateMirrorSync), "VolumeCReplicateMirrorSync"},
+	{int64(CapVolumeCopyRangeBlockSize), "VolumeCopyRangeBlockSize"},
+	{int64(CapVolumeCopyRange), "VolumeCopyRange"},
+	{int64(CapVolumeCopyRangeClone), "VolumeCopyRangeClone"},
+	{int64(CapVolumeCopyRangeCopy), "VolumeCopyRangeCopy"},
+	{int64(CapVolumeDelete), "VolumeDelete"},
+	{int64(CapVolumeEnable), "VolumeEnable"},
+	{int64(CapVolumeDisable), "VolumeDisable"},
+	{int64(CapVolumeMask), "VolumeMask"},
+	{int64(CapVolumeUnmask), "VolumeUnmask"},
+	{int64(CapAccessGroups), "AccessGroups"},
+	{int64(CapAccessGroupCreateWwpn), "AccessGroupCreateWwpn"},
+	{int64(CapAccessGroupDelete), "AccessGroupDelete"},
+	{int64(CapAccessGroupInitiatorAddWwpn), "AccessGroupInitiatorAddWwpn"},
+	{int64(CapAccessGroupInitiatorDel), "AccessGroupInitiatorDel"},
+	{int64(CapVolumesMaskedToAg), "VolumesMaskedToAg"},
+	{int64(CapAgsGrantedToVol), "AgsGrantedToVol"},
+	{int64(CapHasChildDep), "HasChildDep"},
+	{int64(CapChildDepRm), "ChildDepRm"},
+	{int64(CapAccessGroupCreateIscsiIqn), "AccessGroupCreateIscsiIqn"},
+	{int64(CapAccessGroupInitAddIscsiIqn), "AccessGroupInitAddIscsiIqn"},
+	{int64(CapIscsiChapAuthSet), "IscsiChapAuthSet"},
+	{int64(CapVolRaidInfo), "VolRaidInfo"},
+	{int64(CapVolumeThin), "VolumeThin"},
+	{int64(CapBatteries), "Batteries"},
+	{int64(CapVolCacheInfo), "VolCacheInfo"},
+	{int64(CapVolPhyDiskCacheSet), "VolPhyDiskCacheSet"},
+	{int64(CapVolPhysicalDiskCacheSetSystemLevel), "VolPhysicalDiskCacheSetSystemLevel"},
+	{int64(CapVolWriteCacheSetEnable), "VolWriteCacheSetEnable"},
+	{int64(CapVolWriteCacheSetAuto), "VolWriteCacheSetAuto"},
+	{int64(CapVolWriteCacheSetDisabled), "VolWriteCacheSetDisabled"},
+	{int64(CapVolWriteCacheSetImpactRead), "VolWriteCacheSetImpactRead"},
+	{int64(CapVolWriteCacheSetWbImpactOther), "VolWriteCacheSetWbImpactOther"},
+	{int64(CapVolReadCacheSet), "VolReadCacheSet"},
+	{int64(VolReadCacheSetImpactWrite), "VolReadCacheSetImpactWrite"},
+	{int64(CapFs), "Fs"},
+	{int64(CapFsDelete), "FsDelete"},
+	{int64(CapFsResize), "FsResize"},
+	{int64(CapFsCreate), "FsCreate"},
+	{int64(CapFsClone), "FsClone"},
+	{int64(CapFsFileClone), "FsFileClone"},
+	{int64(CapFsSnapshots), "FsSnapshots"},
+	{int64(CapFsSnapshotCreate), "FsSnapshotCreate"},
+	{int64(CapFsSnapshotDelete), "FsSnapshotDelete"},
+	{int64(CapFsSnapshotRestore), "FsSnapshotRestore"},
+	{int64(CapFsSnapshotRestoreSpecificFiles), "FsSnapshotRestoreSpecificFiles"},
+	{int64(CapFsHasChildDep), "FsHasChildDep"},
+	{int64(CapFsChildDepRm), "FsChildDepRm"},
+	{int64(CapFsChildDepRmSpecificFiles), "FsChildDepRmSpecificFiles"},
+	{int64(CapNfsExportAuthTypeList), "NfsExportAuthTypeList"},
+	{int64(CapNfsExports), "NfsExports"},
+	{int64(CapFsExport), "FsExport"},
+	{int64(CapFsUnexport), "FsUnexport"},
+	{int64(CapFsExportCustomPath), "FsExportCustomPath"},
+	{int64(CapSysReadCachePctSet), "SysReadCachePctSet"},
+	{int64(CapSysReadCachePctGet), "SysReadCachePctGet"},
+	{int64(CapSysFwVersionGet), "SysFwVersionGet"},
+	{int64(CapSysModeGet), "SysModeGet"},
+	{int64(CapDiskLocation), "DiskLocation"},
+	{int64(CapDiskRpm), "DiskRpm"},
+	{int64(CapDiskLinkType), "DiskLinkType"},
+	{int64(CapVolumeLed), "VolumeLed"},
+	{int64(CapTargetPorts), "TargetPorts"},
+	{int64(CapDisks), "Disks"},
+	{int64(CapPoolMemberInfo), "PoolMemberInfo"},
+	{int64(CapVolumeRaidCreate), "VolumeRaidCreate"},
+	{int64(CapDiskVpd83Get), "DiskVpd83Get"},
+}}
+
+// String returns the name of the capability, eg. "VolumeCreate".
+func (c CapabilityType) String() string {
+	return capabilityNames.format(int64(c), false)
+}
+
+// Supported lists the supported capabilities in ascending order.
+func (c *Capabilities) Supported() []CapabilityType {
+	var supported []CapabilityType
+	for i := 0; i+2 <= len(c.Cap); i += 2 {
+		if c.Cap[i:i+2] == "01" {
+			supported = append(supported, CapabilityType(i/2))
+		}
+	}
+	return supported
+}
+
+// CapabilitiesBuilder assembles the Capabilities a plugin returns, the zero
+// value supports nothing.
+//
+//	var b CapabilitiesBuilder
+//	return b.Set(CapVolumes, CapVolumeCreate).Build(), nil
+type CapabilitiesBuilder struct {
+	bitmap [capabilityCount]bool
+}
+
+// Set marks the capabilities as supported, values out of range are ignored.
+func (b *CapabilitiesBuilder) Set(caps ...CapabilityType) *CapabilitiesBuilder {
+	return b.mark(true, caps)
+}
+
+// Unset marks the capabilities as unsupported.
+func (b *CapabilitiesBuilder) Unset(caps ...CapabilityType) *CapabilitiesBuilder {
+	return b.mark(false, caps)
+}
+
+func (b *CapabilitiesBuilder) mark(supported bool, caps []CapabilityType) *CapabilitiesBuilder {
+	for _, c := range caps {
+		if int(c) < capabilityCount {
+			b.bitmap[c] = supported
+		}
+	}
+	return b
+}
+
+// Build returns the Capabilities, the builder may be used further.
+func (b *CapabilitiesBuilder) Build() *Capabilities {
+	bitmap := make([]byte, capabilityCount*2)
+	for i, supported := range b.bitmap {
+		bitmap[i*2] = '0'
+		bitmap[i*2+1] = '0'
+		if supported {
+			bitmap[i*2+1] = '1'
+		}
+	}
+	return &Capabilities{Class: "Capabilities", Cap: string(bitmap)}
+}
+
+// CapabilitiesRefine adjusts the capabilities derived for a system, eg. to
+// set those not tied to a callback or unset those the system lacks.
+type CapabilitiesRefine func(system *System, caps *CapabilitiesBuilder) error
+
+// DefaultCapabilities returns a Capabilities callback advertising what the
+// populated callbacks serve, see DerivedCapabilities, refined per system by
+// refine unless it is nil.  It is used when a plugin leaves
+// Mgmt.Capabilities unset.
+func DefaultCapabilities(callbacks *PluginCallBacks, refine CapabilitiesRefine) CapabilitiesCb {
+	return func(system *System) (*Capabilities, error) {
+		caps := DerivedCapabilities(callbacks)
+		if refine != nil {
+			if err := refine(system, caps); err != nil {
+				return nil, err
+			}
+		}
+		return caps.Build(), nil
+	}
+}
+
+// DerivedCapabilities returns a builder with the capabilities served by the
+// populated callbacks.  Only the base capability of an operation is set, the
+// variants a callback may or may not handle, eg. the replication types,
+// CapFsExportCustomPath or the SpecificFiles ones, are for the plugin to
+// set, as are those describing the systems, disks and volumes, eg.
+// CapDiskRpm.  Operations without a base capability, creating access groups
+// and setting the write cache, set the capabilities of all their variants.
+func DerivedCapabilities(callbacks *PluginCallBacks) *CapabilitiesBuilder {
+	b := &CapabilitiesBuilder{}
+	served := func(cb interface{}, caps ...CapabilityType) {
+		if isSet(cb) {
+			b.Set(caps...)
+		}
+	}
+
+	san := &callbacks.San
+	served(san.Volumes, CapVolumes)
+	served(san.VolumeCreate, CapVolumeCreate)
+	served(san.VolumeResize, CapVolumeCResize)
+	served(san.VolumeReplicate, CapVolumeCReplicate)
+	served(san.VolumeRepRangeBlkSize, CapVolumeCopyRangeBlockSize)
+	served(san.VolumeReplicateRange, CapVolumeCopyRange)
+	served(san.VolumeDelete, CapVolumeDelete)
+	served(san.VolumeEnable, CapVolumeEnable)
+	served(san.VolumeDisable, CapVolumeDisable)
+	served(san.VolumeMask, CapVolumeMask)
+	served(san.VolumeUnMask, CapVolumeUnmask)
+	served(san.AccessGroups, CapAccessGroups)
+	served(san.AccessGroupCreate, CapAccessGroupCreateWwpn, CapAccessGroupCreateIscsiIqn)
+	served(san.AccessGroupDelete, CapAccessGroupDelete)
+	served(san.AccessGroupInitAdd, CapAccessGroupInitiatorAddWwpn, CapAccessGroupInitAddIscsiIqn)
+	served(san.AccessGroupInitDelete, CapAccessGroupInitiatorDel)
+	served(san.VolsMaskedToAg, CapVolumesMaskedToAg)
+	served(san.AgsGrantedToVol, CapAgsGrantedToVol)
+	served(san.VolHasChildDep, CapHasChildDep)
+	served(san.VolChildDepRm, CapChildDepRm)
+	served(san.IscsiChapAuthSet, CapIscsiChapAuthSet)
+	served(san.TargetPorts, CapTargetPorts)
+	served(san.Disks, CapDisks)
+	if isSet(san.VolIdentLedOn) && isSet(san.VolIdentLedOff) {
+		b.Set(CapVolumeLed)
+	}
+
+	fs := &callbacks.File
+	served(fs.FileSystems, CapFs)
+	served(fs.FsCreate, CapFsCreate)
+	served(fs.FsDelete, CapFsDelete)
+	served(fs.FsResize, CapFsResize)
+	served(fs.FsClone, CapFsClone)
+	served(fs.FsFileClone, CapFsFileClone)
+	served(fs.FsSnapShots, CapFsSnapshots)
+	served(fs.FsSnapShotCreate, CapFsSnapshotCreate)
+	served(fs.FsSnapShotDelete, CapFsSnapshotDelete)
+	served(fs.FsSnapShotRestore, CapFsSnapshotRestore)
+	served(fs.FsHasChildDep, CapFsHasChildDep)
+	served(fs.FsChildDepRm, CapFsChildDepRm)
+
+	nfs := &callbacks.Nfs
+	served(nfs.Exports, CapNfsExports)
+	served(nfs.ExportAuthTypes, CapNfsExportAuthTypeList)
+	served(nfs.FsExport, CapFsExport)
+	served(nfs.FsUnExport, CapFsUnexport)
+
+	hba := &callbacks.Hba
+	served(hba.VolRaidInfo, CapVolRaidInfo)
+	served(hba.PoolMemberInfo, CapPoolMemberInfo)
+	served(hba.Batteries, CapBatteries)
+	if isSet(hba.VolRaidCreate) && isSet(hba.VolRaidCreateCapGet) {
+		b.Set(CapVolumeRaidCreate)
+	}
+
+	cache := &callbacks.Cache
+	served(cache.SysReadCachePctSet, CapSysReadCachePctSet)
+	served(cache.VolCacheInfo, CapVolCacheInfo)
+	served(cache.VolPhyDiskCacheSet, CapVolPhyDiskCacheSet)
+	served(cache.VolWriteCacheSet, CapVolWriteCacheSetEnable, CapVolWriteCacheSetAuto,
+		CapVolWriteCacheSetDisabled)
+	served(cache.VolReadCacheSet, CapVolReadCacheSet)
+	return b
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package cli implements lsmgo, a command line tool for managing storage
+// along the lines of the Python lsmcli.
+//
+// Global flags come before the command, eg.
+//
+//	lsmgo -u sim:// -o json list -type volumes -pool POOL_ID_01
+//
+// Operations which may run as a job are waited for, unless -async is given,
+// then the job ID is printed and the exit code is 7 (errors.JobStarted), as
+// it is for job-status while the job is still running.  Failed calls exit
+// with the code of the LsmError if it fits an exit code, otherwise 1, usage
+// errors exit with 2.  The LsmError codes which are exit codes of lsmgo
+// already, 1 (LibBug), 2 (PluginBug) and 7 (JobStarted), exit with 3.
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+const (
+	exitFailure = 1
+	exitUsage   = 2
+	// LsmError codes taken by the other exit codes
+	exitReserved = 3
+)
+
+// command is one of the commands of lsmgo, setup defines its flags and
+// returns the function running it.
+type command struct {
+	name    string
+	help    string
+	offline bool
+	setup   func(f *flag.FlagSet) func(t *tool) error
+}
+
+// tool holds what the commands share.
+type tool struct {
+	ctx      context.Context
+	stdout   io.Writer
+	stderr   io.Writer
+	format   *choice
+	async    bool
+	client   lsm.StorageClient
+	commands []command
+}
+
+// usageError is returned for invalid command lines.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// jobStarted is returned when a job is left running.
+type jobStarted struct{}
+
+func (jobStarted) Error() string {
+	return "job started"
+}
+
+// Run runs lsmgo with the arguments following the program name and returns
+// the exit code.
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	t := &tool{
+		ctx:      context.Background(),
+		stdout:   stdout,
+		stderr:   stderr,
+		format:   newChoice("table", "table", "json", "csv"),
+		commands: commands,
+	}
+
+	global := t.globalFlags()
+	uri := global.String("u", getEnv("LSMCLI_URI", "sim://"), "plugin URI, default $LSMCLI_URI")
+	password := global.String("P", os.Getenv("LSMCLI_PASSWORD"), "plugin password, default $LSMCLI_PASSWORD")
+	timeout := global.Uint("timeout", 30000, "plugin timeout in milliseconds")
+
+	if err := global.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return exitUsage
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return exitUsage
+	}
+
+	cmd := t.command(global.Arg(0))
+	if cmd == nil {
+		fmt.Fprintf(stderr, "lsmgo: unknown command %s\n", global.Arg(0))
+		global.Usage()
+		return exitUsage
+	}
+
+	f := flag.NewFlagSet("lsmgo "+cmd.name, flag.ContinueOnError)
+	f.SetOutput(stderr)
+	f.Usage = func() {
+		fmt.Fprintf(stderr, "usage: lsmgo %s [flags]\n\n%s\n\n", cmd.name, cmd.help)
+		f.PrintDefaults()
+	}
+	run := cmd.setup(f)
+
+	if err := f.Parse(global.Args()[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return exitUsage
+	}
+	if f.NArg() > 0 {
+		fmt.Fprintf(stderr, "lsmgo: unexpected arguments %s\n", strings.Join(f.Args(), " "))
+		f.Usage()
+		return exitUsage
+	}
+
+	if !cmd.offline {
+		c, err := lsm.Client(*uri, *password, uint32(*timeout))
+		if err != nil {
+			return t.exit(err)
+		}
+		defer c.Close()
+		t.client = c
+	}
+
+	err := run(t)
+	if _, ok := err.(usageError); ok {
+		fmt.Fprintf(stderr, "lsmgo: %s\n", err)
+		f.Usage()
+		return exitUsage
+	}
+	return t.exit(err)
+}
+
+// globalFlags returns the flags shared by all commands, less the connection
+// ones.
+func (t *tool) globalFlags() *flag.FlagSet {
+	global := flag.NewFlagSet("lsmgo", flag.ContinueOnError)
+	global.SetOutput(t.stderr)
+	global.Var(t.format, "o", "output format, one of "+strings.Join(t.format.choices, ", "))
+	global.BoolVar(&t.async, "async", false, "don't wait for jobs, print the job ID instead")
+	global.BoolVar(&t.async, "b", false, "short for -async")
+	global.Usage = func() {
+		fmt.Fprintf(t.stderr, "usage: lsmgo [flags] command [command flags]\n\nflags:\n")
+		global.PrintDefaults()
+		fmt.Fprintf(t.stderr, "\ncommands:\n")
+		for _, c := range t.commands {
+			fmt.Fprintf(t.stderr, "  %-32s %s\n", c.name, c.help)
+		}
+	}
+	return global
+}
+
+func (t *tool) command(name string) *command {
+	for i := range t.commands {
+		if t.commands[i].name == name {
+			return &t.commands[i]
+		}
+	}
+	return nil
+}
+
+// exit reports err and returns the exit code for it.
+func (t *tool) exit(err error) int {
+	switch err.(type) {
+	case nil:
+		return 0
+	case jobStarted:
+		return int(errors.JobStarted)
+	}
+
+	fmt.Fprintf(t.stderr, "lsmgo: %s\n", err)
+	code, ok := errors.CodeOf(err)
+	switch {
+	case !ok || code <= 0 || code > 255:
+		return exitFailure
+	case code == exitFailure || code == exitUsage || code == errors.JobStarted:
+		return exitReserved
+	}
+	return int(code)
+}
+
+// done finishes a call which may have started a job, the job is waited for
+// unless -async was given, then its ID is printed if it is left running.
+func (t *tool) done(item interface{}, job *lsm.Job, err error) error {
+	if err != nil {
+		return err
+	}
+	if job != nil {
+		if t.async && len(job.ID) > 0 {
+			fmt.Fprintln(t.stdout, job.ID)
+			return jobStarted{}
+		}
+		if err := job.Wait(t.ctx); err != nil {
+			return err
+		}
+	}
+	if item == nil || isNil(item) {
+		return nil
+	}
+	return t.print(item)
+}
+
+// need returns a usage error unless the flags are set.
+func need(f *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if len(f.Lookup(name).Value.String()) == 0 {
+			return usageError(fmt.Sprintf("-%s is required", name))
+		}
+	}
+	return nil
+}
+
+func getEnv(variable string, defValue string) string {
+	if v := os.Getenv(variable); len(v) > 0 {
+		return v
+	}
+	return defValue
+}
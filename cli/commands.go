@@ -0,0 +1,904 @@
+// SPDX-License-Identifier: 0BSD
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+	"github.com/libstorage/libstoragemgmt-golang/inventory"
+	"github.com/libstorage/libstoragemgmt-golang/reconcile"
+)
+
+// listTypes are the types of list -type, with their aliases.
+var listTypes = map[string]string{
+	"systems":       "systems",
+	"pools":         "pools",
+	"volumes":       "volumes",
+	"disks":         "disks",
+	"fs":            "fs",
+	"exports":       "exports",
+	"nfs_exports":   "exports",
+	"access_groups": "access_groups",
+	"ags":           "access_groups",
+	"target_ports":  "target_ports",
+	"batteries":     "batteries",
+	"snapshots":     "snapshots",
+}
+
+var commands = []command{
+	{name: "list", help: "List systems, pools, volumes, disks, file systems, exports, access groups, target ports, batteries or snapshots",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			kind := choiceOf("", listTypes)
+			f.Var(kind, "type", kind.usage("what to list"))
+			id := f.String("id", "", "only the item with this ID")
+			sys := f.String("sys", "", "only items of this system")
+			pool := f.String("pool", "", "only items of this pool")
+			fs := f.String("fs", "", "only exports of this file system, required for snapshots")
+			return func(t *tool) error {
+				if err := need(f, "type"); err != nil {
+					return err
+				}
+				items, err := t.list(listTypes[kind.value], *fs)
+				if err != nil {
+					return err
+				}
+
+				want := map[string]string{"id": *id, "system_id": *sys, "pool_id": *pool}
+				if listTypes[kind.value] != "snapshots" {
+					want["fs_id"] = *fs
+				}
+				if items, err = filter(items, want); err != nil {
+					return err
+				}
+				return t.print(items)
+			}
+		}},
+
+	{name: "inventory", help: "Show everything the plugin manages and how it is linked, as JSON or Graphviz DOT",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			dot := f.Bool("dot", false, "write Graphviz DOT instead of JSON")
+			return func(t *tool) error {
+				inv, err := inventory.Collect(t.ctx, t.client)
+				if err != nil {
+					return err
+				}
+				if *dot {
+					return inv.WriteDOT(t.stdout)
+				}
+				out, err := json.MarshalIndent(inv, "", "  ")
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(t.stdout, string(out))
+				return err
+			}
+		}},
+
+	{name: "apply", help: "Bring volumes, access groups, masking and NFS exports to the state in a YAML or JSON file",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			file := f.String("file", "", "state file")
+			dryRun := f.Bool("dry-run", false, "only show the plan")
+			prune := f.String("prune", "", "delete what is named with this prefix and not in the state")
+			return func(t *tool) error {
+				if err := need(f, "file"); err != nil {
+					return err
+				}
+				data, err := ioutil.ReadFile(*file)
+				if err != nil {
+					return err
+				}
+				state, err := reconcile.Parse(data)
+				if err != nil {
+					return err
+				}
+				plan, err := reconcile.Compute(t.ctx, t.client, state, reconcile.Options{Prune: *prune})
+				if err != nil {
+					return err
+				}
+				if _, err = fmt.Fprint(t.stdout, plan); err != nil || *dryRun {
+					return err
+				}
+				return plan.Apply(t.ctx, t.client)
+			}
+		}},
+
+	{name: "plugin-info", help: "Show the plugin description and version",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			return func(t *tool) error {
+				info, err := t.client.PluginInfoCtx(t.ctx)
+				if err != nil {
+					return err
+				}
+				return t.print(info)
+			}
+		}},
+
+	{name: "capabilities", help: "List the capabilities of a system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			sys := f.String("sys", "", "system ID")
+			return func(t *tool) error {
+				if err := need(f, "sys"); err != nil {
+					return err
+				}
+				system, err := t.system(*sys)
+				if err != nil {
+					return err
+				}
+				caps, err := t.client.CapabilitiesCtx(t.ctx, system)
+				if err != nil {
+					return err
+				}
+				type capability struct {
+					Name string `json:"name"`
+				}
+				supported := []capability{}
+				for _, c := range caps.Supported() {
+					supported = append(supported, capability{c.String()})
+				}
+				return t.print(supported)
+			}
+		}},
+
+	{name: "job-status", help: "Show the progress of a job, the result once it completed",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			job := f.String("job", "", "job ID")
+			return func(t *tool) error {
+				if err := need(f, "job"); err != nil {
+					return err
+				}
+				return t.jobStatus(*job)
+			}
+		}},
+
+	{name: "volume-create", help: "Create a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			name := f.String("name", "", "volume name")
+			pool := f.String("pool", "", "pool ID")
+			var bytes size
+			f.Var(&bytes, "size", "size, eg. 10GiB")
+			provisioning := choiceOf("default", provisionTypes)
+			f.Var(provisioning, "provisioning", provisioning.usage("provisioning"))
+			return func(t *tool) error {
+				if err := need(f, "name", "pool", "size"); err != nil {
+					return err
+				}
+				p, err := t.pool(*pool)
+				if err != nil {
+					return err
+				}
+				return t.done(t.client.VolumeCreateSize(
+					t.ctx, p, *name, lsm.Size(bytes), provisionTypes[provisioning.value]))
+			}
+		}},
+
+	{name: "volume-delete", help: "Delete a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			return func(t *tool) error {
+				if err := need(f, "vol"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				job, err := t.client.VolumeDeleteJob(t.ctx, v)
+				return t.done(nil, job, err)
+			}
+		}},
+
+	{name: "volume-resize", help: "Resize a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			var bytes size
+			f.Var(&bytes, "size", "new size, eg. 10GiB")
+			return func(t *tool) error {
+				if err := need(f, "vol", "size"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				return t.done(t.client.VolumeResizeSize(t.ctx, v, lsm.Size(bytes)))
+			}
+		}},
+
+	{name: "volume-replicate", help: "Replicate a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "source volume ID")
+			name := f.String("name", "", "name of the new volume")
+			pool := f.String("pool", "", "pool ID of the new volume, default the pool of the source")
+			repType := choiceOf("", replicateTypes)
+			f.Var(repType, "rep-type", repType.usage("replication type"))
+			return func(t *tool) error {
+				if err := need(f, "vol", "name", "rep-type"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				var p *lsm.Pool
+				if len(*pool) > 0 {
+					if p, err = t.pool(*pool); err != nil {
+						return err
+					}
+				}
+				return t.done(t.client.VolumeReplicateJob(
+					t.ctx, p, replicateTypes[repType.value], v, *name))
+			}
+		}},
+
+	{name: "volume-enable", help: "Enable access to a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			return func(t *tool) error {
+				if err := need(f, "vol"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				return t.client.VolumeEnableCtx(t.ctx, v)
+			}
+		}},
+
+	{name: "volume-disable", help: "Disable access to a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			return func(t *tool) error {
+				if err := need(f, "vol"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				return t.client.VolumeDisableCtx(t.ctx, v)
+			}
+		}},
+
+	{name: "volume-mask", help: "Grant an access group access to a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			ag := f.String("ag", "", "access group ID")
+			return func(t *tool) error {
+				if err := need(f, "vol", "ag"); err != nil {
+					return err
+				}
+				v, a, err := t.volumeAndGroup(*vol, *ag)
+				if err != nil {
+					return err
+				}
+				return t.client.VolumeMaskCtx(t.ctx, v, a)
+			}
+		}},
+
+	{name: "volume-unmask", help: "Revoke the access of an access group to a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			ag := f.String("ag", "", "access group ID")
+			return func(t *tool) error {
+				if err := need(f, "vol", "ag"); err != nil {
+					return err
+				}
+				v, a, err := t.volumeAndGroup(*vol, *ag)
+				if err != nil {
+					return err
+				}
+				return t.client.VolumeUnMaskCtx(t.ctx, v, a)
+			}
+		}},
+
+	{name: "volume-access-group", help: "List the access groups granted access to a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			return func(t *tool) error {
+				if err := need(f, "vol"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				groups, err := t.client.AgsGrantedToVolCtx(t.ctx, v)
+				if err != nil {
+					return err
+				}
+				return t.print(groups)
+			}
+		}},
+
+	{name: "access-group-create", help: "Create an access group with one initiator",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			name := f.String("name", "", "access group name")
+			initID := f.String("init", "", "initiator ID, eg. an iSCSI IQN or WWPN")
+			initType := choiceOf("iscsi", initiatorTypes)
+			f.Var(initType, "init-type", initType.usage("initiator type"))
+			sys := f.String("sys", "", "system ID")
+			return func(t *tool) error {
+				if err := need(f, "name", "init", "sys"); err != nil {
+					return err
+				}
+				s, err := t.system(*sys)
+				if err != nil {
+					return err
+				}
+				ag, err := t.client.AccessGroupCreateCtx(t.ctx, *name, *initID, initiatorTypes[initType.value], s)
+				return t.done(ag, nil, err)
+			}
+		}},
+
+	{name: "access-group-delete", help: "Delete an access group",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			ag := f.String("ag", "", "access group ID")
+			return func(t *tool) error {
+				if err := need(f, "ag"); err != nil {
+					return err
+				}
+				a, err := t.accessGroup(*ag)
+				if err != nil {
+					return err
+				}
+				return t.client.AccessGroupDeleteCtx(t.ctx, a)
+			}
+		}},
+
+	{name: "access-group-add", help: "Add an initiator to an access group",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			ag := f.String("ag", "", "access group ID")
+			initID := f.String("init", "", "initiator ID")
+			initType := choiceOf("iscsi", initiatorTypes)
+			f.Var(initType, "init-type", initType.usage("initiator type"))
+			return func(t *tool) error {
+				if err := need(f, "ag", "init"); err != nil {
+					return err
+				}
+				a, err := t.accessGroup(*ag)
+				if err != nil {
+					return err
+				}
+				changed, err := t.client.AccessGroupInitAddCtx(t.ctx, a, *initID, initiatorTypes[initType.value])
+				return t.done(changed, nil, err)
+			}
+		}},
+
+	{name: "access-group-remove", help: "Remove an initiator from an access group",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			ag := f.String("ag", "", "access group ID")
+			initID := f.String("init", "", "initiator ID")
+			initType := choiceOf("iscsi", initiatorTypes)
+			f.Var(initType, "init-type", initType.usage("initiator type"))
+			return func(t *tool) error {
+				if err := need(f, "ag", "init"); err != nil {
+					return err
+				}
+				a, err := t.accessGroup(*ag)
+				if err != nil {
+					return err
+				}
+				changed, err := t.client.AccessGroupInitDeleteCtx(t.ctx, a, *initID, initiatorTypes[initType.value])
+				return t.done(changed, nil, err)
+			}
+		}},
+
+	{name: "access-group-volumes", help: "List the volumes an access group has access to",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			ag := f.String("ag", "", "access group ID")
+			return func(t *tool) error {
+				if err := need(f, "ag"); err != nil {
+					return err
+				}
+				a, err := t.accessGroup(*ag)
+				if err != nil {
+					return err
+				}
+				volumes, err := t.client.VolsMaskedToAgCtx(t.ctx, a)
+				if err != nil {
+					return err
+				}
+				return t.print(volumes)
+			}
+		}},
+
+	{name: "fs-create", help: "Create a file system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			name := f.String("name", "", "file system name")
+			pool := f.String("pool", "", "pool ID")
+			var bytes size
+			f.Var(&bytes, "size", "size, eg. 10GiB")
+			return func(t *tool) error {
+				if err := need(f, "name", "pool", "size"); err != nil {
+					return err
+				}
+				p, err := t.pool(*pool)
+				if err != nil {
+					return err
+				}
+				return t.done(t.client.FsCreateSize(t.ctx, p, *name, lsm.Size(bytes)))
+			}
+		}},
+
+	{name: "fs-delete", help: "Delete a file system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			fs := f.String("fs", "", "file system ID")
+			return func(t *tool) error {
+				if err := need(f, "fs"); err != nil {
+					return err
+				}
+				item, err := t.fs(*fs)
+				if err != nil {
+					return err
+				}
+				job, err := t.client.FsDeleteJob(t.ctx, item)
+				return t.done(nil, job, err)
+			}
+		}},
+
+	{name: "fs-resize", help: "Resize a file system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			fs := f.String("fs", "", "file system ID")
+			var bytes size
+			f.Var(&bytes, "size", "new size, eg. 10GiB")
+			return func(t *tool) error {
+				if err := need(f, "fs", "size"); err != nil {
+					return err
+				}
+				item, err := t.fs(*fs)
+				if err != nil {
+					return err
+				}
+				return t.done(t.client.FsResizeSize(t.ctx, item, lsm.Size(bytes)))
+			}
+		}},
+
+	{name: "fs-clone", help: "Clone a file system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			fs := f.String("src-fs", "", "source file system ID")
+			name := f.String("dst-name", "", "name of the clone")
+			snap := f.String("snap", "", "clone this snapshot of the source instead")
+			return func(t *tool) error {
+				if err := need(f, "src-fs", "dst-name"); err != nil {
+					return err
+				}
+				item, ss, err := t.fsAndSnapShot(*fs, *snap)
+				if err != nil {
+					return err
+				}
+				return t.done(t.client.FsCloneJob(t.ctx, item, *name, ss))
+			}
+		}},
+
+	{name: "fs-export", help: "Export a file system over NFS",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			fs := f.String("fs", "", "file system ID")
+			path := f.String("exportpath", "", "export path, default chosen by the plugin")
+			var rw, ro, root stringList
+			f.Var(&rw, "rw-host", "host with read write access, may be repeated")
+			f.Var(&ro, "ro-host", "host with read only access, may be repeated")
+			f.Var(&root, "root-host", "host with root access, may be repeated")
+			anonUID := f.Int64("anon-uid", lsm.AnonUIDGIDNotApplicable, "UID anonymous users map to")
+			anonGID := f.Int64("anon-gid", lsm.AnonUIDGIDNotApplicable, "GID anonymous users map to")
+			auth := f.String("auth-type", "", "authentication type, default chosen by the plugin")
+			options := f.String("options", "", "export options")
+			return func(t *tool) error {
+				if err := need(f, "fs"); err != nil {
+					return err
+				}
+				item, err := t.fs(*fs)
+				if err != nil {
+					return err
+				}
+				access := lsm.NfsAccess{Root: root, Rw: rw, Ro: ro, AnonUID: *anonUID, AnonGID: *anonGID}
+				export, err := t.client.FsExportCtx(
+					t.ctx, item, optional(*path), &access, optional(*auth), optional(*options))
+				return t.done(export, nil, err)
+			}
+		}},
+
+	{name: "fs-unexport", help: "Remove an NFS export",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			export := f.String("export", "", "export ID")
+			return func(t *tool) error {
+				if err := need(f, "export"); err != nil {
+					return err
+				}
+				exports, err := t.client.NfsExportsBy(t.ctx, lsm.ByID(*export))
+				if err != nil {
+					return err
+				}
+				if len(exports) == 0 {
+					return notFound(errors.NotFoundNfsExport, "export", *export)
+				}
+				return t.client.FsUnExportCtx(t.ctx, &exports[0])
+			}
+		}},
+
+	{name: "fs-snap-create", help: "Create a snapshot of a file system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			fs := f.String("fs", "", "file system ID")
+			name := f.String("name", "", "snapshot name")
+			return func(t *tool) error {
+				if err := need(f, "fs", "name"); err != nil {
+					return err
+				}
+				item, err := t.fs(*fs)
+				if err != nil {
+					return err
+				}
+				return t.done(t.client.FsSnapShotCreateJob(t.ctx, item, *name))
+			}
+		}},
+
+	{name: "fs-snap-delete", help: "Delete a snapshot of a file system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			fs := f.String("fs", "", "file system ID")
+			snap := f.String("snap", "", "snapshot ID")
+			return func(t *tool) error {
+				if err := need(f, "fs", "snap"); err != nil {
+					return err
+				}
+				item, ss, err := t.fsAndSnapShot(*fs, *snap)
+				if err != nil {
+					return err
+				}
+				job, err := t.client.FsSnapShotDeleteJob(t.ctx, item, ss)
+				return t.done(nil, job, err)
+			}
+		}},
+
+	{name: "fs-snap-restore", help: "Restore a file system, or some of its files, from a snapshot",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			fs := f.String("fs", "", "file system ID")
+			snap := f.String("snap", "", "snapshot ID")
+			var files, restoreFiles stringList
+			f.Var(&files, "file", "only restore this file, may be repeated")
+			f.Var(&restoreFiles, "restore-file", "restore the -file of the same position under this name, may be repeated")
+			return func(t *tool) error {
+				if err := need(f, "fs", "snap"); err != nil {
+					return err
+				}
+				if len(restoreFiles) > 0 && len(restoreFiles) != len(files) {
+					return usageError("-restore-file must be given once for every -file")
+				}
+				item, ss, err := t.fsAndSnapShot(*fs, *snap)
+				if err != nil {
+					return err
+				}
+				job, err := t.client.FsSnapShotRestoreJob(
+					t.ctx, item, ss, len(files) == 0, files, restoreFiles)
+				return t.done(nil, job, err)
+			}
+		}},
+
+	{name: "volume-raid-create", help: "Create a RAID volume from free disks",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			name := f.String("name", "", "volume name")
+			raidType := choiceOf("", raidTypes)
+			f.Var(raidType, "raid-type", raidType.usage("RAID type"))
+			var disks stringList
+			f.Var(&disks, "disk", "disk ID, repeated for every member")
+			var stripSize size
+			f.Var(&stripSize, "strip-size", "strip size, eg. 64KiB, default chosen by the plugin")
+			return func(t *tool) error {
+				if err := need(f, "name", "raid-type", "disk"); err != nil {
+					return err
+				}
+				members, err := t.disks(disks)
+				if err != nil {
+					return err
+				}
+				volume, err := t.client.VolRaidCreateCtx(
+					t.ctx, *name, raidTypes[raidType.value], members, uint32(stripSize))
+				return t.done(volume, nil, err)
+			}
+		}},
+
+	{name: "volume-raid-create-cap", help: "Show the RAID types and strip sizes volume-raid-create supports",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			sys := f.String("sys", "", "system ID")
+			return func(t *tool) error {
+				if err := need(f, "sys"); err != nil {
+					return err
+				}
+				s, err := t.system(*sys)
+				if err != nil {
+					return err
+				}
+				supported, err := t.client.VolRaidCreateCapGetCtx(t.ctx, s)
+				return t.done(supported, nil, err)
+			}
+		}},
+
+	{name: "volume-raid-info", help: "Show the RAID settings of a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			return func(t *tool) error {
+				if err := need(f, "vol"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				info, err := t.client.VolRaidInfoCtx(t.ctx, v)
+				return t.done(info, nil, err)
+			}
+		}},
+
+	{name: "pool-member-info", help: "Show the RAID type and members of a pool",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			pool := f.String("pool", "", "pool ID")
+			return func(t *tool) error {
+				if err := need(f, "pool"); err != nil {
+					return err
+				}
+				p, err := t.pool(*pool)
+				if err != nil {
+					return err
+				}
+				info, err := t.client.PoolMemberInfoCtx(t.ctx, p)
+				return t.done(info, nil, err)
+			}
+		}},
+
+	{name: "system-read-cache-pct-update", help: "Change the read cache percentage of a system",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			sys := f.String("sys", "", "system ID")
+			pct := f.Uint("read-pct", 0, "percentage of the cache used for reads")
+			return func(t *tool) error {
+				if err := need(f, "sys"); err != nil {
+					return err
+				}
+				s, err := t.system(*sys)
+				if err != nil {
+					return err
+				}
+				return t.client.SysReadCachePctSetCtx(t.ctx, s, uint32(*pct))
+			}
+		}},
+
+	{name: "volume-cache-info", help: "Show the cache settings of a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			return func(t *tool) error {
+				if err := need(f, "vol"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				info, err := t.client.VolCacheInfoCtx(t.ctx, v)
+				return t.done(info, nil, err)
+			}
+		}},
+
+	{name: "volume-write-cache-policy-update", help: "Change the write cache policy of a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			policy := choiceOf("", writeCachePolicies)
+			f.Var(policy, "policy", policy.usage("write back, auto or write through"))
+			return func(t *tool) error {
+				if err := need(f, "vol", "policy"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				return t.client.VolWriteCacheSetCtx(t.ctx, v, writeCachePolicies[policy.value])
+			}
+		}},
+
+	{name: "volume-read-cache-policy-update", help: "Change the read cache policy of a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			policy := choiceOf("", readCachePolicies)
+			f.Var(policy, "policy", policy.usage("read cache"))
+			return func(t *tool) error {
+				if err := need(f, "vol", "policy"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				return t.client.VolReadCacheSetCtx(t.ctx, v, readCachePolicies[policy.value])
+			}
+		}},
+
+	{name: "volume-phy-disk-cache-update", help: "Change the physical disk cache setting of a volume",
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			vol := f.String("vol", "", "volume ID")
+			policy := choiceOf("", phyDiskCaches)
+			f.Var(policy, "policy", policy.usage("physical disk cache"))
+			return func(t *tool) error {
+				if err := need(f, "vol", "policy"); err != nil {
+					return err
+				}
+				v, err := t.volume(*vol)
+				if err != nil {
+					return err
+				}
+				return t.client.VolPhyDiskCacheSetCtx(t.ctx, v, phyDiskCaches[policy.value])
+			}
+		}},
+
+	{name: "completion", help: "Print the shell completion script, eg. source <(lsmgo completion bash)", offline: true,
+		setup: func(f *flag.FlagSet) func(t *tool) error {
+			shell := newChoice("bash", "bash")
+			f.Var(shell, "shell", shell.usage("shell"))
+			return func(t *tool) error {
+				return t.completion()
+			}
+		}},
+}
+
+// list returns the items of kind, snapshots are those of the file system
+// fsID.
+func (t *tool) list(kind string, fsID string) (interface{}, error) {
+	switch kind {
+	case "systems":
+		return t.client.SystemsCtx(t.ctx)
+	case "pools":
+		return t.client.PoolsCtx(t.ctx)
+	case "volumes":
+		return t.client.VolumesCtx(t.ctx)
+	case "disks":
+		return t.client.DisksCtx(t.ctx)
+	case "fs":
+		return t.client.FileSystemsCtx(t.ctx)
+	case "exports":
+		return t.client.NfsExportsCtx(t.ctx)
+	case "access_groups":
+		return t.client.AccessGroupsCtx(t.ctx)
+	case "target_ports":
+		return t.client.TargetPortsCtx(t.ctx)
+	case "batteries":
+		return t.client.BatteriesCtx(t.ctx)
+	case "snapshots":
+		if len(fsID) == 0 {
+			return nil, usageError("-fs is required to list snapshots")
+		}
+		fs, err := t.fs(fsID)
+		if err != nil {
+			return nil, err
+		}
+		return t.client.FsSnapShotsCtx(t.ctx, fs)
+	}
+	return nil, usageError(fmt.Sprintf("can't list %s", kind))
+}
+
+// jobStatus shows the progress of the job, or its result and frees it once
+// it has completed.
+func (t *tool) jobStatus(id string) error {
+	var result json.RawMessage
+	status, percent, err := t.client.Job(id, &result).Status(t.ctx)
+	if err != nil {
+		return err
+	}
+
+	if status == lsm.JobStatusInprogress {
+		fmt.Fprintf(t.stdout, "%d%%\n", percent)
+		return jobStarted{}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+
+	var class struct {
+		Class string `json:"class"`
+	}
+	if err := json.Unmarshal(result, &class); err != nil {
+		return err
+	}
+
+	var item interface{}
+	switch class.Class {
+	case "Volume":
+		item = &lsm.Volume{}
+	case "FileSystem":
+		item = &lsm.FileSystem{}
+	case "FsSnapshot":
+		item = &lsm.FileSystemSnapShot{}
+	default:
+		_, err := fmt.Fprintln(t.stdout, string(result))
+		return err
+	}
+	if err := json.Unmarshal(result, item); err != nil {
+		return err
+	}
+	return t.print(item)
+}
+
+// completion writes a bash completion script for the commands and their
+// flags, completing the values of the flags with fixed choices.
+func (t *tool) completion() error {
+	global := t.globalFlags()
+	global.String("u", "", "")
+	global.String("P", "", "")
+	global.Uint("timeout", 0, "")
+
+	var names []string
+	for _, c := range t.commands {
+		names = append(names, c.name)
+	}
+
+	var b strings.Builder
+	b.WriteString("# bash completion for lsmgo\n_lsmgo()\n{\n")
+	b.WriteString("    local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]} cmd= words= i\n")
+	b.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("        case ${COMP_WORDS[i]} in\n")
+	fmt.Fprintf(&b, "        %s) ((i++)) ;;\n", strings.Join(valueFlags(global), "|"))
+	b.WriteString("        -*) ;;\n")
+	b.WriteString("        *) cmd=${COMP_WORDS[i]}; break ;;\n")
+	b.WriteString("        esac\n    done\n\n")
+
+	b.WriteString("    case $cmd in\n")
+	b.WriteString("    \"\")\n")
+	completeFlags(&b, global, strings.Join(names, " "))
+	for _, c := range t.commands {
+		f := flag.NewFlagSet(c.name, flag.ContinueOnError)
+		c.setup(f)
+		fmt.Fprintf(&b, "    %s)\n", c.name)
+		completeFlags(&b, f, "")
+	}
+	b.WriteString("    esac\n\n")
+	b.WriteString("    COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n}\n")
+	b.WriteString("complete -F _lsmgo lsmgo\n")
+
+	_, err := fmt.Fprint(t.stdout, b.String())
+	return err
+}
+
+// completeFlags writes the case completing the flags of f and the values of
+// those with fixed choices, extra words are offered along with the flags.
+func completeFlags(b *strings.Builder, f *flag.FlagSet, extra string) {
+	var words, values []string
+	f.VisitAll(func(fl *flag.Flag) {
+		words = append(words, "-"+fl.Name)
+		if c, ok := fl.Value.(*choice); ok {
+			values = append(values, fmt.Sprintf(
+				"        -%s|--%s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")); return ;;\n",
+				fl.Name, fl.Name, strings.Join(c.choices, " ")))
+		}
+	})
+
+	if len(values) > 0 {
+		b.WriteString("        case $prev in\n")
+		b.WriteString(strings.Join(values, ""))
+		b.WriteString("        esac\n")
+	}
+	if len(extra) > 0 {
+		words = append(words, extra)
+	}
+	fmt.Fprintf(b, "        words=\"%s\" ;;\n", strings.Join(words, " "))
+}
+
+// valueFlags returns the flags of f taking a value as bash patterns.
+func valueFlags(f *flag.FlagSet) []string {
+	var patterns []string
+	f.VisitAll(func(fl *flag.Flag) {
+		if b, ok := fl.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			return
+		}
+		patterns = append(patterns, "-"+fl.Name, "--"+fl.Name)
+	})
+	return patterns
+}
+
+func optional(s string) *string {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: 0BSD
+
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// The names accepted for the enumerated types.
+var (
+	provisionTypes = map[string]lsm.VolumeProvisionType{
+		"default": lsm.VolumeProvisionTypeDefault,
+		"thin":    lsm.VolumeProvisionTypeThin,
+		"full":    lsm.VolumeProvisionTypeFull,
+	}
+
+	replicateTypes = map[string]lsm.VolumeReplicateType{
+		"clone":        lsm.VolumeReplicateTypeClone,
+		"copy":         lsm.VolumeReplicateTypeCopy,
+		"mirror_sync":  lsm.VolumeReplicateTypeMirrorSync,
+		"mirror_async": lsm.VolumeReplicateTypeMirrorAsync,
+	}
+
+	raidTypes = map[string]lsm.RaidType{
+		"RAID0":  lsm.Raid0,
+		"RAID1":  lsm.Raid1,
+		"RAID5":  lsm.Raid5,
+		"RAID6":  lsm.Raid6,
+		"RAID10": lsm.Raid10,
+		"RAID50": lsm.Raid50,
+		"RAID60": lsm.Raid60,
+	}
+
+	initiatorTypes = map[string]lsm.InitiatorType{
+		"iscsi": lsm.InitiatorTypeIscsiIqn,
+		"wwpn":  lsm.InitiatorTypeWwpn,
+	}
+
+	writeCachePolicies = map[string]lsm.WriteCachePolicy{
+		"WB":   lsm.WriteCachePolicyWriteBack,
+		"AUTO": lsm.WriteCachePolicyAuto,
+		"WT":   lsm.WriteCachePolicyWriteThrough,
+	}
+
+	readCachePolicies = map[string]lsm.ReadCachePolicy{
+		"enable":  lsm.ReadCachePolicyEnabled,
+		"disable": lsm.ReadCachePolicyDisabled,
+	}
+
+	phyDiskCaches = map[string]lsm.PhysicalDiskCache{
+		"enable":           lsm.PhysicalDiskCacheEnabled,
+		"disable":          lsm.PhysicalDiskCacheDisabled,
+		"use_disk_setting": lsm.PhysicalDiskCacheUseDiskSetting,
+	}
+)
+
+// choice is a flag value which must be one of a fixed set.
+type choice struct {
+	value   string
+	choices []string
+}
+
+func newChoice(value string, choices ...string) *choice {
+	return &choice{value: value, choices: choices}
+}
+
+// choiceOf returns a choice of the keys of the map m.
+func choiceOf(value string, m interface{}) *choice {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return newChoice(value, keys...)
+}
+
+func (c *choice) String() string {
+	if c == nil {
+		return ""
+	}
+	return c.value
+}
+
+func (c *choice) Set(s string) error {
+	for _, v := range c.choices {
+		if strings.EqualFold(v, s) {
+			c.value = v
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(c.choices, ", "))
+}
+
+func (c *choice) usage(what string) string {
+	return fmt.Sprintf("%s, one of %s", what, strings.Join(c.choices, ", "))
+}
+
+// stringList is a flag which may be given more than once.
+type stringList []string
+
+func (l *stringList) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// size is a flag taking a size in bytes with an optional unit, eg. 10GiB.
+type size lsm.Size
+
+func (s *size) String() string {
+	if s == nil || *s == 0 {
+		return ""
+	}
+	return lsm.Size(*s).String()
+}
+
+func (s *size) Set(value string) error {
+	if err := (*lsm.Size)(s).Set(value); err != nil {
+		return fmt.Errorf("%s", errors.MessageOf(err))
+	}
+	return nil
+}
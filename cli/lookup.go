@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: 0BSD
+
+package cli
+
+import (
+	"fmt"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// The lookups turn the IDs given on the command line into the items the
+// client calls take.
+
+func (t *tool) system(id string) (*lsm.System, error) {
+	systems, err := t.client.SystemsCtx(t.ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range systems {
+		if systems[i].ID == id {
+			return &systems[i], nil
+		}
+	}
+	return nil, notFound(errors.NotFoundSystem, "system", id)
+}
+
+func (t *tool) pool(id string) (*lsm.Pool, error) {
+	pools, err := t.client.PoolsBy(t.ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		return nil, notFound(errors.NotFoundPool, "pool", id)
+	}
+	return &pools[0], nil
+}
+
+func (t *tool) volume(id string) (*lsm.Volume, error) {
+	volumes, err := t.client.VolumesBy(t.ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(volumes) == 0 {
+		return nil, notFound(errors.NotFoundVolume, "volume", id)
+	}
+	return &volumes[0], nil
+}
+
+func (t *tool) fs(id string) (*lsm.FileSystem, error) {
+	fileSystems, err := t.client.FileSystemsBy(t.ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(fileSystems) == 0 {
+		return nil, notFound(errors.NotFoundFs, "file system", id)
+	}
+	return &fileSystems[0], nil
+}
+
+func (t *tool) accessGroup(id string) (*lsm.AccessGroup, error) {
+	groups, err := t.client.AccessGroupsCtx(t.ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i].ID == id {
+			return &groups[i], nil
+		}
+	}
+	return nil, notFound(errors.NotFoundAccessGroup, "access group", id)
+}
+
+func (t *tool) volumeAndGroup(id string, agID string) (*lsm.Volume, *lsm.AccessGroup, error) {
+	volume, err := t.volume(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	ag, err := t.accessGroup(agID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return volume, ag, nil
+}
+
+// fsAndSnapShot looks up the file system and, if snapID isn't empty, its
+// snapshot.
+func (t *tool) fsAndSnapShot(fsID string, snapID string) (*lsm.FileSystem, *lsm.FileSystemSnapShot, error) {
+	fs, err := t.fs(fsID)
+	if err != nil || len(snapID) == 0 {
+		return fs, nil, err
+	}
+
+	snapShots, err := t.client.FsSnapShotsCtx(t.ctx, fs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range snapShots {
+		if snapShots[i].ID == snapID {
+			return fs, &snapShots[i], nil
+		}
+	}
+	return nil, nil, notFound(errors.NotFoundFsSs, "snapshot", snapID)
+}
+
+func (t *tool) disks(ids []string) ([]lsm.Disk, error) {
+	disks, err := t.client.DisksCtx(t.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []lsm.Disk
+	for _, id := range ids {
+		var disk *lsm.Disk
+		for i := range disks {
+			if disks[i].ID == id {
+				disk = &disks[i]
+			}
+		}
+		if disk == nil {
+			return nil, notFound(errors.NotFoundDisk, "disk", id)
+		}
+		found = append(found, *disk)
+	}
+	return found, nil
+}
+
+func notFound(code int32, what string, id string) error {
+	return &errors.LsmError{
+		Code:    code,
+		Message: fmt.Sprintf("%s %s not found", what, id)}
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: 0BSD
+
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// print writes v, an item or a slice of them, in the output format.
+func (t *tool) print(v interface{}) error {
+	if t.format.value == "json" {
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(t.stdout, string(out))
+		return err
+	}
+
+	header, rows := table(v)
+	if t.format.value == "csv" {
+		w := csv.NewWriter(t.stdout)
+		w.Write(header)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+
+	w := tabwriter.NewWriter(t.stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// table returns the columns and rows of v, the columns being the exported
+// fields by their JSON names.
+func table(v interface{}) ([]string, [][]string) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+
+	var elem reflect.Type
+	var items []reflect.Value
+	if rv.Kind() == reflect.Slice {
+		elem = rv.Type().Elem()
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, rv.Index(i))
+		}
+	} else {
+		elem = rv.Type()
+		items = []reflect.Value{rv}
+	}
+
+	if elem.Kind() != reflect.Struct {
+		var rows [][]string
+		for _, item := range items {
+			rows = append(rows, []string{cell(item)})
+		}
+		return []string{"value"}, rows
+	}
+
+	var header []string
+	var fields []int
+	for i := 0; i < elem.NumField(); i++ {
+		if name := column(elem.Field(i)); len(name) > 0 {
+			header = append(header, name)
+			fields = append(fields, i)
+		}
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = cell(item.Field(field))
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+// column returns the column name of the field, empty for fields not shown.
+func column(f reflect.StructField) string {
+	if len(f.PkgPath) > 0 {
+		return ""
+	}
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if len(name) == 0 {
+		name = f.Name
+	}
+	switch name {
+	case "-", "class", "plugin_data":
+		return ""
+	}
+	return name
+}
+
+func cell(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = cell(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// filter returns the items of the slice v whose columns have the wanted
+// values, an empty value matches anything.
+func filter(v interface{}, want map[string]string) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	elem := rv.Type().Elem()
+
+	fields := map[string]int{}
+	for key, value := range want {
+		if len(value) == 0 {
+			continue
+		}
+		found := false
+		for i := 0; i < elem.NumField(); i++ {
+			if column(elem.Field(i)) == key {
+				fields[key] = i
+				found = true
+			}
+		}
+		if !found {
+			return nil, usageError(fmt.Sprintf("%s can't be filtered by %s", elem.Name(), key))
+		}
+	}
+
+	out := reflect.MakeSlice(rv.Type(), 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		match := true
+		for key, field := range fields {
+			if cell(rv.Index(i).Field(field)) != want[key] {
+				match = false
+			}
+		}
+		if match {
+			out = reflect.Append(out, rv.Index(i))
+		}
+	}
+	return out.Interface(), nil
+}
+
+func isNil(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
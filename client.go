@@ -3,53 +3,178 @@
 package libstoragemgmt
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/url"
 	"os"
+	"os/exec"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	errors "github.com/libstorage/libstoragemgmt-golang/errors"
 )
 
 // ClientConnection is the structure that encomposes the needed data for the plugin connection.
+// A ClientConnection is safe for concurrent use by multiple goroutines.
 type ClientConnection struct {
-	tp         transPort
+	tp         *transPort
 	PluginName string
 	timeout    uint32
+
+	// Plugin process we started, nil when connected through lsmd
+	proc *exec.Cmd
+
+	caps capabilityCache
 }
 
 // Client establishes a connection to a plugin as specified in the URI.
 func Client(uri string, password string, timeout uint32) (*ClientConnection, error) {
+	return ClientCtx(context.Background(), uri, password, timeout)
+}
 
-	p, parseError := url.Parse(uri)
+// ClientCtx is Client with a context used for cancellation and deadlines while
+// connecting to and registering with the plugin.  The context is not retained
+// by the returned connection.
+func ClientCtx(ctx context.Context, uri string, password string, timeout uint32) (*ClientConnection, error) {
+
+	pluginName, parseError := pluginScheme(uri)
 	if parseError != nil {
-		return nil, &errors.LsmError{
-			Code:    errors.InvalidArgument,
-			Message: fmt.Sprintf("invalid uri: %w", parseError)}
+		return nil, parseError
 	}
 
-	pluginName := p.Scheme
 	pluginIpcPath := getPluginIpcPath(pluginName)
 
-	transport, transPortError := newTransport(pluginIpcPath, true)
+	transport, transPortError := newTransport(ctx, pluginIpcPath, true)
 	if transPortError != nil {
 		return nil, transPortError
 	}
 
+	return register(ctx, transport, pluginName, uri, password, timeout)
+}
+
+// ClientConn establishes a connection to a plugin serving the other end of
+// conn, eg. one end of a pipe or a network connection.  The plugin is chosen by
+// whoever serves conn, the URI is passed to it as usual.  Closing the returned
+// connection closes conn.
+func ClientConn(conn io.ReadWriteCloser, uri string, password string, timeout uint32) (*ClientConnection, error) {
+	return ClientConnCtx(context.Background(), conn, uri, password, timeout)
+}
+
+// ClientConnCtx is ClientConn with a context used for cancellation and
+// deadlines while registering with the plugin.  The context is not retained
+// by the returned connection.
+func ClientConnCtx(ctx context.Context, conn io.ReadWriteCloser, uri string, password string,
+	timeout uint32) (*ClientConnection, error) {
+
+	pluginName, parseError := pluginScheme(uri)
+	if parseError != nil {
+		conn.Close()
+		return nil, parseError
+	}
+
+	return register(ctx, newTransPortConn(conn), pluginName, uri, password, timeout)
+}
+
+func pluginScheme(uri string) (string, error) {
+	p, parseError := url.Parse(uri)
+	if parseError != nil {
+		return "", &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("invalid uri: %v", parseError)}
+	}
+	return p.Scheme, nil
+}
+
+// register registers with the plugin on the other end of transport, closing it
+// on failure.
+func register(ctx context.Context, transport *transPort, pluginName string, uri string, password string,
+	timeout uint32) (*ClientConnection, error) {
+
 	args := map[string]interface{}{"password": password, "uri": uri, "timeout": timeout}
-	if libError := transport.invoke("plugin_register", args, nil); libError != nil {
+	if libError := transport.invoke(ctx, "plugin_register", args, nil); libError != nil {
+		transport.close()
+		return nil, libError
+	}
+
+	return &ClientConnection{tp: transport, PluginName: pluginName, timeout: timeout}, nil
+}
+
+// ClientExec starts the plugin executable pluginBinary and connects to it
+// directly over a socket pair, the same way lsmd would, so no daemon is
+// needed.  The plugin process exits when the connection is closed.
+func ClientExec(pluginBinary string, uri string, password string, timeout uint32) (*ClientConnection, error) {
+	return ClientExecCtx(context.Background(), pluginBinary, uri, password, timeout)
+}
+
+// ClientExecCtx is ClientExec with a context used for cancellation and
+// deadlines while starting and registering with the plugin.  The context is
+// not retained by the returned connection.
+func ClientExecCtx(ctx context.Context, pluginBinary string, uri string, password string,
+	timeout uint32) (*ClientConnection, error) {
+
+	pluginName, parseError := pluginScheme(uri)
+	if parseError != nil {
+		return nil, parseError
+	}
+
+	fds, sockError := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if sockError != nil {
+		return nil, &errors.LsmError{
+			Code:    errors.TransPortComunication,
+			Message: fmt.Sprintf("unable to create socket pair: %v", sockError)}
+	}
+
+	ours := os.NewFile(uintptr(fds[0]), "client")
+	theirs := os.NewFile(uintptr(fds[1]), "plugin")
+	defer theirs.Close()
+
+	conn, connError := net.FileConn(ours)
+	ours.Close()
+	if connError != nil {
+		return nil, &errors.LsmError{
+			Code:    errors.TransPortComunication,
+			Message: fmt.Sprintf("unable to use socket pair: %v", connError)}
+	}
+
+	// The plugin end is the first of ExtraFiles, which is always fd 3 in the
+	// child.  Like lsmd we pass it as the only argument.
+	const pluginFd = "3"
+	proc := exec.Command(pluginBinary, pluginFd)
+	proc.Env = append(os.Environ(), "LSM_GO_FD="+pluginFd)
+	proc.ExtraFiles = []*os.File{theirs}
+	proc.Stderr = os.Stderr
+
+	if startError := proc.Start(); startError != nil {
+		conn.Close()
+		return nil, &errors.LsmError{
+			Code:    errors.PluginNotExist,
+			Message: fmt.Sprintf("unable to start plug-in %s: %v", pluginBinary, startError)}
+	}
+
+	c, libError := register(ctx, newTransPortConn(conn), pluginName, uri, password, timeout)
+	if libError != nil {
+		proc.Wait()
 		return nil, libError
 	}
 
-	return &ClientConnection{tp: *transport, PluginName: pluginName, timeout: timeout}, nil
+	c.proc = proc
+	return c, nil
 }
 
 // PluginInfo information about the current plugin
 func (c *ClientConnection) PluginInfo() (*PluginInfo, error) {
+	return c.PluginInfoCtx(context.Background())
+}
+
+// PluginInfoCtx is PluginInfo with a context used for cancellation and deadlines.
+func (c *ClientConnection) PluginInfoCtx(ctx context.Context) (*PluginInfo, error) {
 	args := make(map[string]interface{})
 	var info []string
-	if invokeError := c.tp.invoke("plugin_info", args, &info); invokeError != nil {
+	if invokeError := c.invoke(ctx, "plugin_info", args, &info); invokeError != nil {
 		return nil, invokeError
 	}
 	return &PluginInfo{Description: info[0], Version: info[1], Name: c.PluginName}, nil
@@ -69,14 +194,14 @@ func AvailablePlugins() ([]PluginInfo, error) {
 	var pluginInfos []PluginInfo
 	for _, pluginPath := range getPlugins(udsPath) {
 
-		var trans, transError = newTransport(pluginPath, true)
+		var trans, transError = newTransport(context.Background(), pluginPath, true)
 		if transError != nil {
 			return nil, transError
 		}
 
 		args := make(map[string]interface{})
 		var info []string
-		invokeError := trans.invoke("plugin_info", args, &info)
+		invokeError := trans.invoke(context.Background(), "plugin_info", args, &info)
 
 		trans.close()
 
@@ -94,100 +219,115 @@ func AvailablePlugins() ([]PluginInfo, error) {
 
 // Close instructs the plugin to shutdown and exist.
 func (c *ClientConnection) Close() error {
+	return c.CloseCtx(context.Background())
+}
+
+// CloseCtx is Close with a context used for cancellation and deadlines.
+func (c *ClientConnection) CloseCtx(ctx context.Context) error {
 	args := make(map[string]interface{})
-	ourError := c.tp.invoke("plugin_unregister", args, nil)
+	ourError := c.invoke(ctx, "plugin_unregister", args, nil)
 	c.tp.close()
+
+	if c.proc != nil {
+		// The plugin exits on unregister or when it sees the connection close
+		c.proc.Wait()
+	}
 	return ourError
 }
 
 // Systems returns systems information
 func (c *ClientConnection) Systems() ([]System, error) {
+	return c.SystemsCtx(context.Background())
+}
+
+// SystemsCtx is Systems with a context used for cancellation and deadlines.
+func (c *ClientConnection) SystemsCtx(ctx context.Context) ([]System, error) {
 	args := make(map[string]interface{})
 	var systems []System
-	return systems, c.tp.invoke("systems", args, &systems)
+	return systems, c.invoke(ctx, "systems", args, &systems)
 }
 
-// Volumes returns block device information
+// Volumes returns block device information, search by key and value, eg.
+// "pool_id", POOL_ID, or see VolumesBy.
 func (c *ClientConnection) Volumes(search ...string) ([]Volume, error) {
-	args := make(map[string]interface{})
-	var volumes []Volume
-
-	if !handleSearch(args, search) {
-		return make([]Volume, 0), &errors.LsmError{
-			Code: errors.InvalidArgument,
-			Message: fmt.Sprintf(
-				"volume supports 0 or 2 search parameters (key, value), provide %d", len(search)),
-			Data: ""}
-	}
+	return c.VolumesCtx(context.Background(), search...)
+}
 
-	return volumes, c.tp.invoke("volumes", args, &volumes)
+// VolumesCtx is Volumes with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumesCtx(ctx context.Context, search ...string) ([]Volume, error) {
+	volumes := make([]Volume, 0)
+	return volumes, c.list(ctx, "volumes", "volume", search, &volumes)
 }
 
 // Pools returns the units of storage that block devices and FS
-// can be created from.
+// can be created from, search by key and value, eg. "system_id", SYSTEM_ID,
+// or see PoolsBy.
 func (c *ClientConnection) Pools(search ...string) ([]Pool, error) {
-	args := make(map[string]interface{})
-
-	if !handleSearch(args, search) {
-		return make([]Pool, 0), &errors.LsmError{
-			Code: errors.InvalidArgument,
-			Message: fmt.Sprintf(
-				"pool supports 0 or 2 search parameters (key, value), provide %d", len(search)),
-			Data: ""}
-	}
+	return c.PoolsCtx(context.Background(), search...)
+}
 
-	var pools []Pool
-	return pools, c.tp.invoke("pools", args, &pools)
+// PoolsCtx is Pools with a context used for cancellation and deadlines.
+func (c *ClientConnection) PoolsCtx(ctx context.Context, search ...string) ([]Pool, error) {
+	pools := make([]Pool, 0)
+	return pools, c.list(ctx, "pools", "pool", search, &pools)
 }
 
 // Disks returns disks that are present.
 func (c *ClientConnection) Disks() ([]Disk, error) {
+	return c.DisksCtx(context.Background())
+}
+
+// DisksCtx is Disks with a context used for cancellation and deadlines.
+func (c *ClientConnection) DisksCtx(ctx context.Context) ([]Disk, error) {
 	args := make(map[string]interface{})
 	var disks []Disk
-	return disks, c.tp.invoke("disks", args, &disks)
+	return disks, c.invoke(ctx, "disks", args, &disks)
 }
 
-// FileSystems returns pools that are present.
+// FileSystems returns the file systems that are present, search by key and
+// value, eg. "pool_id", POOL_ID, or see FileSystemsBy.
 func (c *ClientConnection) FileSystems(search ...string) ([]FileSystem, error) {
-	args := make(map[string]interface{})
-	var fileSystems []FileSystem
-
-	if !handleSearch(args, search) {
-		return make([]FileSystem, 0), &errors.LsmError{
-			Code: errors.InvalidArgument,
-			Message: fmt.Sprintf(
-				"fs supports 0 or 2 search parameters (key, value), provide %d", len(search)),
-			Data: ""}
-	}
+	return c.FileSystemsCtx(context.Background(), search...)
+}
 
-	return fileSystems, c.tp.invoke("fs", args, &fileSystems)
+// FileSystemsCtx is FileSystems with a context used for cancellation and deadlines.
+func (c *ClientConnection) FileSystemsCtx(ctx context.Context, search ...string) ([]FileSystem, error) {
+	fileSystems := make([]FileSystem, 0)
+	return fileSystems, c.list(ctx, "fs", "fs", search, &fileSystems)
 }
 
-// NfsExports returns nfs exports  that are present.
+// NfsExports returns nfs exports that are present, search by key and value,
+// eg. "fs_id", FS_ID, or see NfsExportsBy.
 func (c *ClientConnection) NfsExports(search ...string) ([]NfsExport, error) {
-	args := make(map[string]interface{})
-
-	if !handleSearch(args, search) {
-		return make([]NfsExport, 0), &errors.LsmError{
-			Code: errors.InvalidArgument,
-			Message: fmt.Sprintf(
-				"NfsExports supports 0 or 2 search parameters (key, value), provide %d", len(search)),
-			Data: ""}
-	}
+	return c.NfsExportsCtx(context.Background(), search...)
+}
 
-	var nfsExports []NfsExport
-	return nfsExports, c.tp.invoke("exports", args, &nfsExports)
+// NfsExportsCtx is NfsExports with a context used for cancellation and deadlines.
+func (c *ClientConnection) NfsExportsCtx(ctx context.Context, search ...string) ([]NfsExport, error) {
+	nfsExports := make([]NfsExport, 0)
+	return nfsExports, c.list(ctx, "exports", "NfsExports", search, &nfsExports)
 }
 
 // NfsExportAuthTypes returns list of support authentication types
 func (c *ClientConnection) NfsExportAuthTypes() ([]string, error) {
+	return c.NfsExportAuthTypesCtx(context.Background())
+}
+
+// NfsExportAuthTypesCtx is NfsExportAuthTypes with a context used for cancellation and deadlines.
+func (c *ClientConnection) NfsExportAuthTypesCtx(ctx context.Context) ([]string, error) {
 	var authTypes []string
-	return authTypes, c.tp.invoke("export_auth", make(map[string]interface{}), &authTypes)
+	return authTypes, c.invoke(ctx, "export_auth", make(map[string]interface{}), &authTypes)
 }
 
 // FsExport creates or modifies a NFS export.
 func (c *ClientConnection) FsExport(fs *FileSystem, exportPath *string,
 	access *NfsAccess, authType *string, options *string) (*NfsExport, error) {
+	return c.FsExportCtx(context.Background(), fs, exportPath, access, authType, options)
+}
+
+// FsExportCtx is FsExport with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsExportCtx(ctx context.Context, fs *FileSystem, exportPath *string,
+	access *NfsAccess, authType *string, options *string) (*NfsExport, error) {
 
 	if len(access.Ro) == 0 && len(access.Rw) == 0 {
 		return nil, &errors.LsmError{
@@ -218,7 +358,7 @@ func (c *ClientConnection) FsExport(fs *FileSystem, exportPath *string,
 	args := map[string]interface{}{
 		"fs_id":       fs.ID,
 		"export_path": exportPath,
-		"root_list":   emptySliceIfNil(access.Ro),
+		"root_list":   emptySliceIfNil(access.Root),
 		"rw_list":     emptySliceIfNil(access.Rw),
 		"ro_list":     emptySliceIfNil(access.Ro),
 		"anon_uid":    access.AnonUID,
@@ -227,7 +367,7 @@ func (c *ClientConnection) FsExport(fs *FileSystem, exportPath *string,
 		"options":     options,
 	}
 	var nfsExport NfsExport
-	if err := c.tp.invoke("export_fs", args, &nfsExport); err != nil {
+	if err := c.invoke(ctx, "export_fs", args, &nfsExport); err != nil {
 		return nil, err
 	}
 	return &nfsExport, nil
@@ -235,36 +375,61 @@ func (c *ClientConnection) FsExport(fs *FileSystem, exportPath *string,
 
 // FsUnExport removes a file system export.
 func (c *ClientConnection) FsUnExport(export *NfsExport) error {
+	return c.FsUnExportCtx(context.Background(), export)
+}
+
+// FsUnExportCtx is FsUnExport with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsUnExportCtx(ctx context.Context, export *NfsExport) error {
 	args := map[string]interface{}{"export": *export}
-	return c.tp.invoke("export_remove", args, nil)
+	return c.invoke(ctx, "export_remove", args, nil)
 }
 
 // AccessGroups returns access groups  that are present.
 // TODO: Add search arguments
 func (c *ClientConnection) AccessGroups() ([]AccessGroup, error) {
+	return c.AccessGroupsCtx(context.Background())
+}
+
+// AccessGroupsCtx is AccessGroups with a context used for cancellation and deadlines.
+func (c *ClientConnection) AccessGroupsCtx(ctx context.Context) ([]AccessGroup, error) {
 	args := make(map[string]interface{})
 	var accessGroups []AccessGroup
-	return accessGroups, c.tp.invoke("access_groups", args, &accessGroups)
+	return accessGroups, c.invoke(ctx, "access_groups", args, &accessGroups)
 }
 
 // TargetPorts returns target ports that are present.
 func (c *ClientConnection) TargetPorts() ([]TargetPort, error) {
+	return c.TargetPortsCtx(context.Background())
+}
+
+// TargetPortsCtx is TargetPorts with a context used for cancellation and deadlines.
+func (c *ClientConnection) TargetPortsCtx(ctx context.Context) ([]TargetPort, error) {
 	args := make(map[string]interface{})
 	var targetPorts []TargetPort
-	return targetPorts, c.tp.invoke("target_ports", args, &targetPorts)
+	return targetPorts, c.invoke(ctx, "target_ports", args, &targetPorts)
 }
 
 // Batteries returns batteries that are present
 func (c *ClientConnection) Batteries() ([]Battery, error) {
+	return c.BatteriesCtx(context.Background())
+}
+
+// BatteriesCtx is Batteries with a context used for cancellation and deadlines.
+func (c *ClientConnection) BatteriesCtx(ctx context.Context) ([]Battery, error) {
 	args := make(map[string]interface{})
 	var batteries []Battery
-	return batteries, c.tp.invoke("batteries", args, &batteries)
+	return batteries, c.invoke(ctx, "batteries", args, &batteries)
 }
 
 // JobFree instructs the plugin to release resources for the job that was returned.
 func (c *ClientConnection) JobFree(jobID string) error {
+	return c.JobFreeCtx(context.Background(), jobID)
+}
+
+// JobFreeCtx is JobFree with a context used for cancellation and deadlines.
+func (c *ClientConnection) JobFreeCtx(ctx context.Context, jobID string) error {
 	args := map[string]interface{}{"job_id": jobID}
-	return c.tp.invoke("job_free", args, nil)
+	return c.invoke(ctx, "job_free", args, nil)
 }
 
 // JobStatus instructs the plugin to return the status of the specified job.  The returned values are
@@ -272,10 +437,15 @@ func (c *ClientConnection) JobFree(jobID string) error {
 // set the other two are meaningless.  If checking on the status of an operation that doesn't return a result
 // or you are not wanting the result, pass nil.
 func (c *ClientConnection) JobStatus(jobID string, returnedResult interface{}) (JobStatusType, uint8, error) {
+	return c.JobStatusCtx(context.Background(), jobID, returnedResult)
+}
+
+// JobStatusCtx is JobStatus with a context used for cancellation and deadlines.
+func (c *ClientConnection) JobStatusCtx(ctx context.Context, jobID string, returnedResult interface{}) (JobStatusType, uint8, error) {
 	args := map[string]interface{}{"job_id": jobID}
 
 	var result [3]json.RawMessage
-	if jobError := c.tp.invoke("job_status", args, &result); jobError != nil {
+	if jobError := c.invoke(ctx, "job_status", args, &result); jobError != nil {
 		return JobStatusError, 0, jobError
 	}
 
@@ -305,7 +475,9 @@ func (c *ClientConnection) JobStatus(jobID string, returnedResult interface{}) (
 		if checkErrorE := json.Unmarshal(result[2], &error); checkErrorE != nil {
 			return JobStatusError, 0, checkErrorE
 		}
-
+		if error.Code != 0 {
+			return JobStatusError, 0, &error
+		}
 		return JobStatusError, 0, &errors.LsmError{
 			Code:    errors.PluginBug,
 			Message: "job_status returned error status with no error information"}
@@ -316,7 +488,7 @@ func (c *ClientConnection) JobStatus(jobID string, returnedResult interface{}) (
 	}
 }
 
-func (c *ClientConnection) getJobOrResult(err error, returned [2]json.RawMessage, sync bool, result interface{}) (*string, error) {
+func (c *ClientConnection) getJobOrResult(ctx context.Context, err error, returned [2]json.RawMessage, sync bool, result interface{}) (*string, error) {
 	if err != nil {
 		return nil, err
 	}
@@ -326,7 +498,7 @@ func (c *ClientConnection) getJobOrResult(err error, returned [2]json.RawMessage
 	if um == nil {
 		// We have a job, but want to wait for result, so do so.
 		if sync {
-			return nil, c.JobWait(job, result)
+			return nil, c.JobWaitCtx(ctx, job, result)
 		}
 
 		return &job, nil
@@ -335,7 +507,7 @@ func (c *ClientConnection) getJobOrResult(err error, returned [2]json.RawMessage
 	return nil, json.Unmarshal(returned[1], result)
 }
 
-func (c *ClientConnection) getJobOrNone(err error, returned json.RawMessage, sync bool) (*string, error) {
+func (c *ClientConnection) getJobOrNone(ctx context.Context, err error, returned json.RawMessage, sync bool) (*string, error) {
 	if err != nil {
 		return nil, err
 	}
@@ -345,7 +517,7 @@ func (c *ClientConnection) getJobOrNone(err error, returned json.RawMessage, syn
 	if um == nil {
 		// We have a job, but want to wait for result, so do so.
 		if sync {
-			return nil, c.JobWait(job, nil)
+			return nil, c.JobWaitCtx(ctx, job, nil)
 		}
 
 		return &job, nil
@@ -355,53 +527,57 @@ func (c *ClientConnection) getJobOrNone(err error, returned json.RawMessage, syn
 
 // JobWait waits for the job to finish and retrieves the end result in "returnedResult".
 func (c *ClientConnection) JobWait(jobID string, returnedResult interface{}) error {
+	return c.JobWaitCtx(context.Background(), jobID, returnedResult)
+}
 
-	for true {
-		var status, _, err = c.JobStatus(jobID, returnedResult)
-		if err != nil {
-			return err
-		}
-
-		if status == JobStatusInprogress {
-			time.Sleep(time.Millisecond * 250)
-			continue
-		} else if status == JobStatusComplete {
-			if freeError := c.JobFree(jobID); freeError != nil {
-				return &errors.LsmError{
-					Code: errors.PluginBug,
-					Message: fmt.Sprintf(
-						"We successfully waited for job %s, but got an error freeing it: %s", jobID, freeError)}
-			}
-			break
-		}
-	}
-	return nil
+// JobWaitCtx is JobWait with a context used for cancellation and deadlines.  Polling
+// stops and the context error is returned as soon as the context is done, the job
+// itself is left running on the plugin.
+func (c *ClientConnection) JobWaitCtx(ctx context.Context, jobID string, returnedResult interface{}) error {
+	job := c.Job(jobID, returnedResult)
+	job.Backoff = Backoff{Initial: 250 * time.Millisecond}
+	return job.Wait(ctx)
 }
 
 // Capabilities retrieve capabilities
 func (c *ClientConnection) Capabilities(system *System) (*Capabilities, error) {
+	return c.CapabilitiesCtx(context.Background(), system)
+}
+
+// CapabilitiesCtx is Capabilities with a context used for cancellation and deadlines.
+func (c *ClientConnection) CapabilitiesCtx(ctx context.Context, system *System) (*Capabilities, error) {
 	args := map[string]interface{}{"system": *system}
 	var cap Capabilities
-	return &cap, c.tp.invoke("capabilities", args, &cap)
+	return &cap, c.invoke(ctx, "capabilities", args, &cap)
 }
 
 // TimeOutSet sets the connection timeout with the storage device.
 func (c *ClientConnection) TimeOutSet(milliSeconds uint32) error {
+	return c.TimeOutSetCtx(context.Background(), milliSeconds)
+}
+
+// TimeOutSetCtx is TimeOutSet with a context used for cancellation and deadlines.
+func (c *ClientConnection) TimeOutSetCtx(ctx context.Context, milliSeconds uint32) error {
 	args := map[string]interface{}{"ms": milliSeconds}
-	var err = c.tp.invoke("time_out_set", args, nil)
+	var err = c.invoke(ctx, "time_out_set", args, nil)
 	if err == nil {
-		c.timeout = milliSeconds
+		atomic.StoreUint32(&c.timeout, milliSeconds)
 	}
 	return err
 }
 
 // TimeOutGet sets the connection timeout with the storage device.
 func (c *ClientConnection) TimeOutGet() uint32 {
-	return c.timeout
+	return atomic.LoadUint32(&c.timeout)
 }
 
 // SysReadCachePctSet changes the read cache percentage for the specified system.
 func (c *ClientConnection) SysReadCachePctSet(system *System, readPercent uint32) error {
+	return c.SysReadCachePctSetCtx(context.Background(), system, readPercent)
+}
+
+// SysReadCachePctSetCtx is SysReadCachePctSet with a context used for cancellation and deadlines.
+func (c *ClientConnection) SysReadCachePctSetCtx(ctx context.Context, system *System, readPercent uint32) error {
 
 	if readPercent > 100 {
 		return &errors.LsmError{
@@ -411,12 +587,18 @@ func (c *ClientConnection) SysReadCachePctSet(system *System, readPercent uint32
 	}
 
 	args := map[string]interface{}{"system": *system, "read_pct": readPercent}
-	return c.tp.invoke("system_read_cache_pct_update", args, nil)
+	return c.invoke(ctx, "system_read_cache_pct_update", args, nil)
 }
 
 // IscsiChapAuthSet iSCSI CHAP authentication.
 func (c *ClientConnection) IscsiChapAuthSet(initID string, inUser *string, inPassword *string,
 	outUser *string, outPassword *string) error {
+	return c.IscsiChapAuthSetCtx(context.Background(), initID, inUser, inPassword, outUser, outPassword)
+}
+
+// IscsiChapAuthSetCtx is IscsiChapAuthSet with a context used for cancellation and deadlines.
+func (c *ClientConnection) IscsiChapAuthSetCtx(ctx context.Context, initID string, inUser *string, inPassword *string,
+	outUser *string, outPassword *string) error {
 
 	args := map[string]interface{}{
 		"init_id":      initID,
@@ -426,12 +608,23 @@ func (c *ClientConnection) IscsiChapAuthSet(initID string, inUser *string, inPas
 		"out_password": outPassword,
 	}
 
-	return c.tp.invoke("iscsi_chap_auth", args, nil)
+	return c.invoke(ctx, "iscsi_chap_auth", args, nil)
 }
 
 // VolumeCreate creates a block device, returns job id, error.
 // If job id and error are nil, then returnedVolume has newly created volume.
 func (c *ClientConnection) VolumeCreate(
+	pool *Pool,
+	volumeName string,
+	size uint64,
+	provisioning VolumeProvisionType,
+	sync bool) (*Volume, *string, error) {
+	return c.VolumeCreateCtx(context.Background(), pool, volumeName, size, provisioning, sync)
+}
+
+// VolumeCreateCtx is VolumeCreate with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeCreateCtx(
+	ctx context.Context,
 	pool *Pool,
 	volumeName string,
 	size uint64,
@@ -446,23 +639,33 @@ func (c *ClientConnection) VolumeCreate(
 
 	var returnedVolume Volume
 	var result [2]json.RawMessage
-	jobID, err := c.getJobOrResult(c.tp.invoke("volume_create", args, &result), result, sync, &returnedVolume)
+	jobID, err := c.getJobOrResult(ctx, c.invoke(ctx, "volume_create", args, &result), result, sync, &returnedVolume)
 	return ensureExclusiveVol(&returnedVolume, jobID, err)
 }
 
 // VolumeDelete deletes a block device.
 func (c *ClientConnection) VolumeDelete(vol *Volume, sync bool) (*string, error) {
+	return c.VolumeDeleteCtx(context.Background(), vol, sync)
+}
+
+// VolumeDeleteCtx is VolumeDelete with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeDeleteCtx(ctx context.Context, vol *Volume, sync bool) (*string, error) {
 	args := map[string]interface{}{"volume": *vol}
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("volume_delete", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "volume_delete", args, &result), result, sync)
 }
 
 // VolumeResize resizes an existing volume, data loss may occur depending on storage implementation.
 func (c *ClientConnection) VolumeResize(vol *Volume, newSizeBytes uint64, sync bool) (*Volume, *string, error) {
+	return c.VolumeResizeCtx(context.Background(), vol, newSizeBytes, sync)
+}
+
+// VolumeResizeCtx is VolumeResize with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeResizeCtx(ctx context.Context, vol *Volume, newSizeBytes uint64, sync bool) (*Volume, *string, error) {
 	args := map[string]interface{}{"volume": *vol, "new_size_bytes": newSizeBytes}
 	var returnedVolume Volume
 	var result [2]json.RawMessage
-	job, err := c.getJobOrResult(c.tp.invoke("volume_resize", args, &result), result, sync, &returnedVolume)
+	job, err := c.getJobOrResult(ctx, c.invoke(ctx, "volume_resize", args, &result), result, sync, &returnedVolume)
 	return ensureExclusiveVol(&returnedVolume, job, err)
 }
 
@@ -470,6 +673,14 @@ func (c *ClientConnection) VolumeResize(vol *Volume, newSizeBytes uint64, sync b
 func (c *ClientConnection) VolumeReplicate(
 	optionalPool *Pool, repType VolumeReplicateType, sourceVolume *Volume, name string,
 	sync bool) (*Volume, *string, error) {
+	return c.VolumeReplicateCtx(context.Background(), optionalPool, repType, sourceVolume, name, sync)
+}
+
+// VolumeReplicateCtx is VolumeReplicate with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeReplicateCtx(
+	ctx context.Context,
+	optionalPool *Pool, repType VolumeReplicateType, sourceVolume *Volume, name string,
+	sync bool) (*Volume, *string, error) {
 
 	args := map[string]interface{}{
 		"volume_src": *sourceVolume,
@@ -484,21 +695,34 @@ func (c *ClientConnection) VolumeReplicate(
 
 	var returnedVolume Volume
 	var result [2]json.RawMessage
-	job, err := c.getJobOrResult(c.tp.invoke("volume_replicate", args, &result), result, sync, &returnedVolume)
+	job, err := c.getJobOrResult(ctx, c.invoke(ctx, "volume_replicate", args, &result), result, sync, &returnedVolume)
 	return ensureExclusiveVol(&returnedVolume, job, err)
 }
 
 // VolumeRepRangeBlkSize block size for replicating a range of blocks
 func (c *ClientConnection) VolumeRepRangeBlkSize(system *System) (uint32, error) {
+	return c.VolumeRepRangeBlkSizeCtx(context.Background(), system)
+}
+
+// VolumeRepRangeBlkSizeCtx is VolumeRepRangeBlkSize with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeRepRangeBlkSizeCtx(ctx context.Context, system *System) (uint32, error) {
 	args := map[string]interface{}{"system": *system}
 	var blkSize uint32
-	return blkSize, c.tp.invoke("volume_replicate_range_block_size", args, &blkSize)
+	return blkSize, c.invoke(ctx, "volume_replicate_range_block_size", args, &blkSize)
 }
 
 // VolumeReplicateRange replicates a range of blocks on the same or different Volume
 func (c *ClientConnection) VolumeReplicateRange(
 	repType VolumeReplicateType, srcVol *Volume, dstVol *Volume,
 	ranges []BlockRange, sync bool) (*string, error) {
+	return c.VolumeReplicateRangeCtx(context.Background(), repType, srcVol, dstVol, ranges, sync)
+}
+
+// VolumeReplicateRangeCtx is VolumeReplicateRange with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeReplicateRangeCtx(
+	ctx context.Context,
+	repType VolumeReplicateType, srcVol *Volume, dstVol *Volume,
+	ranges []BlockRange, sync bool) (*string, error) {
 
 	args := map[string]interface{}{
 		"rep_type":    repType,
@@ -507,64 +731,114 @@ func (c *ClientConnection) VolumeReplicateRange(
 		"volume_dest": *dstVol,
 	}
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("volume_replicate_range", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "volume_replicate_range", args, &result), result, sync)
 }
 
 // VolumeEnable sets a volume to online.
 func (c *ClientConnection) VolumeEnable(vol *Volume) error {
+	return c.VolumeEnableCtx(context.Background(), vol)
+}
+
+// VolumeEnableCtx is VolumeEnable with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeEnableCtx(ctx context.Context, vol *Volume) error {
 	args := map[string]interface{}{"volume": *vol}
-	return c.tp.invoke("volume_enable", args, nil)
+	return c.invoke(ctx, "volume_enable", args, nil)
 }
 
 // VolumeDisable sets a volume to offline.
 func (c *ClientConnection) VolumeDisable(vol *Volume) error {
+	return c.VolumeDisableCtx(context.Background(), vol)
+}
+
+// VolumeDisableCtx is VolumeDisable with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeDisableCtx(ctx context.Context, vol *Volume) error {
 	args := map[string]interface{}{"volume": *vol}
-	return c.tp.invoke("volume_disable", args, nil)
+	return c.invoke(ctx, "volume_disable", args, nil)
 }
 
 // VolumeMask grants access to a volume for the specified access group.
 func (c *ClientConnection) VolumeMask(vol *Volume, ag *AccessGroup) error {
+	return c.VolumeMaskCtx(context.Background(), vol, ag)
+}
+
+// VolumeMaskCtx is VolumeMask with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeMaskCtx(ctx context.Context, vol *Volume, ag *AccessGroup) error {
 	args := map[string]interface{}{"volume": *vol, "access_group": *ag}
-	return c.tp.invoke("volume_mask", args, nil)
+	return c.invoke(ctx, "volume_mask", args, nil)
 }
 
 // VolumeUnMask removes access to a volume for the specified access group.
 func (c *ClientConnection) VolumeUnMask(vol *Volume, ag *AccessGroup) error {
+	return c.VolumeUnMaskCtx(context.Background(), vol, ag)
+}
+
+// VolumeUnMaskCtx is VolumeUnMask with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolumeUnMaskCtx(ctx context.Context, vol *Volume, ag *AccessGroup) error {
 	args := map[string]interface{}{"volume": *vol, "access_group": *ag}
-	return c.tp.invoke("volume_unmask", args, nil)
+	return c.invoke(ctx, "volume_unmask", args, nil)
 }
 
 // VolsMaskedToAg returns the volumes accessible to access group
 func (c *ClientConnection) VolsMaskedToAg(ag *AccessGroup) ([]Volume, error) {
+	return c.VolsMaskedToAgCtx(context.Background(), ag)
+}
+
+// VolsMaskedToAgCtx is VolsMaskedToAg with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolsMaskedToAgCtx(ctx context.Context, ag *AccessGroup) ([]Volume, error) {
 	args := map[string]interface{}{"access_group": *ag}
 	var volumes []Volume
-	return volumes, c.tp.invoke("volumes_accessible_by_access_group", args, &volumes)
+	return volumes, c.invoke(ctx, "volumes_accessible_by_access_group", args, &volumes)
 }
 
 // AgsGrantedToVol returns access group(s) which have access to specified volume
 func (c *ClientConnection) AgsGrantedToVol(vol *Volume) ([]AccessGroup, error) {
+	return c.AgsGrantedToVolCtx(context.Background(), vol)
+}
+
+// AgsGrantedToVolCtx is AgsGrantedToVol with a context used for cancellation and deadlines.
+func (c *ClientConnection) AgsGrantedToVolCtx(ctx context.Context, vol *Volume) ([]AccessGroup, error) {
 	args := map[string]interface{}{"volume": *vol}
 	var accessGroups []AccessGroup
-	return accessGroups, c.tp.invoke("access_groups_granted_to_volume", args, &accessGroups)
+	return accessGroups, c.invoke(ctx, "access_groups_granted_to_volume", args, &accessGroups)
 }
 
 // VolHasChildDep returns true|false if volume has child dependency
 func (c *ClientConnection) VolHasChildDep(vol *Volume) (bool, error) {
+	return c.VolHasChildDepCtx(context.Background(), vol)
+}
+
+// VolHasChildDepCtx is VolHasChildDep with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolHasChildDepCtx(ctx context.Context, vol *Volume) (bool, error) {
 	args := map[string]interface{}{"volume": *vol}
 	var deps bool
-	return deps, c.tp.invoke("volume_child_dependency", args, &deps)
+	return deps, c.invoke(ctx, "volume_child_dependency", args, &deps)
 }
 
 // VolChildDepRm removes any child dependencies
 func (c *ClientConnection) VolChildDepRm(vol *Volume, sync bool) (*string, error) {
+	return c.VolChildDepRmCtx(context.Background(), vol, sync)
+}
+
+// VolChildDepRmCtx is VolChildDepRm with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolChildDepRmCtx(ctx context.Context, vol *Volume, sync bool) (*string, error) {
 	args := map[string]interface{}{"volume": *vol}
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("volume_child_dependency_rm", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "volume_child_dependency_rm", args, &result), result, sync)
 }
 
 // FsCreate creates a file system, returns job id, error.
 // If job id and error are nil, then returnedFs has newly created filesystem.
 func (c *ClientConnection) FsCreate(
+	pool *Pool,
+	name string,
+	size uint64,
+	sync bool) (*FileSystem, *string, error) {
+	return c.FsCreateCtx(context.Background(), pool, name, size, sync)
+}
+
+// FsCreateCtx is FsCreate with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsCreateCtx(
+	ctx context.Context,
 	pool *Pool,
 	name string,
 	size uint64,
@@ -576,29 +850,51 @@ func (c *ClientConnection) FsCreate(
 	}
 	var returnedFs FileSystem
 	var result [2]json.RawMessage
-	job, err := c.getJobOrResult(c.tp.invoke("fs_create", args, &result), result, sync, &returnedFs)
+	job, err := c.getJobOrResult(ctx, c.invoke(ctx, "fs_create", args, &result), result, sync, &returnedFs)
 	return ensureExclusiveFs(&returnedFs, job, err)
 }
 
 // FsResize resizes an existing file system
 func (c *ClientConnection) FsResize(
+	fs *FileSystem, newSizeBytes uint64, sync bool) (*FileSystem, *string, error) {
+	return c.FsResizeCtx(context.Background(), fs, newSizeBytes, sync)
+}
+
+// FsResizeCtx is FsResize with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsResizeCtx(
+	ctx context.Context,
 	fs *FileSystem, newSizeBytes uint64, sync bool) (*FileSystem, *string, error) {
 	args := map[string]interface{}{"fs": *fs, "new_size_bytes": newSizeBytes}
 	var returnedFs FileSystem
 	var result [2]json.RawMessage
-	job, err := c.getJobOrResult(c.tp.invoke("fs_resize", args, &result), result, sync, &returnedFs)
+	job, err := c.getJobOrResult(ctx, c.invoke(ctx, "fs_resize", args, &result), result, sync, &returnedFs)
 	return ensureExclusiveFs(&returnedFs, job, err)
 }
 
 // FsDelete deletes a file system.
 func (c *ClientConnection) FsDelete(fs *FileSystem, sync bool) (*string, error) {
+	return c.FsDeleteCtx(context.Background(), fs, sync)
+}
+
+// FsDeleteCtx is FsDelete with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsDeleteCtx(ctx context.Context, fs *FileSystem, sync bool) (*string, error) {
 	args := map[string]interface{}{"fs": *fs}
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("fs_delete", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "fs_delete", args, &result), result, sync)
 }
 
 // FsClone makes a clone of an existing file system
 func (c *ClientConnection) FsClone(
+	srcFs *FileSystem,
+	destName string,
+	optionalSnapShot *FileSystemSnapShot,
+	sync bool) (*FileSystem, *string, error) {
+	return c.FsCloneCtx(context.Background(), srcFs, destName, optionalSnapShot, sync)
+}
+
+// FsCloneCtx is FsClone with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsCloneCtx(
+	ctx context.Context,
 	srcFs *FileSystem,
 	destName string,
 	optionalSnapShot *FileSystemSnapShot,
@@ -613,7 +909,7 @@ func (c *ClientConnection) FsClone(
 
 	var returnedFs FileSystem
 	var result [2]json.RawMessage
-	job, err := c.getJobOrResult(c.tp.invoke("fs_clone", args, &result), result, sync, &returnedFs)
+	job, err := c.getJobOrResult(ctx, c.invoke(ctx, "fs_clone", args, &result), result, sync, &returnedFs)
 	return ensureExclusiveFs(&returnedFs, job, err)
 }
 
@@ -624,6 +920,18 @@ func (c *ClientConnection) FsFileClone(
 	dstFileName string,
 	optionalSnapShot *FileSystemSnapShot,
 	sync bool,
+) (*string, error) {
+	return c.FsFileCloneCtx(context.Background(), fs, srcFileName, dstFileName, optionalSnapShot, sync)
+}
+
+// FsFileCloneCtx is FsFileClone with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsFileCloneCtx(
+	ctx context.Context,
+	fs *FileSystem,
+	srcFileName string,
+	dstFileName string,
+	optionalSnapShot *FileSystemSnapShot,
+	sync bool,
 ) (*string, error) {
 	args := map[string]interface{}{
 		"fs":             *fs,
@@ -638,38 +946,61 @@ func (c *ClientConnection) FsFileClone(
 	}
 
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("fs_file_clone", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "fs_file_clone", args, &result), result, sync)
 }
 
 // FsSnapShotCreate creates a file system snapshot for the supplied snapshot
 // If job id and error are nil, then returnedFs has newly created filesystem.
 func (c *ClientConnection) FsSnapShotCreate(fs *FileSystem, name string, sync bool) (*FileSystemSnapShot, *string, error) {
+	return c.FsSnapShotCreateCtx(context.Background(), fs, name, sync)
+}
+
+// FsSnapShotCreateCtx is FsSnapShotCreate with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsSnapShotCreateCtx(ctx context.Context, fs *FileSystem, name string, sync bool) (*FileSystemSnapShot, *string, error) {
 	args := map[string]interface{}{"fs": *fs, "snapshot_name": name}
 	var returnedSnapshot FileSystemSnapShot
 	var result [2]json.RawMessage
-	job, err := c.getJobOrResult(c.tp.invoke("fs_snapshot_create", args, &result), result, sync, &returnedSnapshot)
+	job, err := c.getJobOrResult(ctx, c.invoke(ctx, "fs_snapshot_create", args, &result), result, sync, &returnedSnapshot)
 	return ensureExclusiveSs(&returnedSnapshot, job, err)
 }
 
 // FsSnapShotDelete deletes a file system snapshot.
 func (c *ClientConnection) FsSnapShotDelete(fs *FileSystem, snapShot *FileSystemSnapShot, sync bool) (*string, error) {
+	return c.FsSnapShotDeleteCtx(context.Background(), fs, snapShot, sync)
+}
+
+// FsSnapShotDeleteCtx is FsSnapShotDelete with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsSnapShotDeleteCtx(ctx context.Context, fs *FileSystem, snapShot *FileSystemSnapShot, sync bool) (*string, error) {
 	args := map[string]interface{}{"fs": *fs, "snapshot": *snapShot}
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("fs_snapshot_delete", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "fs_snapshot_delete", args, &result), result, sync)
 }
 
 // FsSnapShots returns list of file system snapsthos for specified file system.
 // can be created from.
 func (c *ClientConnection) FsSnapShots(fs *FileSystem) ([]FileSystemSnapShot, error) {
+	return c.FsSnapShotsCtx(context.Background(), fs)
+}
+
+// FsSnapShotsCtx is FsSnapShots with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsSnapShotsCtx(ctx context.Context, fs *FileSystem) ([]FileSystemSnapShot, error) {
 	args := map[string]interface{}{"fs": *fs}
 	var snapShots []FileSystemSnapShot
-	return snapShots, c.tp.invoke("fs_snapshots", args, &snapShots)
+	return snapShots, c.invoke(ctx, "fs_snapshots", args, &snapShots)
 }
 
 // FsSnapShotRestore restores all the files for a file systems or specific files.
 func (c *ClientConnection) FsSnapShotRestore(
 	fs *FileSystem, snapShot *FileSystemSnapShot, allFiles bool,
 	files []string, restoreFiles []string, sync bool) (*string, error) {
+	return c.FsSnapShotRestoreCtx(context.Background(), fs, snapShot, allFiles, files, restoreFiles, sync)
+}
+
+// FsSnapShotRestoreCtx is FsSnapShotRestore with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsSnapShotRestoreCtx(
+	ctx context.Context,
+	fs *FileSystem, snapShot *FileSystemSnapShot, allFiles bool,
+	files []string, restoreFiles []string, sync bool) (*string, error) {
 
 	if !allFiles {
 		if len(files) == 0 {
@@ -693,27 +1024,45 @@ func (c *ClientConnection) FsSnapShotRestore(
 		"all_files":     allFiles,
 	}
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("fs_snapshot_restore", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "fs_snapshot_restore", args, &result), result, sync)
 }
 
 // FsHasChildDep checks whether file system has a child dependency.
 func (c *ClientConnection) FsHasChildDep(fs *FileSystem, files []string) (bool, error) {
+	return c.FsHasChildDepCtx(context.Background(), fs, files)
+}
+
+// FsHasChildDepCtx is FsHasChildDep with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsHasChildDepCtx(ctx context.Context, fs *FileSystem, files []string) (bool, error) {
 	args := map[string]interface{}{"fs": *fs, "files": files}
 	var result bool
-	return result, c.tp.invoke("fs_child_dependency", args, &result)
+	return result, c.invoke(ctx, "fs_child_dependency", args, &result)
 }
 
 // FsChildDepRm remove dependencies for specified file system.
 func (c *ClientConnection) FsChildDepRm(
+	fs *FileSystem, files []string, sync bool) (*string, error) {
+	return c.FsChildDepRmCtx(context.Background(), fs, files, sync)
+}
+
+// FsChildDepRmCtx is FsChildDepRm with a context used for cancellation and deadlines.
+func (c *ClientConnection) FsChildDepRmCtx(
+	ctx context.Context,
 	fs *FileSystem, files []string, sync bool) (*string, error) {
 	args := map[string]interface{}{"fs": *fs, "files": files}
 	var result json.RawMessage
-	return c.getJobOrNone(c.tp.invoke("fs_child_dependency_rm", args, &result), result, sync)
+	return c.getJobOrNone(ctx, c.invoke(ctx, "fs_child_dependency_rm", args, &result), result, sync)
 }
 
 // AccessGroupCreate creates an access group.
 func (c *ClientConnection) AccessGroupCreate(name string, initID string,
 	initType InitiatorType, system *System) (*AccessGroup, error) {
+	return c.AccessGroupCreateCtx(context.Background(), name, initID, initType, system)
+}
+
+// AccessGroupCreateCtx is AccessGroupCreate with a context used for cancellation and deadlines.
+func (c *ClientConnection) AccessGroupCreateCtx(ctx context.Context, name string, initID string,
+	initType InitiatorType, system *System) (*AccessGroup, error) {
 
 	if check := validateInitID(initID, initType); check != nil {
 		return nil, check
@@ -726,7 +1075,7 @@ func (c *ClientConnection) AccessGroupCreate(name string, initID string,
 		"system":    *system,
 	}
 	var accessGroup AccessGroup
-	if err := c.tp.invoke("access_group_create", args, &accessGroup); err != nil {
+	if err := c.invoke(ctx, "access_group_create", args, &accessGroup); err != nil {
 		return nil, err
 	}
 	return &accessGroup, nil
@@ -734,8 +1083,13 @@ func (c *ClientConnection) AccessGroupCreate(name string, initID string,
 
 // AccessGroupDelete deletes an access group.
 func (c *ClientConnection) AccessGroupDelete(ag *AccessGroup) error {
+	return c.AccessGroupDeleteCtx(context.Background(), ag)
+}
+
+// AccessGroupDeleteCtx is AccessGroupDelete with a context used for cancellation and deadlines.
+func (c *ClientConnection) AccessGroupDeleteCtx(ctx context.Context, ag *AccessGroup) error {
 	args := map[string]interface{}{"access_group": *ag}
-	return c.tp.invoke("access_group_delete", args, nil)
+	return c.invoke(ctx, "access_group_delete", args, nil)
 }
 
 func initSetup(initID string,
@@ -747,6 +1101,12 @@ func initSetup(initID string,
 // AccessGroupInitAdd adds an initiator to an access group.
 func (c *ClientConnection) AccessGroupInitAdd(ag *AccessGroup,
 	initID string, initType InitiatorType) (*AccessGroup, error) {
+	return c.AccessGroupInitAddCtx(context.Background(), ag, initID, initType)
+}
+
+// AccessGroupInitAddCtx is AccessGroupInitAdd with a context used for cancellation and deadlines.
+func (c *ClientConnection) AccessGroupInitAddCtx(ctx context.Context, ag *AccessGroup,
+	initID string, initType InitiatorType) (*AccessGroup, error) {
 
 	var args, setupErr = initSetup(initID, initType, ag)
 	if setupErr != nil {
@@ -754,7 +1114,7 @@ func (c *ClientConnection) AccessGroupInitAdd(ag *AccessGroup,
 	}
 
 	var accessGroup AccessGroup
-	if err := c.tp.invoke("access_group_initiator_add", args, &accessGroup); err != nil {
+	if err := c.invoke(ctx, "access_group_initiator_add", args, &accessGroup); err != nil {
 		return nil, err
 	}
 	return &accessGroup, nil
@@ -762,13 +1122,19 @@ func (c *ClientConnection) AccessGroupInitAdd(ag *AccessGroup,
 
 // AccessGroupInitDelete deletes an initiator from an access group.
 func (c *ClientConnection) AccessGroupInitDelete(ag *AccessGroup,
+	initID string, initType InitiatorType) (*AccessGroup, error) {
+	return c.AccessGroupInitDeleteCtx(context.Background(), ag, initID, initType)
+}
+
+// AccessGroupInitDeleteCtx is AccessGroupInitDelete with a context used for cancellation and deadlines.
+func (c *ClientConnection) AccessGroupInitDeleteCtx(ctx context.Context, ag *AccessGroup,
 	initID string, initType InitiatorType) (*AccessGroup, error) {
 	var args, setupErr = initSetup(initID, initType, ag)
 	if setupErr != nil {
 		return nil, setupErr
 	}
 	var accessGroup AccessGroup
-	if err := c.tp.invoke("access_group_initiator_delete", args, &accessGroup); err != nil {
+	if err := c.invoke(ctx, "access_group_initiator_delete", args, &accessGroup); err != nil {
 		return nil, err
 	}
 	return &accessGroup, nil
@@ -776,10 +1142,15 @@ func (c *ClientConnection) AccessGroupInitDelete(ag *AccessGroup,
 
 // VolRaidInfo retrieves RAID information about specified volume.
 func (c *ClientConnection) VolRaidInfo(vol *Volume) (*VolumeRaidInfo, error) {
+	return c.VolRaidInfoCtx(context.Background(), vol)
+}
+
+// VolRaidInfoCtx is VolRaidInfo with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolRaidInfoCtx(ctx context.Context, vol *Volume) (*VolumeRaidInfo, error) {
 	args := map[string]interface{}{"volume": *vol}
 
 	var ret [5]int32
-	if err := c.tp.invoke("volume_raid_info", args, &ret); err != nil {
+	if err := c.invoke(ctx, "volume_raid_info", args, &ret); err != nil {
 		return nil, err
 	}
 	var info VolumeRaidInfo
@@ -793,10 +1164,15 @@ func (c *ClientConnection) VolRaidInfo(vol *Volume) (*VolumeRaidInfo, error) {
 
 // PoolMemberInfo retrieves RAID information about specified volume.
 func (c *ClientConnection) PoolMemberInfo(pool *Pool) (*PoolMemberInfo, error) {
+	return c.PoolMemberInfoCtx(context.Background(), pool)
+}
+
+// PoolMemberInfoCtx is PoolMemberInfo with a context used for cancellation and deadlines.
+func (c *ClientConnection) PoolMemberInfoCtx(ctx context.Context, pool *Pool) (*PoolMemberInfo, error) {
 	args := map[string]interface{}{"pool": *pool}
 
 	var ret [3]json.RawMessage
-	if err := c.tp.invoke("pool_member_info", args, &ret); err != nil {
+	if err := c.invoke(ctx, "pool_member_info", args, &ret); err != nil {
 		return nil, err
 	}
 
@@ -827,9 +1203,14 @@ func (c *ClientConnection) PoolMemberInfo(pool *Pool) (*PoolMemberInfo, error) {
 
 // VolRaidCreateCapGet returns supported RAID types and strip sizes for hardware raid.
 func (c *ClientConnection) VolRaidCreateCapGet(system *System) (*SupportedRaidCapability, error) {
+	return c.VolRaidCreateCapGetCtx(context.Background(), system)
+}
+
+// VolRaidCreateCapGetCtx is VolRaidCreateCapGet with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolRaidCreateCapGetCtx(ctx context.Context, system *System) (*SupportedRaidCapability, error) {
 	args := map[string]interface{}{"system": *system}
 	var ret []json.RawMessage
-	if err := c.tp.invoke("volume_raid_create_cap_get", args, &ret); err != nil {
+	if err := c.invoke(ctx, "volume_raid_create_cap_get", args, &ret); err != nil {
 		return nil, err
 	}
 
@@ -858,6 +1239,12 @@ func paramError(msg string) error {
 // VolRaidCreate creates RAIDed volume directly from disks, only for hardware RAID.
 func (c *ClientConnection) VolRaidCreate(name string,
 	raidType RaidType, disks []Disk, stripSize uint32) (*Volume, error) {
+	return c.VolRaidCreateCtx(context.Background(), name, raidType, disks, stripSize)
+}
+
+// VolRaidCreateCtx is VolRaidCreate with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolRaidCreateCtx(ctx context.Context, name string,
+	raidType RaidType, disks []Disk, stripSize uint32) (*Volume, error) {
 
 	if len(disks) == 0 {
 		return nil, paramError("no disks included")
@@ -894,33 +1281,48 @@ func (c *ClientConnection) VolRaidCreate(name string,
 		"strip_size": stripSize, //stripe
 	}
 	var returnedVolume Volume
-	if err := c.tp.invoke("volume_raid_create", args, &returnedVolume); err != nil {
+	if err := c.invoke(ctx, "volume_raid_create", args, &returnedVolume); err != nil {
 		return nil, err
 	}
 	return &returnedVolume, nil
 }
 
-func (c *ClientConnection) identLED(volume *Volume, method string) error {
+func (c *ClientConnection) identLED(ctx context.Context, volume *Volume, method string) error {
 	args := map[string]interface{}{"volume": *volume}
-	return c.tp.invoke(method, args, nil)
+	return c.invoke(ctx, method, args, nil)
 }
 
 // VolIdentLedOn turn on the identification LED for the specified volume.
 func (c *ClientConnection) VolIdentLedOn(volume *Volume) error {
-	return c.identLED(volume, "volume_ident_led_on")
+	return c.VolIdentLedOnCtx(context.Background(), volume)
+}
+
+// VolIdentLedOnCtx is VolIdentLedOn with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolIdentLedOnCtx(ctx context.Context, volume *Volume) error {
+	return c.identLED(ctx, volume, "volume_ident_led_on")
 }
 
 // VolIdentLedOff turn off the identification LED for the specified volume.
 func (c *ClientConnection) VolIdentLedOff(volume *Volume) error {
-	return c.identLED(volume, "volume_ident_led_off")
+	return c.VolIdentLedOffCtx(context.Background(), volume)
+}
+
+// VolIdentLedOffCtx is VolIdentLedOff with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolIdentLedOffCtx(ctx context.Context, volume *Volume) error {
+	return c.identLED(ctx, volume, "volume_ident_led_off")
 }
 
 // VolCacheInfo returns cache information for specified volume
 func (c *ClientConnection) VolCacheInfo(volume *Volume) (*VolumeCacheInfo, error) {
+	return c.VolCacheInfoCtx(context.Background(), volume)
+}
+
+// VolCacheInfoCtx is VolCacheInfo with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolCacheInfoCtx(ctx context.Context, volume *Volume) (*VolumeCacheInfo, error) {
 	args := map[string]interface{}{"volume": *volume}
 
 	var ret [5]uint32
-	if err := c.tp.invoke("volume_cache_info", args, &ret); err != nil {
+	if err := c.invoke(ctx, "volume_cache_info", args, &ret); err != nil {
 		return nil, err
 	}
 
@@ -935,27 +1337,42 @@ func (c *ClientConnection) VolCacheInfo(volume *Volume) (*VolumeCacheInfo, error
 
 // VolPhyDiskCacheSet set the volume physical disk cache policy
 func (c *ClientConnection) VolPhyDiskCacheSet(volume *Volume, pdc PhysicalDiskCache) error {
+	return c.VolPhyDiskCacheSetCtx(context.Background(), volume, pdc)
+}
+
+// VolPhyDiskCacheSetCtx is VolPhyDiskCacheSet with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolPhyDiskCacheSetCtx(ctx context.Context, volume *Volume, pdc PhysicalDiskCache) error {
 	args := map[string]interface{}{
 		"volume": *volume,
 		"pdc":    pdc,
 	}
-	return c.tp.invoke("volume_physical_disk_cache_update", args, nil)
+	return c.invoke(ctx, "volume_physical_disk_cache_update", args, nil)
 }
 
 // VolWriteCacheSet sets volume write cache policy
 func (c *ClientConnection) VolWriteCacheSet(volume *Volume, wcp WriteCachePolicy) error {
+	return c.VolWriteCacheSetCtx(context.Background(), volume, wcp)
+}
+
+// VolWriteCacheSetCtx is VolWriteCacheSet with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolWriteCacheSetCtx(ctx context.Context, volume *Volume, wcp WriteCachePolicy) error {
 	args := map[string]interface{}{
 		"volume": *volume,
 		"wcp":    wcp,
 	}
-	return c.tp.invoke("volume_write_cache_policy_update", args, nil)
+	return c.invoke(ctx, "volume_write_cache_policy_update", args, nil)
 }
 
 // VolReadCacheSet sets volume read cache policy
 func (c *ClientConnection) VolReadCacheSet(volume *Volume, rcp ReadCachePolicy) error {
+	return c.VolReadCacheSetCtx(context.Background(), volume, rcp)
+}
+
+// VolReadCacheSetCtx is VolReadCacheSet with a context used for cancellation and deadlines.
+func (c *ClientConnection) VolReadCacheSetCtx(ctx context.Context, volume *Volume, rcp ReadCachePolicy) error {
 	args := map[string]interface{}{
 		"volume": *volume,
 		"rcp":    rcp,
 	}
-	return c.tp.invoke("volume_read_cache_policy_update", args, nil)
+	return c.invoke(ctx, "volume_read_cache_policy_update", args, nil)
 }
@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// capabilityCache holds the capabilities of the systems by ID for the
+// capability checks.
+type capabilityCache struct {
+	lock    sync.Mutex
+	enabled bool
+	systems map[string]*Capabilities
+}
+
+func (cc *capabilityCache) on() bool {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	return cc.enabled
+}
+
+func (cc *capabilityCache) get(systemID string) *Capabilities {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	return cc.systems[systemID]
+}
+
+func (cc *capabilityCache) put(systemID string, caps *Capabilities) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	if cc.systems == nil {
+		cc.systems = make(map[string]*Capabilities)
+	}
+	cc.systems[systemID] = caps
+}
+
+// methodCapabilities are the capabilities the plugin methods need, the
+// variants, eg. of the replication type, are added by neededCapabilities.
+var methodCapabilities = map[string][]CapabilityType{
+	"volume_create":                      {CapVolumeCreate},
+	"volume_delete":                      {CapVolumeDelete},
+	"volume_resize":                      {CapVolumeCResize},
+	"volume_replicate":                   {CapVolumeCReplicate},
+	"volume_replicate_range_block_size":  {CapVolumeCopyRangeBlockSize},
+	"volume_replicate_range":             {CapVolumeCopyRange},
+	"volume_enable":                      {CapVolumeEnable},
+	"volume_disable":                     {CapVolumeDisable},
+	"volume_mask":                        {CapVolumeMask},
+	"volume_unmask":                      {CapVolumeUnmask},
+	"volumes_accessible_by_access_group": {CapVolumesMaskedToAg},
+	"access_groups_granted_to_volume":    {CapAgsGrantedToVol},
+	"volume_child_dependency":            {CapHasChildDep},
+	"volume_child_dependency_rm":         {CapChildDepRm},
+	"access_group_delete":                {CapAccessGroupDelete},
+	"access_group_initiator_delete":      {CapAccessGroupInitiatorDel},
+	"volume_ident_led_on":                {CapVolumeLed},
+	"volume_ident_led_off":               {CapVolumeLed},
+
+	"fs_create":              {CapFsCreate},
+	"fs_delete":              {CapFsDelete},
+	"fs_resize":              {CapFsResize},
+	"fs_clone":               {CapFsClone},
+	"fs_file_clone":          {CapFsFileClone},
+	"fs_snapshot_create":     {CapFsSnapshotCreate},
+	"fs_snapshot_delete":     {CapFsSnapshotDelete},
+	"fs_snapshots":           {CapFsSnapshots},
+	"fs_snapshot_restore":    {CapFsSnapshotRestore},
+	"fs_child_dependency":    {CapFsHasChildDep},
+	"fs_child_dependency_rm": {CapFsChildDepRm},
+
+	"volume_raid_info":           {CapVolRaidInfo},
+	"pool_member_info":           {CapPoolMemberInfo},
+	"volume_raid_create_cap_get": {CapVolumeRaidCreate},
+	"volume_raid_create":         {CapVolumeRaidCreate},
+
+	"system_read_cache_pct_update":      {CapSysReadCachePctSet},
+	"volume_cache_info":                 {CapVolCacheInfo},
+	"volume_physical_disk_cache_update": {CapVolPhyDiskCacheSet},
+	"volume_read_cache_policy_update":   {CapVolReadCacheSet},
+}
+
+var (
+	replicateCapabilities = map[VolumeReplicateType]CapabilityType{
+		VolumeReplicateTypeClone:       CapVolumeCReplicateClone,
+		VolumeReplicateTypeCopy:        CapVolumeCReplicateCopy,
+		VolumeReplicateTypeMirrorAsync: CapVolumeCReplicateMirrorAsync,
+		VolumeReplicateTypeMirrorSync:  CapVolumeCReplicateMirrorSync,
+	}
+
+	copyRangeCapabilities = map[VolumeReplicateType]CapabilityType{
+		VolumeReplicateTypeClone: CapVolumeCopyRangeClone,
+		VolumeReplicateTypeCopy:  CapVolumeCopyRangeCopy,
+	}
+
+	writeCacheCapabilities = map[WriteCachePolicy]CapabilityType{
+		WriteCachePolicyWriteBack:    CapVolWriteCacheSetEnable,
+		WriteCachePolicyAuto:         CapVolWriteCacheSetAuto,
+		WriteCachePolicyWriteThrough: CapVolWriteCacheSetDisabled,
+	}
+
+	agCreateCapabilities = map[InitiatorType]CapabilityType{
+		InitiatorTypeWwpn:     CapAccessGroupCreateWwpn,
+		InitiatorTypeIscsiIqn: CapAccessGroupCreateIscsiIqn,
+	}
+
+	agInitAddCapabilities = map[InitiatorType]CapabilityType{
+		InitiatorTypeWwpn:     CapAccessGroupInitiatorAddWwpn,
+		InitiatorTypeIscsiIqn: CapAccessGroupInitAddIscsiIqn,
+	}
+)
+
+// neededCapabilities returns the capabilities the call of method with args
+// needs.
+func neededCapabilities(method string, args map[string]interface{}) []CapabilityType {
+	needed := append([]CapabilityType{}, methodCapabilities[method]...)
+
+	var variant CapabilityType
+	var ok bool
+	repType, _ := args["rep_type"].(VolumeReplicateType)
+	initType, _ := args["init_type"].(InitiatorType)
+
+	switch method {
+	case "volume_create":
+		provisioning, _ := args["provisioning"].(VolumeProvisionType)
+		variant, ok = CapVolumeThin, provisioning == VolumeProvisionTypeThin
+	case "volume_replicate":
+		variant, ok = replicateCapabilities[repType]
+	case "volume_replicate_range":
+		variant, ok = copyRangeCapabilities[repType]
+	case "volume_write_cache_policy_update":
+		wcp, _ := args["wcp"].(WriteCachePolicy)
+		variant, ok = writeCacheCapabilities[wcp]
+	case "access_group_create":
+		variant, ok = agCreateCapabilities[initType]
+	case "access_group_initiator_add":
+		variant, ok = agInitAddCapabilities[initType]
+	}
+	if ok {
+		needed = append(needed, variant)
+	}
+	return needed
+}
+
+// systemOf returns the ID of the system the arguments belong to.
+func systemOf(args map[string]interface{}) (string, bool) {
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case System:
+			return a.ID, true
+		case Pool:
+			return a.SystemID, true
+		case Volume:
+			return a.SystemID, true
+		case FileSystem:
+			return a.SystemID, true
+		case AccessGroup:
+			return a.SystemID, true
+		case []Disk:
+			if len(a) > 0 {
+				return a[0].SystemID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// CapabilityChecks turns checking calls against the capabilities of the
+// system on or off, it is off by default.  When on, the capabilities of each
+// system are fetched once and cached for the life of the connection, calls
+// needing a capability the system lacks fail with NoSupport without reaching
+// the plugin.  Calls whose arguments don't tell the system aren't checked.
+func (c *ClientConnection) CapabilityChecks(on bool) {
+	c.caps.lock.Lock()
+	defer c.caps.lock.Unlock()
+	c.caps.enabled = on
+}
+
+// Require returns a NoSupport error naming the first of the capabilities the
+// system lacks, nil if it has them all.  The capabilities are cached as for
+// CapabilityChecks.
+func (c *ClientConnection) Require(system *System, caps ...CapabilityType) error {
+	return c.RequireCtx(context.Background(), system, caps...)
+}
+
+// RequireCtx is Require with a context used for cancellation and deadlines.
+func (c *ClientConnection) RequireCtx(ctx context.Context, system *System, caps ...CapabilityType) error {
+	supported := c.caps.get(system.ID)
+	if supported == nil {
+		var err error
+		if supported, err = c.CapabilitiesCtx(ctx, system); err != nil {
+			return err
+		}
+		c.caps.put(system.ID, supported)
+	}
+
+	for _, capability := range caps {
+		if !supported.IsSupported(capability) {
+			return &errors.LsmError{
+				Code:    errors.NoSupport,
+				Message: fmt.Sprintf("system %s lacks capability %s", system.ID, capability)}
+		}
+	}
+	return nil
+}
+
+// requireByID is RequireCtx for the system with the ID, which is looked up
+// unless its capabilities are cached.  Systems missing from the listing, which
+// may be yet to appear, are left to the plugin and looked up again next time.
+func (c *ClientConnection) requireByID(ctx context.Context, systemID string, caps []CapabilityType) error {
+	if c.caps.get(systemID) != nil {
+		return c.RequireCtx(ctx, &System{ID: systemID}, caps...)
+	}
+
+	systems, err := c.SystemsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range systems {
+		if systems[i].ID == systemID {
+			return c.RequireCtx(ctx, &systems[i], caps...)
+		}
+	}
+	return nil
+}
+
+// invoke calls the plugin method, after checking the capabilities it needs
+// when the checks are on.
+func (c *ClientConnection) invoke(ctx context.Context, method string, args map[string]interface{},
+	result interface{}) error {
+
+	if c.caps.on() {
+		if needed := neededCapabilities(method, args); len(needed) > 0 {
+			if systemID, ok := systemOf(args); ok && len(systemID) > 0 {
+				if err := c.requireByID(ctx, systemID, needed); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return c.tp.invoke(ctx, method, args, result)
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import "context"
+
+// The client interfaces follow the grouping of the plugin callbacks, so code
+// only needing part of the API can say so and be tested against a fake, see
+// the fake package.
+
+// ManagementClient covers the plugin connection itself, systems, pools,
+// capabilities and jobs.
+type ManagementClient interface {
+	PluginInfo() (*PluginInfo, error)
+	PluginInfoCtx(ctx context.Context) (*PluginInfo, error)
+	Close() error
+	CloseCtx(ctx context.Context) error
+	Systems() ([]System, error)
+	SystemsCtx(ctx context.Context) ([]System, error)
+	Pools(search ...string) ([]Pool, error)
+	PoolsCtx(ctx context.Context, search ...string) ([]Pool, error)
+	PoolsBy(ctx context.Context, search Search) ([]Pool, error)
+	Capabilities(system *System) (*Capabilities, error)
+	CapabilitiesCtx(ctx context.Context, system *System) (*Capabilities, error)
+	TimeOutSet(milliSeconds uint32) error
+	TimeOutSetCtx(ctx context.Context, milliSeconds uint32) error
+	TimeOutGet() uint32
+	JobStatus(jobID string, returnedResult interface{}) (JobStatusType, uint8, error)
+	JobStatusCtx(ctx context.Context, jobID string, returnedResult interface{}) (JobStatusType, uint8, error)
+	JobFree(jobID string) error
+	JobFreeCtx(ctx context.Context, jobID string) error
+	JobWait(jobID string, returnedResult interface{}) error
+	JobWaitCtx(ctx context.Context, jobID string, returnedResult interface{}) error
+	Job(jobID string, result interface{}) *Job
+}
+
+// SanClient covers block storage, volumes, disks, access groups and target
+// ports.
+type SanClient interface {
+	Volumes(search ...string) ([]Volume, error)
+	VolumesCtx(ctx context.Context, search ...string) ([]Volume, error)
+	VolumesBy(ctx context.Context, search Search) ([]Volume, error)
+	Disks() ([]Disk, error)
+	DisksCtx(ctx context.Context) ([]Disk, error)
+	VolumeCreate(
+		pool *Pool,
+		volumeName string,
+		size uint64,
+		provisioning VolumeProvisionType,
+		sync bool) (*Volume, *string, error)
+	VolumeCreateCtx(
+		ctx context.Context,
+		pool *Pool,
+		volumeName string,
+		size uint64,
+		provisioning VolumeProvisionType,
+		sync bool) (*Volume, *string, error)
+	VolumeDelete(vol *Volume, sync bool) (*string, error)
+	VolumeDeleteCtx(ctx context.Context, vol *Volume, sync bool) (*string, error)
+	VolumeResize(vol *Volume, newSizeBytes uint64, sync bool) (*Volume, *string, error)
+	VolumeResizeCtx(ctx context.Context, vol *Volume, newSizeBytes uint64, sync bool) (*Volume, *string, error)
+	VolumeReplicate(
+		optionalPool *Pool, repType VolumeReplicateType, sourceVolume *Volume, name string,
+		sync bool) (*Volume, *string, error)
+	VolumeReplicateCtx(
+		ctx context.Context,
+		optionalPool *Pool, repType VolumeReplicateType, sourceVolume *Volume, name string,
+		sync bool) (*Volume, *string, error)
+	VolumeRepRangeBlkSize(system *System) (uint32, error)
+	VolumeRepRangeBlkSizeCtx(ctx context.Context, system *System) (uint32, error)
+	VolumeReplicateRange(
+		repType VolumeReplicateType, srcVol *Volume, dstVol *Volume,
+		ranges []BlockRange, sync bool) (*string, error)
+	VolumeReplicateRangeCtx(
+		ctx context.Context,
+		repType VolumeReplicateType, srcVol *Volume, dstVol *Volume,
+		ranges []BlockRange, sync bool) (*string, error)
+	VolumeEnable(vol *Volume) error
+	VolumeEnableCtx(ctx context.Context, vol *Volume) error
+	VolumeDisable(vol *Volume) error
+	VolumeDisableCtx(ctx context.Context, vol *Volume) error
+	VolumeMask(vol *Volume, ag *AccessGroup) error
+	VolumeMaskCtx(ctx context.Context, vol *Volume, ag *AccessGroup) error
+	VolumeUnMask(vol *Volume, ag *AccessGroup) error
+	VolumeUnMaskCtx(ctx context.Context, vol *Volume, ag *AccessGroup) error
+	VolsMaskedToAg(ag *AccessGroup) ([]Volume, error)
+	VolsMaskedToAgCtx(ctx context.Context, ag *AccessGroup) ([]Volume, error)
+	AgsGrantedToVol(vol *Volume) ([]AccessGroup, error)
+	AgsGrantedToVolCtx(ctx context.Context, vol *Volume) ([]AccessGroup, error)
+	VolHasChildDep(vol *Volume) (bool, error)
+	VolHasChildDepCtx(ctx context.Context, vol *Volume) (bool, error)
+	VolChildDepRm(vol *Volume, sync bool) (*string, error)
+	VolChildDepRmCtx(ctx context.Context, vol *Volume, sync bool) (*string, error)
+	AccessGroups() ([]AccessGroup, error)
+	AccessGroupsCtx(ctx context.Context) ([]AccessGroup, error)
+	AccessGroupCreate(name string, initID string,
+		initType InitiatorType, system *System) (*AccessGroup, error)
+	AccessGroupCreateCtx(ctx context.Context, name string, initID string,
+		initType InitiatorType, system *System) (*AccessGroup, error)
+	AccessGroupDelete(ag *AccessGroup) error
+	AccessGroupDeleteCtx(ctx context.Context, ag *AccessGroup) error
+	AccessGroupInitAdd(ag *AccessGroup,
+		initID string, initType InitiatorType) (*AccessGroup, error)
+	AccessGroupInitAddCtx(ctx context.Context, ag *AccessGroup,
+		initID string, initType InitiatorType) (*AccessGroup, error)
+	AccessGroupInitDelete(ag *AccessGroup,
+		initID string, initType InitiatorType) (*AccessGroup, error)
+	AccessGroupInitDeleteCtx(ctx context.Context, ag *AccessGroup,
+		initID string, initType InitiatorType) (*AccessGroup, error)
+	IscsiChapAuthSet(initID string, inUser *string, inPassword *string,
+		outUser *string, outPassword *string) error
+	IscsiChapAuthSetCtx(ctx context.Context, initID string, inUser *string, inPassword *string,
+		outUser *string, outPassword *string) error
+	TargetPorts() ([]TargetPort, error)
+	TargetPortsCtx(ctx context.Context) ([]TargetPort, error)
+	VolIdentLedOn(volume *Volume) error
+	VolIdentLedOnCtx(ctx context.Context, volume *Volume) error
+	VolIdentLedOff(volume *Volume) error
+	VolIdentLedOffCtx(ctx context.Context, volume *Volume) error
+	VolumeCreateJob(
+		ctx context.Context,
+		pool *Pool,
+		volumeName string,
+		size uint64,
+		provisioning VolumeProvisionType) (*Volume, *Job, error)
+	VolumeDeleteJob(ctx context.Context, vol *Volume) (*Job, error)
+	VolumeResizeJob(ctx context.Context, vol *Volume, newSizeBytes uint64) (*Volume, *Job, error)
+	VolumeReplicateJob(
+		ctx context.Context,
+		optionalPool *Pool, repType VolumeReplicateType, sourceVolume *Volume, name string) (*Volume, *Job, error)
+	VolumeReplicateRangeJob(
+		ctx context.Context,
+		repType VolumeReplicateType, srcVol *Volume, dstVol *Volume,
+		ranges []BlockRange) (*Job, error)
+	VolChildDepRmJob(ctx context.Context, vol *Volume) (*Job, error)
+	VolumeCreateSize(ctx context.Context, pool *Pool, volumeName string, size Size,
+		provisioning VolumeProvisionType) (*Volume, *Job, error)
+	VolumeResizeSize(ctx context.Context, vol *Volume, newSize Size) (*Volume, *Job, error)
+}
+
+// FsClient covers file systems and their snapshots.
+type FsClient interface {
+	FileSystems(search ...string) ([]FileSystem, error)
+	FileSystemsCtx(ctx context.Context, search ...string) ([]FileSystem, error)
+	FileSystemsBy(ctx context.Context, search Search) ([]FileSystem, error)
+	FsCreate(
+		pool *Pool,
+		name string,
+		size uint64,
+		sync bool) (*FileSystem, *string, error)
+	FsCreateCtx(
+		ctx context.Context,
+		pool *Pool,
+		name string,
+		size uint64,
+		sync bool) (*FileSystem, *string, error)
+	FsResize(
+		fs *FileSystem, newSizeBytes uint64, sync bool) (*FileSystem, *string, error)
+	FsResizeCtx(
+		ctx context.Context,
+		fs *FileSystem, newSizeBytes uint64, sync bool) (*FileSystem, *string, error)
+	FsDelete(fs *FileSystem, sync bool) (*string, error)
+	FsDeleteCtx(ctx context.Context, fs *FileSystem, sync bool) (*string, error)
+	FsClone(
+		srcFs *FileSystem,
+		destName string,
+		optionalSnapShot *FileSystemSnapShot,
+		sync bool) (*FileSystem, *string, error)
+	FsCloneCtx(
+		ctx context.Context,
+		srcFs *FileSystem,
+		destName string,
+		optionalSnapShot *FileSystemSnapShot,
+		sync bool) (*FileSystem, *string, error)
+	FsFileClone(
+		fs *FileSystem,
+		srcFileName string,
+		dstFileName string,
+		optionalSnapShot *FileSystemSnapShot,
+		sync bool,
+	) (*string, error)
+	FsFileCloneCtx(
+		ctx context.Context,
+		fs *FileSystem,
+		srcFileName string,
+		dstFileName string,
+		optionalSnapShot *FileSystemSnapShot,
+		sync bool,
+	) (*string, error)
+	FsSnapShotCreate(fs *FileSystem, name string, sync bool) (*FileSystemSnapShot, *string, error)
+	FsSnapShotCreateCtx(ctx context.Context, fs *FileSystem, name string, sync bool) (*FileSystemSnapShot, *string, error)
+	FsSnapShotDelete(fs *FileSystem, snapShot *FileSystemSnapShot, sync bool) (*string, error)
+	FsSnapShotDeleteCtx(ctx context.Context, fs *FileSystem, snapShot *FileSystemSnapShot, sync bool) (*string, error)
+	FsSnapShots(fs *FileSystem) ([]FileSystemSnapShot, error)
+	FsSnapShotsCtx(ctx context.Context, fs *FileSystem) ([]FileSystemSnapShot, error)
+	FsSnapShotRestore(
+		fs *FileSystem, snapShot *FileSystemSnapShot, allFiles bool,
+		files []string, restoreFiles []string, sync bool) (*string, error)
+	FsSnapShotRestoreCtx(
+		ctx context.Context,
+		fs *FileSystem, snapShot *FileSystemSnapShot, allFiles bool,
+		files []string, restoreFiles []string, sync bool) (*string, error)
+	FsHasChildDep(fs *FileSystem, files []string) (bool, error)
+	FsHasChildDepCtx(ctx context.Context, fs *FileSystem, files []string) (bool, error)
+	FsChildDepRm(
+		fs *FileSystem, files []string, sync bool) (*string, error)
+	FsChildDepRmCtx(
+		ctx context.Context,
+		fs *FileSystem, files []string, sync bool) (*string, error)
+	FsCreateJob(ctx context.Context, pool *Pool, name string, size uint64) (*FileSystem, *Job, error)
+	FsResizeJob(ctx context.Context, fs *FileSystem, newSizeBytes uint64) (*FileSystem, *Job, error)
+	FsDeleteJob(ctx context.Context, fs *FileSystem) (*Job, error)
+	FsCloneJob(
+		ctx context.Context,
+		srcFs *FileSystem,
+		destName string,
+		optionalSnapShot *FileSystemSnapShot) (*FileSystem, *Job, error)
+	FsFileCloneJob(
+		ctx context.Context,
+		fs *FileSystem,
+		srcFileName string,
+		dstFileName string,
+		optionalSnapShot *FileSystemSnapShot) (*Job, error)
+	FsSnapShotCreateJob(ctx context.Context, fs *FileSystem, name string) (*FileSystemSnapShot, *Job, error)
+	FsSnapShotDeleteJob(ctx context.Context, fs *FileSystem, snapShot *FileSystemSnapShot) (*Job, error)
+	FsSnapShotRestoreJob(
+		ctx context.Context,
+		fs *FileSystem, snapShot *FileSystemSnapShot, allFiles bool,
+		files []string, restoreFiles []string) (*Job, error)
+	FsChildDepRmJob(ctx context.Context, fs *FileSystem, files []string) (*Job, error)
+	FsCreateSize(ctx context.Context, pool *Pool, name string, size Size) (*FileSystem, *Job, error)
+	FsResizeSize(ctx context.Context, fs *FileSystem, newSize Size) (*FileSystem, *Job, error)
+}
+
+// NfsClient covers NFS exports of file systems.
+type NfsClient interface {
+	NfsExports(search ...string) ([]NfsExport, error)
+	NfsExportsCtx(ctx context.Context, search ...string) ([]NfsExport, error)
+	NfsExportsBy(ctx context.Context, search Search) ([]NfsExport, error)
+	NfsExportAuthTypes() ([]string, error)
+	NfsExportAuthTypesCtx(ctx context.Context) ([]string, error)
+	FsExport(fs *FileSystem, exportPath *string,
+		access *NfsAccess, authType *string, options *string) (*NfsExport, error)
+	FsExportCtx(ctx context.Context, fs *FileSystem, exportPath *string,
+		access *NfsAccess, authType *string, options *string) (*NfsExport, error)
+	FsUnExport(export *NfsExport) error
+	FsUnExportCtx(ctx context.Context, export *NfsExport) error
+}
+
+// HbaClient covers hardware RAID and batteries.
+type HbaClient interface {
+	VolRaidInfo(vol *Volume) (*VolumeRaidInfo, error)
+	VolRaidInfoCtx(ctx context.Context, vol *Volume) (*VolumeRaidInfo, error)
+	PoolMemberInfo(pool *Pool) (*PoolMemberInfo, error)
+	PoolMemberInfoCtx(ctx context.Context, pool *Pool) (*PoolMemberInfo, error)
+	VolRaidCreateCapGet(system *System) (*SupportedRaidCapability, error)
+	VolRaidCreateCapGetCtx(ctx context.Context, system *System) (*SupportedRaidCapability, error)
+	VolRaidCreate(name string,
+		raidType RaidType, disks []Disk, stripSize uint32) (*Volume, error)
+	VolRaidCreateCtx(ctx context.Context, name string,
+		raidType RaidType, disks []Disk, stripSize uint32) (*Volume, error)
+	Batteries() ([]Battery, error)
+	BatteriesCtx(ctx context.Context) ([]Battery, error)
+}
+
+// CacheClient covers system and volume cache settings.
+type CacheClient interface {
+	SysReadCachePctSet(system *System, readPercent uint32) error
+	SysReadCachePctSetCtx(ctx context.Context, system *System, readPercent uint32) error
+	VolCacheInfo(volume *Volume) (*VolumeCacheInfo, error)
+	VolCacheInfoCtx(ctx context.Context, volume *Volume) (*VolumeCacheInfo, error)
+	VolPhyDiskCacheSet(volume *Volume, pdc PhysicalDiskCache) error
+	VolPhyDiskCacheSetCtx(ctx context.Context, volume *Volume, pdc PhysicalDiskCache) error
+	VolWriteCacheSet(volume *Volume, wcp WriteCachePolicy) error
+	VolWriteCacheSetCtx(ctx context.Context, volume *Volume, wcp WriteCachePolicy) error
+	VolReadCacheSet(volume *Volume, rcp ReadCachePolicy) error
+	VolReadCacheSetCtx(ctx context.Context, volume *Volume, rcp ReadCachePolicy) error
+}
+
+// StorageClient is everything a ClientConnection can do.
+type StorageClient interface {
+	ManagementClient
+	SanClient
+	FsClient
+	NfsClient
+	HbaClient
+	CacheClient
+}
+
+var _ StorageClient = (*ClientConnection)(nil)
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import "context"
+
+// The ...Job variants of the asynchronous calls return a Job to wait on rather
+// than a job ID.  The item returned is filled in once Wait returns nil, when
+// the plugin completed the call without a job the Job has an empty ID and has
+// finished already.
+
+func volumeJob(c ManagementClient, vol *Volume, jobID *string, err error) (*Volume, *Job, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	if vol == nil {
+		vol = &Volume{}
+	}
+	return vol, AsyncJob(c, jobID, vol), nil
+}
+
+func fsJob(c ManagementClient, fs *FileSystem, jobID *string, err error) (*FileSystem, *Job, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	if fs == nil {
+		fs = &FileSystem{}
+	}
+	return fs, AsyncJob(c, jobID, fs), nil
+}
+
+func noneJob(c ManagementClient, jobID *string, err error) (*Job, error) {
+	if err != nil {
+		return nil, err
+	}
+	return AsyncJob(c, jobID, nil), nil
+}
+
+// VolumeCreateJob is VolumeCreateCtx returning a Job.
+func (c *ClientConnection) VolumeCreateJob(
+	ctx context.Context,
+	pool *Pool,
+	volumeName string,
+	size uint64,
+	provisioning VolumeProvisionType) (*Volume, *Job, error) {
+	vol, jobID, err := c.VolumeCreateCtx(ctx, pool, volumeName, size, provisioning, false)
+	return volumeJob(c, vol, jobID, err)
+}
+
+// VolumeDeleteJob is VolumeDeleteCtx returning a Job.
+func (c *ClientConnection) VolumeDeleteJob(ctx context.Context, vol *Volume) (*Job, error) {
+	jobID, err := c.VolumeDeleteCtx(ctx, vol, false)
+	return noneJob(c, jobID, err)
+}
+
+// VolumeResizeJob is VolumeResizeCtx returning a Job.
+func (c *ClientConnection) VolumeResizeJob(ctx context.Context, vol *Volume, newSizeBytes uint64) (*Volume, *Job, error) {
+	resized, jobID, err := c.VolumeResizeCtx(ctx, vol, newSizeBytes, false)
+	return volumeJob(c, resized, jobID, err)
+}
+
+// VolumeReplicateJob is VolumeReplicateCtx returning a Job.
+func (c *ClientConnection) VolumeReplicateJob(
+	ctx context.Context,
+	optionalPool *Pool, repType VolumeReplicateType, sourceVolume *Volume, name string) (*Volume, *Job, error) {
+	vol, jobID, err := c.VolumeReplicateCtx(ctx, optionalPool, repType, sourceVolume, name, false)
+	return volumeJob(c, vol, jobID, err)
+}
+
+// VolumeReplicateRangeJob is VolumeReplicateRangeCtx returning a Job.
+func (c *ClientConnection) VolumeReplicateRangeJob(
+	ctx context.Context,
+	repType VolumeReplicateType, srcVol *Volume, dstVol *Volume,
+	ranges []BlockRange) (*Job, error) {
+	jobID, err := c.VolumeReplicateRangeCtx(ctx, repType, srcVol, dstVol, ranges, false)
+	return noneJob(c, jobID, err)
+}
+
+// VolChildDepRmJob is VolChildDepRmCtx returning a Job.
+func (c *ClientConnection) VolChildDepRmJob(ctx context.Context, vol *Volume) (*Job, error) {
+	jobID, err := c.VolChildDepRmCtx(ctx, vol, false)
+	return noneJob(c, jobID, err)
+}
+
+// FsCreateJob is FsCreateCtx returning a Job.
+func (c *ClientConnection) FsCreateJob(ctx context.Context, pool *Pool, name string, size uint64) (*FileSystem, *Job, error) {
+	fs, jobID, err := c.FsCreateCtx(ctx, pool, name, size, false)
+	return fsJob(c, fs, jobID, err)
+}
+
+// FsResizeJob is FsResizeCtx returning a Job.
+func (c *ClientConnection) FsResizeJob(ctx context.Context, fs *FileSystem, newSizeBytes uint64) (*FileSystem, *Job, error) {
+	resized, jobID, err := c.FsResizeCtx(ctx, fs, newSizeBytes, false)
+	return fsJob(c, resized, jobID, err)
+}
+
+// FsDeleteJob is FsDeleteCtx returning a Job.
+func (c *ClientConnection) FsDeleteJob(ctx context.Context, fs *FileSystem) (*Job, error) {
+	jobID, err := c.FsDeleteCtx(ctx, fs, false)
+	return noneJob(c, jobID, err)
+}
+
+// FsCloneJob is FsCloneCtx returning a Job.
+func (c *ClientConnection) FsCloneJob(
+	ctx context.Context,
+	srcFs *FileSystem,
+	destName string,
+	optionalSnapShot *FileSystemSnapShot) (*FileSystem, *Job, error) {
+	fs, jobID, err := c.FsCloneCtx(ctx, srcFs, destName, optionalSnapShot, false)
+	return fsJob(c, fs, jobID, err)
+}
+
+// FsFileCloneJob is FsFileCloneCtx returning a Job.
+func (c *ClientConnection) FsFileCloneJob(
+	ctx context.Context,
+	fs *FileSystem,
+	srcFileName string,
+	dstFileName string,
+	optionalSnapShot *FileSystemSnapShot) (*Job, error) {
+	jobID, err := c.FsFileCloneCtx(ctx, fs, srcFileName, dstFileName, optionalSnapShot, false)
+	return noneJob(c, jobID, err)
+}
+
+// FsSnapShotCreateJob is FsSnapShotCreateCtx returning a Job.
+func (c *ClientConnection) FsSnapShotCreateJob(ctx context.Context, fs *FileSystem, name string) (*FileSystemSnapShot, *Job, error) {
+	ss, jobID, err := c.FsSnapShotCreateCtx(ctx, fs, name, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ss == nil {
+		ss = &FileSystemSnapShot{}
+	}
+	return ss, AsyncJob(c, jobID, ss), nil
+}
+
+// FsSnapShotDeleteJob is FsSnapShotDeleteCtx returning a Job.
+func (c *ClientConnection) FsSnapShotDeleteJob(ctx context.Context, fs *FileSystem, snapShot *FileSystemSnapShot) (*Job, error) {
+	jobID, err := c.FsSnapShotDeleteCtx(ctx, fs, snapShot, false)
+	return noneJob(c, jobID, err)
+}
+
+// FsSnapShotRestoreJob is FsSnapShotRestoreCtx returning a Job.
+func (c *ClientConnection) FsSnapShotRestoreJob(
+	ctx context.Context,
+	fs *FileSystem, snapShot *FileSystemSnapShot, allFiles bool,
+	files []string, restoreFiles []string) (*Job, error) {
+	jobID, err := c.FsSnapShotRestoreCtx(ctx, fs, snapShot, allFiles, files, restoreFiles, false)
+	return noneJob(c, jobID, err)
+}
+
+// FsChildDepRmJob is FsChildDepRmCtx returning a Job.
+func (c *ClientConnection) FsChildDepRmJob(ctx context.Context, fs *FileSystem, files []string) (*Job, error) {
+	jobID, err := c.FsChildDepRmCtx(ctx, fs, files, false)
+	return noneJob(c, jobID, err)
+}
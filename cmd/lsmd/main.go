@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: 0BSD
+
+// lsmd serves libStorageMgmt plugins to clients, like the daemon of the same
+// name shipped with libStorageMgmt.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	"github.com/libstorage/libstoragemgmt-golang/daemon"
+)
+
+func main() {
+	var cfg daemon.Config
+	var verbose bool
+
+	flag.StringVar(&cfg.PluginDir, "plugindir", "/usr/bin", "folder to search for *_lsmplugin executables")
+	flag.StringVar(&cfg.SocketDir, "socketdir", lsm.IpcPath(), "folder to create the plugin sockets in")
+	flag.StringVar(&cfg.ConfDir, "confdir", "/etc/lsm", "folder containing lsmd.conf and pluginconf.d")
+	flag.StringVar(&cfg.User, "user", "libstoragemgmt", "user to run plugins as when started as root")
+	flag.BoolVar(&verbose, "v", false, "log what the daemon is doing to stderr")
+	flag.Parse()
+
+	if verbose {
+		cfg.Log = log.New(os.Stderr, "lsmd: ", log.LstdFlags)
+	}
+
+	d, err := daemon.New(cfg)
+	if err != nil {
+		fmt.Printf("Failed to start, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		d.Close()
+	}()
+
+	if err := d.Run(); err != nil {
+		fmt.Printf("Failed to serve plugins, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+}
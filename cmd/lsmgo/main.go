@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: 0BSD
+
+// lsmgo is a command line tool for managing storage, see package cli.
+package main
+
+import (
+	"os"
+
+	"github.com/libstorage/libstoragemgmt-golang/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: 0BSD
+
+// lsmgrpc serves the gRPC storage service of package lsmgrpc for one plugin.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	"github.com/libstorage/libstoragemgmt-golang/lsmgrpc"
+)
+
+func main() {
+	var listen, uri, password string
+	var timeout uint
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:7798", "TCP address to listen on")
+	flag.StringVar(&uri, "uri", "sim://", "plugin URI")
+	flag.StringVar(&password, "password", "", "plugin password")
+	flag.UintVar(&timeout, "timeout", 30000, "plugin timeout in milliseconds")
+	flag.Parse()
+
+	c, err := lsm.Client(uri, password, uint32(timeout))
+	if err != nil {
+		fmt.Printf("Unable to connect to plugin, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	l, err := net.Listen("tcp", listen)
+	if err != nil {
+		fmt.Printf("Unable to listen, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+
+	server := grpc.NewServer()
+	lsmgrpc.RegisterStorageServer(server, lsmgrpc.NewServer(c))
+	if err := server.Serve(l); err != nil {
+		fmt.Printf("Failed to serve, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: 0BSD
+
+// lsmproxy gives remote clients with a trusted certificate access to the
+// local lsmd plugins over TCP with mutual TLS.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	"github.com/libstorage/libstoragemgmt-golang/proxy"
+)
+
+func main() {
+	var server proxy.Server
+	var listen, cert, key, ca string
+	var verbose bool
+
+	flag.StringVar(&listen, "listen", ":7799", "TCP address to listen on")
+	flag.StringVar(&cert, "cert", "", "PEM file with the proxy certificate")
+	flag.StringVar(&key, "key", "", "PEM file with the proxy private key")
+	flag.StringVar(&ca, "ca", "", "PEM file with the CAs client certificates must be signed by")
+	flag.StringVar(&server.SocketDir, "socketdir", lsm.IpcPath(), "folder containing the plugin sockets")
+	flag.BoolVar(&verbose, "v", false, "log connections to stderr")
+	flag.Parse()
+
+	config, err := proxy.ServerConfig(cert, key, ca)
+	if err != nil {
+		fmt.Printf("Unable to load certificates, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+	server.TLSConfig = config
+
+	if verbose {
+		server.Log = log.New(os.Stderr, "lsmproxy: ", log.LstdFlags)
+	}
+
+	if err := server.ListenAndServe(listen); err != nil {
+		fmt.Printf("Failed to serve, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+}
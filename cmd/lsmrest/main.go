@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: 0BSD
+
+// lsmrest serves the REST API of package rest for one plugin.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	"github.com/libstorage/libstoragemgmt-golang/rest"
+)
+
+func main() {
+	var listen, uri, password string
+	var timeout uint
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:8080", "TCP address to listen on")
+	flag.StringVar(&uri, "uri", "sim://", "plugin URI")
+	flag.StringVar(&password, "password", "", "plugin password")
+	flag.UintVar(&timeout, "timeout", 30000, "plugin timeout in milliseconds")
+	flag.Parse()
+
+	c, err := lsm.Client(uri, password, uint32(timeout))
+	if err != nil {
+		fmt.Printf("Unable to connect to plugin, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	if err := http.ListenAndServe(listen, rest.NewServer(c)); err != nil {
+		fmt.Printf("Failed to serve, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: 0BSD
+
+// simgo_lsmplugin serves the Go simulator to lsmd, use the URI "simgo://".
+package main
+
+import (
+	"fmt"
+	"os"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	"github.com/libstorage/libstoragemgmt-golang/simulator"
+)
+
+func main() {
+	sim := simulator.New()
+
+	plugin, err := lsm.PluginInit(sim.Callbacks(), os.Args, simulator.Description, simulator.Version)
+	if err != nil {
+		fmt.Printf("Failed to initialize plugin, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+	plugin.Run()
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: 0BSD
+
+package daemon
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+const (
+	lsmdConfName       = "lsmd.conf"
+	pluginConfDirName  = "pluginconf.d"
+	allowRootOption    = "allow-plugin-root-privilege"
+	requireRootOption  = "require-root-privilege"
+	pluginSuffix       = "_lsmplugin"
+	defaultPluginDir   = "/usr/bin"
+	defaultConfDir     = "/etc/lsm"
+	defaultUnprivilege = "libstoragemgmt"
+)
+
+// parseConf reads the small subset of libconfig used by the lsmd
+// configuration files, lines of the form 'key = value;' with '#' comments.
+func parseConf(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexAny(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			continue
+		}
+
+		kv := strings.SplitN(strings.TrimSuffix(text, ";"), "=", 2)
+		if len(kv) != 2 {
+			return nil, &errors.LsmError{
+				Code:    errors.InvalidArgument,
+				Message: fmt.Sprintf("%s:%d: expected 'key = value;'", path, line)}
+		}
+		values[strings.TrimSpace(kv[0])] = strings.Trim(strings.TrimSpace(kv[1]), "\"")
+	}
+	return values, scanner.Err()
+}
+
+// confBool returns the boolean value of key in the configuration file at
+// path, or def if the file or key doesn't exist.
+func confBool(path string, key string, def bool) (bool, error) {
+	values, err := parseConf(path)
+	if os.IsNotExist(err) {
+		return def, nil
+	}
+	if err != nil {
+		return def, err
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return def, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return def, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("%s: invalid value %q for %s", path, value, key)}
+	}
+	return b, nil
+}
+
+// pluginInfo is one plugin executable found in the plugin folder
+type pluginInfo struct {
+	name        string
+	path        string
+	requireRoot bool
+}
+
+// findPlugins returns the plugin executables in pluginDir, they are named
+// <name>_lsmplugin and served on a socket called <name>.
+func findPlugins(pluginDir string, confDir string) ([]pluginInfo, error) {
+	matches, err := filepath.Glob(filepath.Join(pluginDir, "*"+pluginSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []pluginInfo
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+			continue
+		}
+
+		name := strings.TrimSuffix(filepath.Base(path), pluginSuffix)
+		requireRoot, err := confBool(
+			filepath.Join(confDir, pluginConfDirName, name+".conf"), requireRootOption, false)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, pluginInfo{name: name, path: path, requireRoot: requireRoot})
+	}
+	return plugins, nil
+}
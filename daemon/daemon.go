@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package daemon implements the role of lsmd, it listens on a unix domain
+// socket per plugin and starts a new plugin process for every connection.
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Config controls where the daemon finds plugins and creates sockets, the zero
+// value uses the same locations as lsmd.
+type Config struct {
+	// PluginDir is searched for <name>_lsmplugin executables, default /usr/bin
+	PluginDir string
+
+	// SocketDir is where the plugin sockets are created, default lsm.IpcPath()
+	SocketDir string
+
+	// ConfDir holds lsmd.conf and pluginconf.d, default /etc/lsm
+	ConfDir string
+
+	// User plugins run as when the daemon runs as root and the plugin doesn't
+	// need root, default libstoragemgmt
+	User string
+
+	// Log receives diagnostics, default discards them
+	Log *log.Logger
+}
+
+// Daemon serves the plugins found when it was created.
+type Daemon struct {
+	cfg       Config
+	allowRoot bool
+	plugins   []pluginInfo
+
+	lock      sync.Mutex
+	listeners []net.Listener
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// New reads the configuration and finds the plugins to serve.
+func New(cfg Config) (*Daemon, error) {
+	if len(cfg.PluginDir) == 0 {
+		cfg.PluginDir = defaultPluginDir
+	}
+	if len(cfg.SocketDir) == 0 {
+		cfg.SocketDir = lsm.IpcPath()
+	}
+	if len(cfg.ConfDir) == 0 {
+		cfg.ConfDir = defaultConfDir
+	}
+	if len(cfg.User) == 0 {
+		cfg.User = defaultUnprivilege
+	}
+	if cfg.Log == nil {
+		cfg.Log = log.New(ioutil.Discard, "", 0)
+	}
+
+	allowRoot, err := confBool(filepath.Join(cfg.ConfDir, lsmdConfName), allowRootOption, false)
+	if err != nil {
+		return nil, err
+	}
+
+	plugins, err := findPlugins(cfg.PluginDir, cfg.ConfDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(plugins) == 0 {
+		return nil, &errors.LsmError{
+			Code:    errors.PluginNotExist,
+			Message: fmt.Sprintf("no plugins found in %s", cfg.PluginDir)}
+	}
+
+	return &Daemon{cfg: cfg, allowRoot: allowRoot, plugins: plugins}, nil
+}
+
+// Plugins returns the names of the plugins being served, which are also the
+// URI schemes used to reach them.
+func (d *Daemon) Plugins() []string {
+	var names []string
+	for _, p := range d.plugins {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+// Run creates the plugin sockets and serves them until Close is called.
+func (d *Daemon) Run() error {
+	if err := os.MkdirAll(d.cfg.SocketDir, 0755); err != nil {
+		return err
+	}
+
+	d.lock.Lock()
+	if d.closed {
+		d.lock.Unlock()
+		return nil
+	}
+	for _, p := range d.plugins {
+		if p.requireRoot && !d.allowRoot {
+			d.cfg.Log.Printf("plugin %s requires root, which %s doesn't allow, skipping",
+				p.name, lsmdConfName)
+			continue
+		}
+
+		path := filepath.Join(d.cfg.SocketDir, p.name)
+		// Left over from a previous run
+		os.Remove(path)
+
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			d.lock.Unlock()
+			d.Close()
+			return err
+		}
+		// Clients run as any user
+		os.Chmod(path, 0666)
+
+		d.listeners = append(d.listeners, l)
+		d.wg.Add(1)
+		go d.serve(p, l)
+	}
+	d.lock.Unlock()
+
+	d.wg.Wait()
+	return nil
+}
+
+// Close stops accepting connections and removes the sockets.  Plugin
+// processes already started carry on until their client disconnects.
+func (d *Daemon) Close() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.closed = true
+	for _, l := range d.listeners {
+		l.Close()
+	}
+	d.listeners = nil
+	return nil
+}
+
+func (d *Daemon) serve(p pluginInfo, l net.Listener) {
+	defer d.wg.Done()
+	d.cfg.Log.Printf("serving plugin %s from %s", p.name, p.path)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		if err := d.start(p, conn.(*net.UnixConn)); err != nil {
+			d.cfg.Log.Printf("unable to start plugin %s: %s", p.name, err)
+		}
+	}
+}
+
+// start runs a plugin process to serve the client on conn.
+func (d *Daemon) start(p pluginInfo, conn *net.UnixConn) error {
+	// The child gets its own copy of the descriptor, ours isn't needed after
+	f, err := conn.File()
+	conn.Close()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// The first of ExtraFiles is always fd 3 in the child.  C plugins take the
+	// descriptor as the only argument and Go ones look at LSM_GO_FD first.
+	const pluginFd = "3"
+	proc := exec.Command(p.path, pluginFd)
+	proc.Env = append(os.Environ(), "LSM_GO_FD="+pluginFd)
+	proc.ExtraFiles = []*os.File{f}
+	proc.Stdout = os.Stdout
+	proc.Stderr = os.Stderr
+
+	if os.Geteuid() == 0 && !p.requireRoot {
+		cred, err := d.credential()
+		if err != nil {
+			return err
+		}
+		proc.SysProcAttr = &syscall.SysProcAttr{Credential: cred}
+	}
+
+	if err := proc.Start(); err != nil {
+		return err
+	}
+
+	// Reap the plugin when it exits
+	go proc.Wait()
+	return nil
+}
+
+// credential returns the unprivileged user plugins run as.
+func (d *Daemon) credential() (*syscall.Credential, error) {
+	u, err := user.Lookup(d.cfg.User)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}, nil
+}
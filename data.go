@@ -603,9 +603,13 @@ type Capabilities struct {
 	Cap   string `json:"cap"`
 }
 
-// IsSupported used to determine if a capability is supported
+// IsSupported used to determine if a capability is supported, capabilities
+// beyond the end of the bitmap are not.
 func (c *Capabilities) IsSupported(cap CapabilityType) bool {
-	var capIdx = int32(cap) * 2
+	var capIdx = int(cap) * 2
+	if capIdx+2 > len(c.Cap) {
+		return false
+	}
 	if c.Cap[capIdx:capIdx+2] == "01" {
 		return true
 	}
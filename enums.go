@@ -0,0 +1,1060 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// The enumerated types print by name and are encoded by name as text, eg.
+// in YAML, for bitfields the names of the bits set are joined by "|", eg.
+// "OK|Degraded".  Names are matched ignoring case and numbers are accepted
+// in their place.  JSON stays numeric as it is what the plugins speak, names
+// are accepted in strings when decoding.
+
+// enum holds the names of the values of an enumerated type, for a bitfield
+// those of its bits.
+type enum struct {
+	typeName string
+	bitfield bool
+	names    []enumName
+}
+
+type enumName struct {
+	value int64
+	name  string
+}
+
+// format returns the name of v, values without one are given as eg.
+// "DiskType(99)", or as a plain number for text.
+func (e *enum) format(v int64, text bool) string {
+	if !e.bitfield {
+		for _, n := range e.names {
+			if n.value == v {
+				return n.name
+			}
+		}
+		if text {
+			return strconv.FormatInt(v, 10)
+		}
+		return fmt.Sprintf("%s(%d)", e.typeName, v)
+	}
+
+	var parts []string
+	rest := uint64(v)
+	for _, n := range e.names {
+		if bit := uint64(n.value); rest&bit != 0 {
+			parts = append(parts, n.name)
+			rest &^= bit
+		}
+	}
+	if rest != 0 || len(parts) == 0 {
+		if text {
+			parts = append(parts, fmt.Sprintf("%#x", rest))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s(%#x)", e.typeName, rest))
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+// parse returns the value named by s, current if it is invalid.
+func (e *enum) parse(s string, current int64) (int64, error) {
+	parts := []string{s}
+	if e.bitfield {
+		parts = strings.Split(s, "|")
+	}
+
+	var v int64
+	for _, part := range parts {
+		n, err := e.value(strings.TrimSpace(part))
+		if err != nil {
+			return current, err
+		}
+		v |= n
+	}
+	return v, nil
+}
+
+func (e *enum) value(name string) (int64, error) {
+	for _, n := range e.names {
+		if strings.EqualFold(n.name, name) {
+			return n.value, nil
+		}
+	}
+	if v, err := strconv.ParseInt(name, 0, 64); err == nil {
+		return v, nil
+	}
+	return 0, &errors.LsmError{
+		Code:    errors.InvalidArgument,
+		Message: fmt.Sprintf("invalid %s %q", e.typeName, name)}
+}
+
+// unmarshalJSON returns the value of the JSON number or string b, current
+// for null.
+func (e *enum) unmarshalJSON(b []byte, current int64) (int64, error) {
+	if string(b) == "null" {
+		return current, nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var name string
+		if err := json.Unmarshal(b, &name); err != nil {
+			return current, err
+		}
+		return e.parse(name, current)
+	}
+	var v int64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return current, err
+	}
+	return v, nil
+}
+
+var systemStatusNames = &enum{typeName: "SystemStatusType", bitfield: true, names: []enumName{
+	{int64(SystemStatusUnknown), "Unknown"},
+	{int64(SystemStatusOk), "OK"},
+	{int64(SystemStatusError), "Error"},
+	{int64(SystemStatusDegraded), "Degraded"},
+	{int64(SystemStatusPredictiveFailure), "PredictiveFailure"},
+	{int64(SystemStatusOther), "Other"},
+}}
+
+// String returns the names of the bits set in s joined by "|".
+func (s SystemStatusType) String() string {
+	return systemStatusNames.format(int64(s), false)
+}
+
+// MarshalText encodes s by name.
+func (s SystemStatusType) MarshalText() ([]byte, error) {
+	return []byte(systemStatusNames.format(int64(s), true)), nil
+}
+
+// UnmarshalText decodes s from a name or a number.
+func (s *SystemStatusType) UnmarshalText(text []byte) error {
+	value, err := systemStatusNames.parse(string(text), int64(*s))
+	*s = SystemStatusType(value)
+	return err
+}
+
+// MarshalJSON encodes s as a number, as the plugins expect.
+func (s SystemStatusType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(s), 10)), nil
+}
+
+// UnmarshalJSON decodes s from a number, or a name in a string.
+func (s *SystemStatusType) UnmarshalJSON(data []byte) error {
+	value, err := systemStatusNames.unmarshalJSON(data, int64(*s))
+	*s = SystemStatusType(value)
+	return err
+}
+
+var systemModeNames = &enum{typeName: "SystemModeType", names: []enumName{
+	{int64(SystemModeUnknown), "Unknown"},
+	{int64(SystemModeNoSupport), "NoSupport"},
+	{int64(SystemModeHardwareRaid), "HardwareRaid"},
+	{int64(SystemModeHba), "Hba"},
+}}
+
+// String returns the name of s.
+func (s SystemModeType) String() string {
+	return systemModeNames.format(int64(s), false)
+}
+
+// MarshalText encodes s by name.
+func (s SystemModeType) MarshalText() ([]byte, error) {
+	return []byte(systemModeNames.format(int64(s), true)), nil
+}
+
+// UnmarshalText decodes s from a name or a number.
+func (s *SystemModeType) UnmarshalText(text []byte) error {
+	value, err := systemModeNames.parse(string(text), int64(*s))
+	*s = SystemModeType(value)
+	return err
+}
+
+// MarshalJSON encodes s as a number, as the plugins expect.
+func (s SystemModeType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(s), 10)), nil
+}
+
+// UnmarshalJSON decodes s from a number, or a name in a string.
+func (s *SystemModeType) UnmarshalJSON(data []byte) error {
+	value, err := systemModeNames.unmarshalJSON(data, int64(*s))
+	*s = SystemModeType(value)
+	return err
+}
+
+var jobStatusNames = &enum{typeName: "JobStatusType", names: []enumName{
+	{int64(JobStatusInprogress), "Inprogress"},
+	{int64(JobStatusComplete), "Complete"},
+	{int64(JobStatusError), "Error"},
+}}
+
+// String returns the name of j.
+func (j JobStatusType) String() string {
+	return jobStatusNames.format(int64(j), false)
+}
+
+// MarshalText encodes j by name.
+func (j JobStatusType) MarshalText() ([]byte, error) {
+	return []byte(jobStatusNames.format(int64(j), true)), nil
+}
+
+// UnmarshalText decodes j from a name or a number.
+func (j *JobStatusType) UnmarshalText(text []byte) error {
+	value, err := jobStatusNames.parse(string(text), int64(*j))
+	*j = JobStatusType(value)
+	return err
+}
+
+// MarshalJSON encodes j as a number, as the plugins expect.
+func (j JobStatusType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(j), 10)), nil
+}
+
+// UnmarshalJSON decodes j from a number, or a name in a string.
+func (j *JobStatusType) UnmarshalJSON(data []byte) error {
+	value, err := jobStatusNames.unmarshalJSON(data, int64(*j))
+	*j = JobStatusType(value)
+	return err
+}
+
+var volumeReplicateNames = &enum{typeName: "VolumeReplicateType", names: []enumName{
+	{int64(VolumeReplicateTypeUnknown), "Unknown"},
+	{int64(VolumeReplicateTypeClone), "Clone"},
+	{int64(VolumeReplicateTypeCopy), "Copy"},
+	{int64(VolumeReplicateTypeMirrorSync), "MirrorSync"},
+	{int64(VolumeReplicateTypeMirrorAsync), "MirrorAsync"},
+}}
+
+// String returns the name of v.
+func (v VolumeReplicateType) String() string {
+	return volumeReplicateNames.format(int64(v), false)
+}
+
+// MarshalText encodes v by name.
+func (v VolumeReplicateType) MarshalText() ([]byte, error) {
+	return []byte(volumeReplicateNames.format(int64(v), true)), nil
+}
+
+// UnmarshalText decodes v from a name or a number.
+func (v *VolumeReplicateType) UnmarshalText(text []byte) error {
+	value, err := volumeReplicateNames.parse(string(text), int64(*v))
+	*v = VolumeReplicateType(value)
+	return err
+}
+
+// MarshalJSON encodes v as a number, as the plugins expect.
+func (v VolumeReplicateType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON decodes v from a number, or a name in a string.
+func (v *VolumeReplicateType) UnmarshalJSON(data []byte) error {
+	value, err := volumeReplicateNames.unmarshalJSON(data, int64(*v))
+	*v = VolumeReplicateType(value)
+	return err
+}
+
+var volumeProvisionNames = &enum{typeName: "VolumeProvisionType", names: []enumName{
+	{int64(VolumeProvisionTypeUnknown), "Unknown"},
+	{int64(VolumeProvisionTypeThin), "Thin"},
+	{int64(VolumeProvisionTypeFull), "Full"},
+	{int64(VolumeProvisionTypeDefault), "Default"},
+}}
+
+// String returns the name of v.
+func (v VolumeProvisionType) String() string {
+	return volumeProvisionNames.format(int64(v), false)
+}
+
+// MarshalText encodes v by name.
+func (v VolumeProvisionType) MarshalText() ([]byte, error) {
+	return []byte(volumeProvisionNames.format(int64(v), true)), nil
+}
+
+// UnmarshalText decodes v from a name or a number.
+func (v *VolumeProvisionType) UnmarshalText(text []byte) error {
+	value, err := volumeProvisionNames.parse(string(text), int64(*v))
+	*v = VolumeProvisionType(value)
+	return err
+}
+
+// MarshalJSON encodes v as a number, as the plugins expect.
+func (v VolumeProvisionType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON decodes v from a number, or a name in a string.
+func (v *VolumeProvisionType) UnmarshalJSON(data []byte) error {
+	value, err := volumeProvisionNames.unmarshalJSON(data, int64(*v))
+	*v = VolumeProvisionType(value)
+	return err
+}
+
+var poolElementNames = &enum{typeName: "PoolElementType", bitfield: true, names: []enumName{
+	{int64(PoolElementPool), "Pool"},
+	{int64(PoolElementTypeVolume), "Volume"},
+	{int64(PoolElementTypeFs), "Fs"},
+	{int64(PoolElementTypeDelta), "Delta"},
+	{int64(PoolElementTypeVolumeFull), "VolumeFull"},
+	{int64(PoolElementTypeVolumeThin), "VolumeThin"},
+	{int64(PoolElementTypeSysReserved), "SysReserved"},
+}}
+
+// String returns the names of the bits set in p joined by "|".
+func (p PoolElementType) String() string {
+	return poolElementNames.format(int64(p), false)
+}
+
+// MarshalText encodes p by name.
+func (p PoolElementType) MarshalText() ([]byte, error) {
+	return []byte(poolElementNames.format(int64(p), true)), nil
+}
+
+// UnmarshalText decodes p from a name or a number.
+func (p *PoolElementType) UnmarshalText(text []byte) error {
+	value, err := poolElementNames.parse(string(text), int64(*p))
+	*p = PoolElementType(value)
+	return err
+}
+
+// MarshalJSON encodes p as a number, as the plugins expect.
+func (p PoolElementType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(p), 10)), nil
+}
+
+// UnmarshalJSON decodes p from a number, or a name in a string.
+func (p *PoolElementType) UnmarshalJSON(data []byte) error {
+	value, err := poolElementNames.unmarshalJSON(data, int64(*p))
+	*p = PoolElementType(value)
+	return err
+}
+
+var poolUnsupportedNames = &enum{typeName: "PoolUnsupportedType", bitfield: true, names: []enumName{
+	{int64(PoolUnsupportedVolumeGrow), "VolumeGrow"},
+	{int64(PoolUnsupportedVolumeShink), "VolumeShink"},
+}}
+
+// String returns the names of the bits set in p joined by "|".
+func (p PoolUnsupportedType) String() string {
+	return poolUnsupportedNames.format(int64(p), false)
+}
+
+// MarshalText encodes p by name.
+func (p PoolUnsupportedType) MarshalText() ([]byte, error) {
+	return []byte(poolUnsupportedNames.format(int64(p), true)), nil
+}
+
+// UnmarshalText decodes p from a name or a number.
+func (p *PoolUnsupportedType) UnmarshalText(text []byte) error {
+	value, err := poolUnsupportedNames.parse(string(text), int64(*p))
+	*p = PoolUnsupportedType(value)
+	return err
+}
+
+// MarshalJSON encodes p as a number, as the plugins expect.
+func (p PoolUnsupportedType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(p), 10)), nil
+}
+
+// UnmarshalJSON decodes p from a number, or a name in a string.
+func (p *PoolUnsupportedType) UnmarshalJSON(data []byte) error {
+	value, err := poolUnsupportedNames.unmarshalJSON(data, int64(*p))
+	*p = PoolUnsupportedType(value)
+	return err
+}
+
+var poolStatusNames = &enum{typeName: "PoolStatusType", bitfield: true, names: []enumName{
+	{int64(PoolStatusUnknown), "Unknown"},
+	{int64(PoolStatusOk), "OK"},
+	{int64(PoolStatusOther), "Other"},
+	{int64(PoolStatusDegraded), "Degraded"},
+	{int64(PoolStatusError), "Error"},
+	{int64(PoolStatusStopped), "Stopped"},
+	{int64(PoolStatusStarting), "Starting"},
+	{int64(PoolStatusReconstructing), "Reconstructing"},
+	{int64(PoolStatusVerifying), "Verifying"},
+	{int64(PoolStatusInitializing), "Initializing"},
+	{int64(PoolStatusGrowing), "Growing"},
+}}
+
+// String returns the names of the bits set in p joined by "|".
+func (p PoolStatusType) String() string {
+	return poolStatusNames.format(int64(p), false)
+}
+
+// MarshalText encodes p by name.
+func (p PoolStatusType) MarshalText() ([]byte, error) {
+	return []byte(poolStatusNames.format(int64(p), true)), nil
+}
+
+// UnmarshalText decodes p from a name or a number.
+func (p *PoolStatusType) UnmarshalText(text []byte) error {
+	value, err := poolStatusNames.parse(string(text), int64(*p))
+	*p = PoolStatusType(value)
+	return err
+}
+
+// MarshalJSON encodes p as a number, as the plugins expect.
+func (p PoolStatusType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(p), 10)), nil
+}
+
+// UnmarshalJSON decodes p from a number, or a name in a string.
+func (p *PoolStatusType) UnmarshalJSON(data []byte) error {
+	value, err := poolStatusNames.unmarshalJSON(data, int64(*p))
+	*p = PoolStatusType(value)
+	return err
+}
+
+var diskNames = &enum{typeName: "DiskType", names: []enumName{
+	{int64(DiskTypeUnknown), "Unknown"},
+	{int64(DiskTypeOther), "Other"},
+	{int64(DiskTypeAta), "Ata"},
+	{int64(DiskTypeSata), "Sata"},
+	{int64(DiskTypeSas), "Sas"},
+	{int64(DiskTypeFc), "Fc"},
+	{int64(DiskTypeSop), "Sop"},
+	{int64(DiskTypeScsi), "Scsi"},
+	{int64(DiskTypeLun), "Lun"},
+	{int64(DiskTypeNlSas), "NlSas"},
+	{int64(DiskTypeHdd), "Hdd"},
+	{int64(DiskTypeSsd), "Ssd"},
+	{int64(DiskTypeHybrid), "Hybrid"},
+}}
+
+// String returns the name of d.
+func (d DiskType) String() string {
+	return diskNames.format(int64(d), false)
+}
+
+// MarshalText encodes d by name.
+func (d DiskType) MarshalText() ([]byte, error) {
+	return []byte(diskNames.format(int64(d), true)), nil
+}
+
+// UnmarshalText decodes d from a name or a number.
+func (d *DiskType) UnmarshalText(text []byte) error {
+	value, err := diskNames.parse(string(text), int64(*d))
+	*d = DiskType(value)
+	return err
+}
+
+// MarshalJSON encodes d as a number, as the plugins expect.
+func (d DiskType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(d), 10)), nil
+}
+
+// UnmarshalJSON decodes d from a number, or a name in a string.
+func (d *DiskType) UnmarshalJSON(data []byte) error {
+	value, err := diskNames.unmarshalJSON(data, int64(*d))
+	*d = DiskType(value)
+	return err
+}
+
+var diskLinkNames = &enum{typeName: "DiskLinkType", names: []enumName{
+	{int64(DiskLinkTypeNoSupport), "NoSupport"},
+	{int64(DiskLinkTypeUnknown), "Unknown"},
+	{int64(DiskLinkTypeFc), "Fc"},
+	{int64(DiskLinkTypeSsa), "Ssa"},
+	{int64(DiskLinkTypeSbp), "Sbp"},
+	{int64(DiskLinkTypeSrp), "Srp"},
+	{int64(DiskLinkTypeIscsi), "Iscsi"},
+	{int64(DiskLinkTypeSas), "Sas"},
+	{int64(DiskLinkTypeAdt), "Adt"},
+	{int64(DiskLinkTypeAta), "Ata"},
+	{int64(DiskLinkTypeUsb), "Usb"},
+	{int64(DiskLinkTypeSop), "Sop"},
+	{int64(DiskLinkTypePciE), "PciE"},
+}}
+
+// String returns the name of d.
+func (d DiskLinkType) String() string {
+	return diskLinkNames.format(int64(d), false)
+}
+
+// MarshalText encodes d by name.
+func (d DiskLinkType) MarshalText() ([]byte, error) {
+	return []byte(diskLinkNames.format(int64(d), true)), nil
+}
+
+// UnmarshalText decodes d from a name or a number.
+func (d *DiskLinkType) UnmarshalText(text []byte) error {
+	value, err := diskLinkNames.parse(string(text), int64(*d))
+	*d = DiskLinkType(value)
+	return err
+}
+
+// MarshalJSON encodes d as a number, as the plugins expect.
+func (d DiskLinkType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(d), 10)), nil
+}
+
+// UnmarshalJSON decodes d from a number, or a name in a string.
+func (d *DiskLinkType) UnmarshalJSON(data []byte) error {
+	value, err := diskLinkNames.unmarshalJSON(data, int64(*d))
+	*d = DiskLinkType(value)
+	return err
+}
+
+var diskStatusNames = &enum{typeName: "DiskStatusType", bitfield: true, names: []enumName{
+	{int64(DiskStatusUnknown), "Unknown"},
+	{int64(DiskStatusOk), "OK"},
+	{int64(DiskStatusOther), "Other"},
+	{int64(DiskStatusPredictiveFailure), "PredictiveFailure"},
+	{int64(DiskStatusError), "Error"},
+	{int64(DiskStatusRemoved), "Removed"},
+	{int64(DiskStatusStarting), "Starting"},
+	{int64(DiskStatusStopping), "Stopping"},
+	{int64(DiskStatusStopped), "Stopped"},
+	{int64(DiskStatusInitializing), "Initializing"},
+	{int64(DiskStatusMaintenanceMode), "MaintenanceMode"},
+	{int64(DiskStatusSpareDisk), "SpareDisk"},
+	{int64(DiskStatusReconstruct), "Reconstruct"},
+	{int64(DiskStatusFree), "Free"},
+}}
+
+// String returns the names of the bits set in d joined by "|".
+func (d DiskStatusType) String() string {
+	return diskStatusNames.format(int64(d), false)
+}
+
+// MarshalText encodes d by name.
+func (d DiskStatusType) MarshalText() ([]byte, error) {
+	return []byte(diskStatusNames.format(int64(d), true)), nil
+}
+
+// UnmarshalText decodes d from a name or a number.
+func (d *DiskStatusType) UnmarshalText(text []byte) error {
+	value, err := diskStatusNames.parse(string(text), int64(*d))
+	*d = DiskStatusType(value)
+	return err
+}
+
+// MarshalJSON encodes d as a number, as the plugins expect.
+func (d DiskStatusType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(d), 10)), nil
+}
+
+// UnmarshalJSON decodes d from a number, or a name in a string.
+func (d *DiskStatusType) UnmarshalJSON(data []byte) error {
+	value, err := diskStatusNames.unmarshalJSON(data, int64(*d))
+	*d = DiskStatusType(value)
+	return err
+}
+
+var initiatorNames = &enum{typeName: "InitiatorType", names: []enumName{
+	{int64(InitiatorTypeUnknown), "Unknown"},
+	{int64(InitiatorTypeOther), "Other"},
+	{int64(InitiatorTypeWwpn), "Wwpn"},
+	{int64(InitiatorTypeIscsiIqn), "IscsiIqn"},
+	{int64(InitiatorTypeMixed), "Mixed"},
+}}
+
+// String returns the name of i.
+func (i InitiatorType) String() string {
+	return initiatorNames.format(int64(i), false)
+}
+
+// MarshalText encodes i by name.
+func (i InitiatorType) MarshalText() ([]byte, error) {
+	return []byte(initiatorNames.format(int64(i), true)), nil
+}
+
+// UnmarshalText decodes i from a name or a number.
+func (i *InitiatorType) UnmarshalText(text []byte) error {
+	value, err := initiatorNames.parse(string(text), int64(*i))
+	*i = InitiatorType(value)
+	return err
+}
+
+// MarshalJSON encodes i as a number, as the plugins expect.
+func (i InitiatorType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(i), 10)), nil
+}
+
+// UnmarshalJSON decodes i from a number, or a name in a string.
+func (i *InitiatorType) UnmarshalJSON(data []byte) error {
+	value, err := initiatorNames.unmarshalJSON(data, int64(*i))
+	*i = InitiatorType(value)
+	return err
+}
+
+var portNames = &enum{typeName: "PortType", names: []enumName{
+	{int64(PortTypeOther), "Other"},
+	{int64(PortTypeFc), "Fc"},
+	{int64(PortTypeFCoE), "FCoE"},
+	{int64(PortTypeIscsi), "Iscsi"},
+}}
+
+// String returns the name of p.
+func (p PortType) String() string {
+	return portNames.format(int64(p), false)
+}
+
+// MarshalText encodes p by name.
+func (p PortType) MarshalText() ([]byte, error) {
+	return []byte(portNames.format(int64(p), true)), nil
+}
+
+// UnmarshalText decodes p from a name or a number.
+func (p *PortType) UnmarshalText(text []byte) error {
+	value, err := portNames.parse(string(text), int64(*p))
+	*p = PortType(value)
+	return err
+}
+
+// MarshalJSON encodes p as a number, as the plugins expect.
+func (p PortType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(p), 10)), nil
+}
+
+// UnmarshalJSON decodes p from a number, or a name in a string.
+func (p *PortType) UnmarshalJSON(data []byte) error {
+	value, err := portNames.unmarshalJSON(data, int64(*p))
+	*p = PortType(value)
+	return err
+}
+
+var batteryNames = &enum{typeName: "BatteryType", names: []enumName{
+	{int64(BatteryTypeUnknown), "Unknown"},
+	{int64(BatteryTypeOther), "Other"},
+	{int64(BatteryTypeChemical), "Chemical"},
+	{int64(BatteryTypeCapacitor), "Capacitor"},
+}}
+
+// String returns the name of b.
+func (b BatteryType) String() string {
+	return batteryNames.format(int64(b), false)
+}
+
+// MarshalText encodes b by name.
+func (b BatteryType) MarshalText() ([]byte, error) {
+	return []byte(batteryNames.format(int64(b), true)), nil
+}
+
+// UnmarshalText decodes b from a name or a number.
+func (b *BatteryType) UnmarshalText(text []byte) error {
+	value, err := batteryNames.parse(string(text), int64(*b))
+	*b = BatteryType(value)
+	return err
+}
+
+// MarshalJSON encodes b as a number, as the plugins expect.
+func (b BatteryType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(b), 10)), nil
+}
+
+// UnmarshalJSON decodes b from a number, or a name in a string.
+func (b *BatteryType) UnmarshalJSON(data []byte) error {
+	value, err := batteryNames.unmarshalJSON(data, int64(*b))
+	*b = BatteryType(value)
+	return err
+}
+
+var batteryStatusNames = &enum{typeName: "BatteryStatus", bitfield: true, names: []enumName{
+	{int64(BatteryStatusUnknown), "Unknown"},
+	{int64(BatteryStatusOther), "Other"},
+	{int64(BatteryStatusOk), "OK"},
+	{int64(BatteryStatusDischarging), "Discharging"},
+	{int64(BatteryStatusCharging), "Charging"},
+	{int64(BatteryStatusLearning), "Learning"},
+	{int64(BatteryStatusDegraded), "Degraded"},
+	{int64(BatteryStatusError), "Error"},
+}}
+
+// String returns the names of the bits set in b joined by "|".
+func (b BatteryStatus) String() string {
+	return batteryStatusNames.format(int64(b), false)
+}
+
+// MarshalText encodes b by name.
+func (b BatteryStatus) MarshalText() ([]byte, error) {
+	return []byte(batteryStatusNames.format(int64(b), true)), nil
+}
+
+// UnmarshalText decodes b from a name or a number.
+func (b *BatteryStatus) UnmarshalText(text []byte) error {
+	value, err := batteryStatusNames.parse(string(text), int64(*b))
+	*b = BatteryStatus(value)
+	return err
+}
+
+// MarshalJSON encodes b as a number, as the plugins expect.
+func (b BatteryStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(b), 10)), nil
+}
+
+// UnmarshalJSON decodes b from a number, or a name in a string.
+func (b *BatteryStatus) UnmarshalJSON(data []byte) error {
+	value, err := batteryStatusNames.unmarshalJSON(data, int64(*b))
+	*b = BatteryStatus(value)
+	return err
+}
+
+var raidNames = &enum{typeName: "RaidType", names: []enumName{
+	{int64(RaidUnknown), "Unknown"},
+	{int64(Raid0), "RAID0"},
+	{int64(Raid1), "RAID1"},
+	{int64(Raid3), "RAID3"},
+	{int64(Raid4), "RAID4"},
+	{int64(Raid5), "RAID5"},
+	{int64(Raid6), "RAID6"},
+	{int64(Raid10), "RAID10"},
+	{int64(Raid15), "RAID15"},
+	{int64(Raid16), "RAID16"},
+	{int64(Raid50), "RAID50"},
+	{int64(Raid60), "RAID60"},
+	{int64(Raid51), "RAID51"},
+	{int64(Raid61), "RAID61"},
+	{int64(RaidJbod), "JBOD"},
+	{int64(RaidMixed), "Mixed"},
+	{int64(RaidOther), "Other"},
+}}
+
+// String returns the name of r.
+func (r RaidType) String() string {
+	return raidNames.format(int64(r), false)
+}
+
+// MarshalText encodes r by name.
+func (r RaidType) MarshalText() ([]byte, error) {
+	return []byte(raidNames.format(int64(r), true)), nil
+}
+
+// UnmarshalText decodes r from a name or a number.
+func (r *RaidType) UnmarshalText(text []byte) error {
+	value, err := raidNames.parse(string(text), int64(*r))
+	*r = RaidType(value)
+	return err
+}
+
+// MarshalJSON encodes r as a number, as the plugins expect.
+func (r RaidType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(r), 10)), nil
+}
+
+// UnmarshalJSON decodes r from a number, or a name in a string.
+func (r *RaidType) UnmarshalJSON(data []byte) error {
+	value, err := raidNames.unmarshalJSON(data, int64(*r))
+	*r = RaidType(value)
+	return err
+}
+
+var memberNames = &enum{typeName: "MemberType", names: []enumName{
+	{int64(MemberTypeUnknown), "Unknown"},
+	{int64(MemberTypeOther), "Other"},
+	{int64(MemberTypeDisk), "Disk"},
+	{int64(MemberTypePool), "Pool"},
+}}
+
+// String returns the name of m.
+func (m MemberType) String() string {
+	return memberNames.format(int64(m), false)
+}
+
+// MarshalText encodes m by name.
+func (m MemberType) MarshalText() ([]byte, error) {
+	return []byte(memberNames.format(int64(m), true)), nil
+}
+
+// UnmarshalText decodes m from a name or a number.
+func (m *MemberType) UnmarshalText(text []byte) error {
+	value, err := memberNames.parse(string(text), int64(*m))
+	*m = MemberType(value)
+	return err
+}
+
+// MarshalJSON encodes m as a number, as the plugins expect.
+func (m MemberType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(m), 10)), nil
+}
+
+// UnmarshalJSON decodes m from a number, or a name in a string.
+func (m *MemberType) UnmarshalJSON(data []byte) error {
+	value, err := memberNames.unmarshalJSON(data, int64(*m))
+	*m = MemberType(value)
+	return err
+}
+
+var writeCachePolicyNames = &enum{typeName: "WriteCachePolicy", names: []enumName{
+	{int64(WriteCachePolicyUnknown), "Unknown"},
+	{int64(WriteCachePolicyWriteBack), "WriteBack"},
+	{int64(WriteCachePolicyAuto), "Auto"},
+	{int64(WriteCachePolicyWriteThrough), "WriteThrough"},
+}}
+
+// String returns the name of w.
+func (w WriteCachePolicy) String() string {
+	return writeCachePolicyNames.format(int64(w), false)
+}
+
+// MarshalText encodes w by name.
+func (w WriteCachePolicy) MarshalText() ([]byte, error) {
+	return []byte(writeCachePolicyNames.format(int64(w), true)), nil
+}
+
+// UnmarshalText decodes w from a name or a number.
+func (w *WriteCachePolicy) UnmarshalText(text []byte) error {
+	value, err := writeCachePolicyNames.parse(string(text), int64(*w))
+	*w = WriteCachePolicy(value)
+	return err
+}
+
+// MarshalJSON encodes w as a number, as the plugins expect.
+func (w WriteCachePolicy) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(w), 10)), nil
+}
+
+// UnmarshalJSON decodes w from a number, or a name in a string.
+func (w *WriteCachePolicy) UnmarshalJSON(data []byte) error {
+	value, err := writeCachePolicyNames.unmarshalJSON(data, int64(*w))
+	*w = WriteCachePolicy(value)
+	return err
+}
+
+var writeCacheStatusNames = &enum{typeName: "WriteCacheStatus", names: []enumName{
+	{int64(WriteCacheStatusUnknown), "Unknown"},
+	{int64(WriteCacheStatusWriteBack), "WriteBack"},
+	{int64(WriteCacheStatusWriteThrough), "WriteThrough"},
+}}
+
+// String returns the name of w.
+func (w WriteCacheStatus) String() string {
+	return writeCacheStatusNames.format(int64(w), false)
+}
+
+// MarshalText encodes w by name.
+func (w WriteCacheStatus) MarshalText() ([]byte, error) {
+	return []byte(writeCacheStatusNames.format(int64(w), true)), nil
+}
+
+// UnmarshalText decodes w from a name or a number.
+func (w *WriteCacheStatus) UnmarshalText(text []byte) error {
+	value, err := writeCacheStatusNames.parse(string(text), int64(*w))
+	*w = WriteCacheStatus(value)
+	return err
+}
+
+// MarshalJSON encodes w as a number, as the plugins expect.
+func (w WriteCacheStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(w), 10)), nil
+}
+
+// UnmarshalJSON decodes w from a number, or a name in a string.
+func (w *WriteCacheStatus) UnmarshalJSON(data []byte) error {
+	value, err := writeCacheStatusNames.unmarshalJSON(data, int64(*w))
+	*w = WriteCacheStatus(value)
+	return err
+}
+
+var readCachePolicyNames = &enum{typeName: "ReadCachePolicy", names: []enumName{
+	{int64(ReadCachePolicyUnknown), "Unknown"},
+	{int64(ReadCachePolicyEnabled), "Enabled"},
+	{int64(ReadCachePolicyDisabled), "Disabled"},
+}}
+
+// String returns the name of r.
+func (r ReadCachePolicy) String() string {
+	return readCachePolicyNames.format(int64(r), false)
+}
+
+// MarshalText encodes r by name.
+func (r ReadCachePolicy) MarshalText() ([]byte, error) {
+	return []byte(readCachePolicyNames.format(int64(r), true)), nil
+}
+
+// UnmarshalText decodes r from a name or a number.
+func (r *ReadCachePolicy) UnmarshalText(text []byte) error {
+	value, err := readCachePolicyNames.parse(string(text), int64(*r))
+	*r = ReadCachePolicy(value)
+	return err
+}
+
+// MarshalJSON encodes r as a number, as the plugins expect.
+func (r ReadCachePolicy) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(r), 10)), nil
+}
+
+// UnmarshalJSON decodes r from a number, or a name in a string.
+func (r *ReadCachePolicy) UnmarshalJSON(data []byte) error {
+	value, err := readCachePolicyNames.unmarshalJSON(data, int64(*r))
+	*r = ReadCachePolicy(value)
+	return err
+}
+
+var readCacheStatusNames = &enum{typeName: "ReadCacheStatus", names: []enumName{
+	{int64(ReadCacheStatusUnknown), "Unknown"},
+	{int64(ReadCacheStatusEnabled), "Enabled"},
+	{int64(ReadCacheStatusDisabled), "Disabled"},
+}}
+
+// String returns the name of r.
+func (r ReadCacheStatus) String() string {
+	return readCacheStatusNames.format(int64(r), false)
+}
+
+// MarshalText encodes r by name.
+func (r ReadCacheStatus) MarshalText() ([]byte, error) {
+	return []byte(readCacheStatusNames.format(int64(r), true)), nil
+}
+
+// UnmarshalText decodes r from a name or a number.
+func (r *ReadCacheStatus) UnmarshalText(text []byte) error {
+	value, err := readCacheStatusNames.parse(string(text), int64(*r))
+	*r = ReadCacheStatus(value)
+	return err
+}
+
+// MarshalJSON encodes r as a number, as the plugins expect.
+func (r ReadCacheStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(r), 10)), nil
+}
+
+// UnmarshalJSON decodes r from a number, or a name in a string.
+func (r *ReadCacheStatus) UnmarshalJSON(data []byte) error {
+	value, err := readCacheStatusNames.unmarshalJSON(data, int64(*r))
+	*r = ReadCacheStatus(value)
+	return err
+}
+
+var physicalDiskCacheNames = &enum{typeName: "PhysicalDiskCache", names: []enumName{
+	{int64(PhysicalDiskCacheUnknown), "Unknown"},
+	{int64(PhysicalDiskCacheEnabled), "Enabled"},
+	{int64(PhysicalDiskCacheDisabled), "Disabled"},
+	{int64(PhysicalDiskCacheUseDiskSetting), "UseDiskSetting"},
+}}
+
+// String returns the name of p.
+func (p PhysicalDiskCache) String() string {
+	return physicalDiskCacheNames.format(int64(p), false)
+}
+
+// MarshalText encodes p by name.
+func (p PhysicalDiskCache) MarshalText() ([]byte, error) {
+	return []byte(physicalDiskCacheNames.format(int64(p), true)), nil
+}
+
+// UnmarshalText decodes p from a name or a number.
+func (p *PhysicalDiskCache) UnmarshalText(text []byte) error {
+	value, err := physicalDiskCacheNames.parse(string(text), int64(*p))
+	*p = PhysicalDiskCache(value)
+	return err
+}
+
+// MarshalJSON encodes p as a number, as the plugins expect.
+func (p PhysicalDiskCache) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(p), 10)), nil
+}
+
+// UnmarshalJSON decodes p from a number, or a name in a string.
+func (p *PhysicalDiskCache) UnmarshalJSON(data []byte) error {
+	value, err := physicalDiskCacheNames.unmarshalJSON(data, int64(*p))
+	*p = PhysicalDiskCache(value)
+	return err
+}
+
+var diskHealthStatusNames = &enum{typeName: "DiskHealthStatus", names: []enumName{
+	{int64(DiskHealthStatusUnknown), "Unknown"},
+	{int64(DiskHealthStatusFail), "Fail"},
+	{int64(DiskHealthStatusWarn), "Warn"},
+	{int64(DiskHealthStatusGood), "Good"},
+}}
+
+// String returns the name of d.
+func (d DiskHealthStatus) String() string {
+	return diskHealthStatusNames.format(int64(d), false)
+}
+
+// MarshalText encodes d by name.
+func (d DiskHealthStatus) MarshalText() ([]byte, error) {
+	return []byte(diskHealthStatusNames.format(int64(d), true)), nil
+}
+
+// UnmarshalText decodes d from a name or a number.
+func (d *DiskHealthStatus) UnmarshalText(text []byte) error {
+	value, err := diskHealthStatusNames.parse(string(text), int64(*d))
+	*d = DiskHealthStatus(value)
+	return err
+}
+
+// MarshalJSON encodes d as a number, as the plugins expect.
+func (d DiskHealthStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(d), 10)), nil
+}
+
+// UnmarshalJSON decodes d from a number, or a name in a string.
+func (d *DiskHealthStatus) UnmarshalJSON(data []byte) error {
+	value, err := diskHealthStatusNames.unmarshalJSON(data, int64(*d))
+	*d = DiskHealthStatus(value)
+	return err
+}
+
+var diskLedStatusNames = &enum{typeName: "DiskLedStatusBitField", bitfield: true, names: []enumName{
+	{int64(DiskLedStatusUnknown), "Unknown"},
+	{int64(DiskLedStatusIdentOn), "IdentOn"},
+	{int64(DiskLedStatusIdentOff), "IdentOff"},
+	{int64(DiskLedStatusIdentUnknown), "IdentUnknown"},
+	{int64(DiskLedStatusFaultOn), "FaultOn"},
+	{int64(DiskLedStatusFaultOff), "FaultOff"},
+	{int64(DiskLedStatusFaultUnknown), "FaultUnknown"},
+}}
+
+// String returns the names of the bits set in d joined by "|".
+func (d DiskLedStatusBitField) String() string {
+	return diskLedStatusNames.format(int64(d), false)
+}
+
+// MarshalText encodes d by name.
+func (d DiskLedStatusBitField) MarshalText() ([]byte, error) {
+	return []byte(diskLedStatusNames.format(int64(d), true)), nil
+}
+
+// UnmarshalText decodes d from a name or a number.
+func (d *DiskLedStatusBitField) UnmarshalText(text []byte) error {
+	value, err := diskLedStatusNames.parse(string(text), int64(*d))
+	*d = DiskLedStatusBitField(value)
+	return err
+}
+
+// MarshalJSON encodes d as a number, as the plugins expect.
+func (d DiskLedStatusBitField) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(d), 10)), nil
+}
+
+// UnmarshalJSON decodes d from a number, or a name in a string.
+func (d *DiskLedStatusBitField) UnmarshalJSON(data []byte) error {
+	value, err := diskLedStatusNames.unmarshalJSON(data, int64(*d))
+	*d = DiskLedStatusBitField(value)
+	return err
+}
+
+// MarshalText encodes c by name.
+func (c CapabilityType) MarshalText() ([]byte, error) {
+	return []byte(capabilityNames.format(int64(c), true)), nil
+}
+
+// UnmarshalText decodes c from a name or a number.
+func (c *CapabilityType) UnmarshalText(text []byte) error {
+	value, err := capabilityNames.parse(string(text), int64(*c))
+	*c = CapabilityType(value)
+	return err
+}
+
+// MarshalJSON encodes c as a number, as for the other enumerated types.
+func (c CapabilityType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(c), 10)), nil
+}
+
+// UnmarshalJSON decodes c from a number, or a name in a string.
+func (c *CapabilityType) UnmarshalJSON(data []byte) error {
+	value, err := capabilityNames.unmarshalJSON(data, int64(*c))
+	*c = CapabilityType(value)
+	return err
+}
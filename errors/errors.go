@@ -2,7 +2,10 @@
 
 package errors
 
-import "fmt"
+import (
+	stderrors "errors"
+	"fmt"
+)
 
 // LsmError returned from JSON API
 type LsmError struct {
@@ -18,6 +21,13 @@ func (e *LsmError) Error() string {
 	return fmt.Sprintf("code = %d, message = %s", e.Code, e.Message)
 }
 
+// Is reports whether target is an *LsmError with the same code, which makes
+// the Err sentinels work with errors.Is.
+func (e *LsmError) Is(target error) bool {
+	t, ok := target.(*LsmError)
+	return ok && t.Code == e.Code
+}
+
 const (
 	// LibBug ... Library bug
 	LibBug int32 = 1
@@ -37,21 +47,253 @@ const (
 	// PermissionDenied Insufficient permission
 	PermissionDenied int32 = 13
 
+	// NameConflict ... Name already in use
+	NameConflict int32 = 50
+
+	// ExistsInitiator ... Initiator already exists in another access group
+	ExistsInitiator int32 = 52
+
 	// InvalidArgument ... provided argument is incorrect
 	InvalidArgument int32 = 101
 
+	// NoStateChange ... Request results in no change of state
+	NoStateChange int32 = 125
+
+	// NetworkConRefused ... Host on network, but not accepting connections
+	NetworkConRefused int32 = 140
+
+	// NetworkHostDown ... Host unreachable on network
+	NetworkHostDown int32 = 141
+
+	// NetworkError ... Generic network error
+	NetworkError int32 = 142
+
+	// NoMemory ... Out of memory
+	NoMemory int32 = 152
+
 	// NoSupport operation not supported
 	NoSupport int32 = 153
 
+	// IsMasked ... Volume is masked to an access group
+	IsMasked int32 = 160
+
+	// HasChildDependency ... Item has one or more child dependencies
+	HasChildDependency int32 = 161
+
+	// NotFoundAccessGroup specified access group not found
+	NotFoundAccessGroup int32 = 200
+
 	// NotFoundFs specfified file system not found
 	NotFoundFs int32 = 201
 
+	// NotFoundJob specified job not found
+	NotFoundJob int32 = 202
+
+	// NotFoundPool specified pool not found
+	NotFoundPool int32 = 203
+
+	// NotFoundFsSs specified file system snapshot not found
+	NotFoundFsSs int32 = 204
+
+	// NotFoundVolume specified volume not found
+	NotFoundVolume int32 = 205
+
+	// NotFoundNfsExport specified NFS export not found
+	NotFoundNfsExport int32 = 206
+
+	// NotFoundSystem specified system not found
+	NotFoundSystem int32 = 208
+
+	// NotFoundDisk specified disk not found
+	NotFoundDisk int32 = 209
+
+	// NotLicensed ... Feature requires a license which isn't installed
+	NotLicensed int32 = 226
+
+	// NoSupportOnlineChange ... Change can't be made while online
+	NoSupportOnlineChange int32 = 250
+
+	// NoSupportOfflineChange ... Change can't be made while offline
+	NoSupportOfflineChange int32 = 251
+
+	// PluginAuthFailed ... Authentication with the storage failed
+	PluginAuthFailed int32 = 300
+
+	// PluginIpcFail ... Plugin failed to communicate with the storage
+	PluginIpcFail int32 = 301
+
+	// PluginSocketPermission ... Insufficient permission to use the plugin socket
+	PluginSocketPermission int32 = 307
+
 	// PluginNotExist ... Plugin doesn't apprear to exist
 	PluginNotExist int32 = 311
 
+	// NotEnoughSpace ... Insufficient space to complete the request
+	NotEnoughSpace int32 = 350
+
 	//TransPortComunication ... Issue reading/writing to plugin
 	TransPortComunication int32 = 400
 
+	// TransPortSerialization ... Issue serializing or de-serializing IPC data
+	TransPortSerialization int32 = 401
+
 	// TransPortInvalidArg parameter transported over IPC is invalid
 	TransPortInvalidArg int32 = 402
+
+	// LastInitInAccessGroup ... Refusing to remove the last initiator of an access group
+	LastInitInAccessGroup int32 = 502
+
+	// UnsupportedSearchKey ... Search key is not supported for this type
+	UnsupportedSearchKey int32 = 510
+
+	// EmptyAccessGroup ... Access group has no initiators
+	EmptyAccessGroup int32 = 511
+
+	// PoolNotReady ... Pool is not ready for the requested operation
+	PoolNotReady int32 = 512
+
+	// DiskNotFree ... Disk is in use and can't be used for the request
+	DiskNotFree int32 = 513
+)
+
+// Code gives the error codes a name, eg. Code(NotFoundVolume).String()
+type Code int32
+
+var codeNames = map[int32]string{
+	LibBug:                 "LibBug",
+	PluginBug:              "PluginBug",
+	JobStarted:             "JobStarted",
+	TimeOut:                "TimeOut",
+	DameonNotRunning:       "DameonNotRunning",
+	PermissionDenied:       "PermissionDenied",
+	NameConflict:           "NameConflict",
+	ExistsInitiator:        "ExistsInitiator",
+	InvalidArgument:        "InvalidArgument",
+	NoStateChange:          "NoStateChange",
+	NetworkConRefused:      "NetworkConRefused",
+	NetworkHostDown:        "NetworkHostDown",
+	NetworkError:           "NetworkError",
+	NoMemory:               "NoMemory",
+	NoSupport:              "NoSupport",
+	IsMasked:               "IsMasked",
+	HasChildDependency:     "HasChildDependency",
+	NotFoundAccessGroup:    "NotFoundAccessGroup",
+	NotFoundFs:             "NotFoundFs",
+	NotFoundJob:            "NotFoundJob",
+	NotFoundPool:           "NotFoundPool",
+	NotFoundFsSs:           "NotFoundFsSs",
+	NotFoundVolume:         "NotFoundVolume",
+	NotFoundNfsExport:      "NotFoundNfsExport",
+	NotFoundSystem:         "NotFoundSystem",
+	NotFoundDisk:           "NotFoundDisk",
+	NotLicensed:            "NotLicensed",
+	NoSupportOnlineChange:  "NoSupportOnlineChange",
+	NoSupportOfflineChange: "NoSupportOfflineChange",
+	PluginAuthFailed:       "PluginAuthFailed",
+	PluginIpcFail:          "PluginIpcFail",
+	PluginSocketPermission: "PluginSocketPermission",
+	PluginNotExist:         "PluginNotExist",
+	NotEnoughSpace:         "NotEnoughSpace",
+	TransPortComunication:  "TransPortComunication",
+	TransPortSerialization: "TransPortSerialization",
+	TransPortInvalidArg:    "TransPortInvalidArg",
+	LastInitInAccessGroup:  "LastInitInAccessGroup",
+	UnsupportedSearchKey:   "UnsupportedSearchKey",
+	EmptyAccessGroup:       "EmptyAccessGroup",
+	PoolNotReady:           "PoolNotReady",
+	DiskNotFree:            "DiskNotFree",
+}
+
+func (c Code) String() string {
+	if name, ok := codeNames[int32(c)]; ok {
+		return name
+	}
+	return fmt.Sprintf("Code(%d)", int32(c))
+}
+
+// Sentinels for use with errors.Is, only the code is compared.
+var (
+	ErrLibBug                 = &LsmError{Code: LibBug}
+	ErrPluginBug              = &LsmError{Code: PluginBug}
+	ErrJobStarted             = &LsmError{Code: JobStarted}
+	ErrTimeOut                = &LsmError{Code: TimeOut}
+	ErrDameonNotRunning       = &LsmError{Code: DameonNotRunning}
+	ErrPermissionDenied       = &LsmError{Code: PermissionDenied}
+	ErrNameConflict           = &LsmError{Code: NameConflict}
+	ErrExistsInitiator        = &LsmError{Code: ExistsInitiator}
+	ErrInvalidArgument        = &LsmError{Code: InvalidArgument}
+	ErrNoStateChange          = &LsmError{Code: NoStateChange}
+	ErrNetworkConRefused      = &LsmError{Code: NetworkConRefused}
+	ErrNetworkHostDown        = &LsmError{Code: NetworkHostDown}
+	ErrNetworkError           = &LsmError{Code: NetworkError}
+	ErrNoMemory               = &LsmError{Code: NoMemory}
+	ErrNoSupport              = &LsmError{Code: NoSupport}
+	ErrIsMasked               = &LsmError{Code: IsMasked}
+	ErrHasChildDependency     = &LsmError{Code: HasChildDependency}
+	ErrNotFoundAccessGroup    = &LsmError{Code: NotFoundAccessGroup}
+	ErrNotFoundFs             = &LsmError{Code: NotFoundFs}
+	ErrNotFoundJob            = &LsmError{Code: NotFoundJob}
+	ErrNotFoundPool           = &LsmError{Code: NotFoundPool}
+	ErrNotFoundFsSs           = &LsmError{Code: NotFoundFsSs}
+	ErrNotFoundVolume         = &LsmError{Code: NotFoundVolume}
+	ErrNotFoundNfsExport      = &LsmError{Code: NotFoundNfsExport}
+	ErrNotFoundSystem         = &LsmError{Code: NotFoundSystem}
+	ErrNotFoundDisk           = &LsmError{Code: NotFoundDisk}
+	ErrNotLicensed            = &LsmError{Code: NotLicensed}
+	ErrNoSupportOnlineChange  = &LsmError{Code: NoSupportOnlineChange}
+	ErrNoSupportOfflineChange = &LsmError{Code: NoSupportOfflineChange}
+	ErrPluginAuthFailed       = &LsmError{Code: PluginAuthFailed}
+	ErrPluginIpcFail          = &LsmError{Code: PluginIpcFail}
+	ErrPluginSocketPermission = &LsmError{Code: PluginSocketPermission}
+	ErrPluginNotExist         = &LsmError{Code: PluginNotExist}
+	ErrNotEnoughSpace         = &LsmError{Code: NotEnoughSpace}
+	ErrTransPortComunication  = &LsmError{Code: TransPortComunication}
+	ErrTransPortSerialization = &LsmError{Code: TransPortSerialization}
+	ErrTransPortInvalidArg    = &LsmError{Code: TransPortInvalidArg}
+	ErrLastInitInAccessGroup  = &LsmError{Code: LastInitInAccessGroup}
+	ErrUnsupportedSearchKey   = &LsmError{Code: UnsupportedSearchKey}
+	ErrEmptyAccessGroup       = &LsmError{Code: EmptyAccessGroup}
+	ErrPoolNotReady           = &LsmError{Code: PoolNotReady}
+	ErrDiskNotFree            = &LsmError{Code: DiskNotFree}
 )
+
+// CodeOf returns the code of the first LsmError in err's chain.
+func CodeOf(err error) (int32, bool) {
+	var e *LsmError
+	if stderrors.As(err, &e) {
+		return e.Code, true
+	}
+	return 0, false
+}
+
+// MessageOf returns the message of the first LsmError in err's chain, for
+// wrapping it in another, or err.Error() if there is none.
+func MessageOf(err error) string {
+	var e *LsmError
+	if stderrors.As(err, &e) {
+		return e.Message
+	}
+	return err.Error()
+}
+
+// IsNotFound reports whether err is one of the NotFound errors.
+func IsNotFound(err error) bool {
+	code, ok := CodeOf(err)
+	return ok && code >= NotFoundAccessGroup && code <= NotFoundDisk
+}
+
+// IsRetryable reports whether err is a transient failure, where trying the
+// same request again later may succeed.
+func IsRetryable(err error) bool {
+	code, ok := CodeOf(err)
+	if !ok {
+		return false
+	}
+
+	switch code {
+	case TimeOut, NetworkConRefused, NetworkHostDown, NetworkError,
+		PluginIpcFail, TransPortComunication, PoolNotReady:
+		return true
+	}
+	return false
+}
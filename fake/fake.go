@@ -0,0 +1,1076 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package fake provides a Client implementing lsm.StorageClient without a
+// plugin, for testing code which uses the client interfaces.  Every call is
+// recorded and returns whatever the matching function field returns, or zero
+// values when it's nil.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+)
+
+// Call is one recorded call.  Method is the name without the Ctx suffix, both
+// variants are recorded the same way, and Args are the arguments less the
+// context.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client is a fake lsm.StorageClient.  Set the function fields for the calls
+// the code under test makes before using it, they are given the context and
+// arguments of each call.  A Client is safe for concurrent use by multiple
+// goroutines.
+type Client struct {
+	lock  sync.Mutex
+	calls []Call
+
+	// ManagementClient
+	PluginInfoFunc   func(ctx context.Context) (*lsm.PluginInfo, error)
+	CloseFunc        func(ctx context.Context) error
+	SystemsFunc      func(ctx context.Context) ([]lsm.System, error)
+	PoolsFunc        func(ctx context.Context, search ...string) ([]lsm.Pool, error)
+	CapabilitiesFunc func(ctx context.Context, system *lsm.System) (*lsm.Capabilities, error)
+	TimeOutSetFunc   func(ctx context.Context, milliSeconds uint32) error
+	TimeOutGetFunc   func() uint32
+	JobStatusFunc    func(ctx context.Context, jobID string, returnedResult interface{}) (lsm.JobStatusType, uint8, error)
+	JobFreeFunc      func(ctx context.Context, jobID string) error
+	JobWaitFunc      func(ctx context.Context, jobID string, returnedResult interface{}) error
+
+	// SanClient
+	VolumesFunc               func(ctx context.Context, search ...string) ([]lsm.Volume, error)
+	DisksFunc                 func(ctx context.Context) ([]lsm.Disk, error)
+	VolumeCreateFunc          func(ctx context.Context, pool *lsm.Pool, volumeName string, size uint64, provisioning lsm.VolumeProvisionType, sync bool) (*lsm.Volume, *string, error)
+	VolumeDeleteFunc          func(ctx context.Context, vol *lsm.Volume, sync bool) (*string, error)
+	VolumeResizeFunc          func(ctx context.Context, vol *lsm.Volume, newSizeBytes uint64, sync bool) (*lsm.Volume, *string, error)
+	VolumeReplicateFunc       func(ctx context.Context, optionalPool *lsm.Pool, repType lsm.VolumeReplicateType, sourceVolume *lsm.Volume, name string, sync bool) (*lsm.Volume, *string, error)
+	VolumeRepRangeBlkSizeFunc func(ctx context.Context, system *lsm.System) (uint32, error)
+	VolumeReplicateRangeFunc  func(ctx context.Context, repType lsm.VolumeReplicateType, srcVol *lsm.Volume, dstVol *lsm.Volume, ranges []lsm.BlockRange, sync bool) (*string, error)
+	VolumeEnableFunc          func(ctx context.Context, vol *lsm.Volume) error
+	VolumeDisableFunc         func(ctx context.Context, vol *lsm.Volume) error
+	VolumeMaskFunc            func(ctx context.Context, vol *lsm.Volume, ag *lsm.AccessGroup) error
+	VolumeUnMaskFunc          func(ctx context.Context, vol *lsm.Volume, ag *lsm.AccessGroup) error
+	VolsMaskedToAgFunc        func(ctx context.Context, ag *lsm.AccessGroup) ([]lsm.Volume, error)
+	AgsGrantedToVolFunc       func(ctx context.Context, vol *lsm.Volume) ([]lsm.AccessGroup, error)
+	VolHasChildDepFunc        func(ctx context.Context, vol *lsm.Volume) (bool, error)
+	VolChildDepRmFunc         func(ctx context.Context, vol *lsm.Volume, sync bool) (*string, error)
+	AccessGroupsFunc          func(ctx context.Context) ([]lsm.AccessGroup, error)
+	AccessGroupCreateFunc     func(ctx context.Context, name string, initID string, initType lsm.InitiatorType, system *lsm.System) (*lsm.AccessGroup, error)
+	AccessGroupDeleteFunc     func(ctx context.Context, ag *lsm.AccessGroup) error
+	AccessGroupInitAddFunc    func(ctx context.Context, ag *lsm.AccessGroup, initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error)
+	AccessGroupInitDeleteFunc func(ctx context.Context, ag *lsm.AccessGroup, initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error)
+	IscsiChapAuthSetFunc      func(ctx context.Context, initID string, inUser *string, inPassword *string, outUser *string, outPassword *string) error
+	TargetPortsFunc           func(ctx context.Context) ([]lsm.TargetPort, error)
+	VolIdentLedOnFunc         func(ctx context.Context, volume *lsm.Volume) error
+	VolIdentLedOffFunc        func(ctx context.Context, volume *lsm.Volume) error
+
+	// FsClient
+	FileSystemsFunc       func(ctx context.Context, search ...string) ([]lsm.FileSystem, error)
+	FsCreateFunc          func(ctx context.Context, pool *lsm.Pool, name string, size uint64, sync bool) (*lsm.FileSystem, *string, error)
+	FsResizeFunc          func(ctx context.Context, fs *lsm.FileSystem, newSizeBytes uint64, sync bool) (*lsm.FileSystem, *string, error)
+	FsDeleteFunc          func(ctx context.Context, fs *lsm.FileSystem, sync bool) (*string, error)
+	FsCloneFunc           func(ctx context.Context, srcFs *lsm.FileSystem, destName string, optionalSnapShot *lsm.FileSystemSnapShot, sync bool) (*lsm.FileSystem, *string, error)
+	FsFileCloneFunc       func(ctx context.Context, fs *lsm.FileSystem, srcFileName string, dstFileName string, optionalSnapShot *lsm.FileSystemSnapShot, sync bool) (*string, error)
+	FsSnapShotCreateFunc  func(ctx context.Context, fs *lsm.FileSystem, name string, sync bool) (*lsm.FileSystemSnapShot, *string, error)
+	FsSnapShotDeleteFunc  func(ctx context.Context, fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot, sync bool) (*string, error)
+	FsSnapShotsFunc       func(ctx context.Context, fs *lsm.FileSystem) ([]lsm.FileSystemSnapShot, error)
+	FsSnapShotRestoreFunc func(ctx context.Context, fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot, allFiles bool, files []string, restoreFiles []string, sync bool) (*string, error)
+	FsHasChildDepFunc     func(ctx context.Context, fs *lsm.FileSystem, files []string) (bool, error)
+	FsChildDepRmFunc      func(ctx context.Context, fs *lsm.FileSystem, files []string, sync bool) (*string, error)
+
+	// NfsClient
+	NfsExportsFunc         func(ctx context.Context, search ...string) ([]lsm.NfsExport, error)
+	NfsExportAuthTypesFunc func(ctx context.Context) ([]string, error)
+	FsExportFunc           func(ctx context.Context, fs *lsm.FileSystem, exportPath *string, access *lsm.NfsAccess, authType *string, options *string) (*lsm.NfsExport, error)
+	FsUnExportFunc         func(ctx context.Context, export *lsm.NfsExport) error
+
+	// HbaClient
+	VolRaidInfoFunc         func(ctx context.Context, vol *lsm.Volume) (*lsm.VolumeRaidInfo, error)
+	PoolMemberInfoFunc      func(ctx context.Context, pool *lsm.Pool) (*lsm.PoolMemberInfo, error)
+	VolRaidCreateCapGetFunc func(ctx context.Context, system *lsm.System) (*lsm.SupportedRaidCapability, error)
+	VolRaidCreateFunc       func(ctx context.Context, name string, raidType lsm.RaidType, disks []lsm.Disk, stripSize uint32) (*lsm.Volume, error)
+	BatteriesFunc           func(ctx context.Context) ([]lsm.Battery, error)
+
+	// CacheClient
+	SysReadCachePctSetFunc func(ctx context.Context, system *lsm.System, readPercent uint32) error
+	VolCacheInfoFunc       func(ctx context.Context, volume *lsm.Volume) (*lsm.VolumeCacheInfo, error)
+	VolPhyDiskCacheSetFunc func(ctx context.Context, volume *lsm.Volume, pdc lsm.PhysicalDiskCache) error
+	VolWriteCacheSetFunc   func(ctx context.Context, volume *lsm.Volume, wcp lsm.WriteCachePolicy) error
+	VolReadCacheSetFunc    func(ctx context.Context, volume *lsm.Volume, rcp lsm.ReadCachePolicy) error
+}
+
+var _ lsm.StorageClient = (*Client)(nil)
+
+// New returns a Client with no function fields set.
+func New() *Client {
+	return &Client{}
+}
+
+func (f *Client) record(method string, args ...interface{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, oldest first.
+func (f *Client) Calls() []Call {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]Call{}, f.calls...)
+}
+
+// CallsTo returns the calls made so far to method, oldest first.
+func (f *Client) CallsTo(method string) []Call {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var calls []Call
+	for _, c := range f.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the calls made so far.
+func (f *Client) Reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.calls = nil
+}
+
+// Job records the call and returns a handle using f for JobStatus and JobFree.
+func (f *Client) Job(jobID string, result interface{}) *lsm.Job {
+	f.record("Job", jobID, result)
+	return lsm.NewJob(f, jobID, result)
+}
+
+// PluginInfo calls PluginInfoCtx with a background context.
+func (f *Client) PluginInfo() (*lsm.PluginInfo, error) {
+	return f.PluginInfoCtx(context.Background())
+}
+
+// PluginInfoCtx records the call and returns the result of PluginInfoFunc, if set.
+func (f *Client) PluginInfoCtx(ctx context.Context) (*lsm.PluginInfo, error) {
+	f.record("PluginInfo")
+	if f.PluginInfoFunc != nil {
+		return f.PluginInfoFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Close calls CloseCtx with a background context.
+func (f *Client) Close() error {
+	return f.CloseCtx(context.Background())
+}
+
+// CloseCtx records the call and returns the result of CloseFunc, if set.
+func (f *Client) CloseCtx(ctx context.Context) error {
+	f.record("Close")
+	if f.CloseFunc != nil {
+		return f.CloseFunc(ctx)
+	}
+	return nil
+}
+
+// Systems calls SystemsCtx with a background context.
+func (f *Client) Systems() ([]lsm.System, error) {
+	return f.SystemsCtx(context.Background())
+}
+
+// SystemsCtx records the call and returns the result of SystemsFunc, if set.
+func (f *Client) SystemsCtx(ctx context.Context) ([]lsm.System, error) {
+	f.record("Systems")
+	if f.SystemsFunc != nil {
+		return f.SystemsFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Pools calls PoolsCtx with a background context.
+func (f *Client) Pools(search ...string) ([]lsm.Pool, error) {
+	return f.PoolsCtx(context.Background(), search...)
+}
+
+// PoolsCtx records the call and returns the result of PoolsFunc, if set.
+func (f *Client) PoolsCtx(ctx context.Context, search ...string) ([]lsm.Pool, error) {
+	f.record("Pools", search)
+	if f.PoolsFunc != nil {
+		return f.PoolsFunc(ctx, search...)
+	}
+	return nil, nil
+}
+
+// PoolsBy calls PoolsCtx with the key and value of the search.
+func (f *Client) PoolsBy(ctx context.Context, search lsm.Search) ([]lsm.Pool, error) {
+	return f.PoolsCtx(ctx, search.Key, search.Value)
+}
+
+// Capabilities calls CapabilitiesCtx with a background context.
+func (f *Client) Capabilities(system *lsm.System) (*lsm.Capabilities, error) {
+	return f.CapabilitiesCtx(context.Background(), system)
+}
+
+// CapabilitiesCtx records the call and returns the result of CapabilitiesFunc, if set.
+func (f *Client) CapabilitiesCtx(ctx context.Context, system *lsm.System) (*lsm.Capabilities, error) {
+	f.record("Capabilities", system)
+	if f.CapabilitiesFunc != nil {
+		return f.CapabilitiesFunc(ctx, system)
+	}
+	return nil, nil
+}
+
+// TimeOutSet calls TimeOutSetCtx with a background context.
+func (f *Client) TimeOutSet(milliSeconds uint32) error {
+	return f.TimeOutSetCtx(context.Background(), milliSeconds)
+}
+
+// TimeOutSetCtx records the call and returns the result of TimeOutSetFunc, if set.
+func (f *Client) TimeOutSetCtx(ctx context.Context, milliSeconds uint32) error {
+	f.record("TimeOutSet", milliSeconds)
+	if f.TimeOutSetFunc != nil {
+		return f.TimeOutSetFunc(ctx, milliSeconds)
+	}
+	return nil
+}
+
+// TimeOutGet records the call and returns the result of TimeOutGetFunc, if set.
+func (f *Client) TimeOutGet() uint32 {
+	f.record("TimeOutGet")
+	if f.TimeOutGetFunc != nil {
+		return f.TimeOutGetFunc()
+	}
+	return 0
+}
+
+// JobStatus calls JobStatusCtx with a background context.
+func (f *Client) JobStatus(jobID string, returnedResult interface{}) (lsm.JobStatusType, uint8, error) {
+	return f.JobStatusCtx(context.Background(), jobID, returnedResult)
+}
+
+// JobStatusCtx records the call and returns the result of JobStatusFunc, if
+// set.  Otherwise jobs are reported complete, so waiting on them finishes.
+func (f *Client) JobStatusCtx(ctx context.Context, jobID string, returnedResult interface{}) (lsm.JobStatusType, uint8, error) {
+	f.record("JobStatus", jobID, returnedResult)
+	if f.JobStatusFunc != nil {
+		return f.JobStatusFunc(ctx, jobID, returnedResult)
+	}
+	return lsm.JobStatusComplete, 100, nil
+}
+
+// JobFree calls JobFreeCtx with a background context.
+func (f *Client) JobFree(jobID string) error {
+	return f.JobFreeCtx(context.Background(), jobID)
+}
+
+// JobFreeCtx records the call and returns the result of JobFreeFunc, if set.
+func (f *Client) JobFreeCtx(ctx context.Context, jobID string) error {
+	f.record("JobFree", jobID)
+	if f.JobFreeFunc != nil {
+		return f.JobFreeFunc(ctx, jobID)
+	}
+	return nil
+}
+
+// JobWait calls JobWaitCtx with a background context.
+func (f *Client) JobWait(jobID string, returnedResult interface{}) error {
+	return f.JobWaitCtx(context.Background(), jobID, returnedResult)
+}
+
+// JobWaitCtx records the call and returns the result of JobWaitFunc, if set.
+func (f *Client) JobWaitCtx(ctx context.Context, jobID string, returnedResult interface{}) error {
+	f.record("JobWait", jobID, returnedResult)
+	if f.JobWaitFunc != nil {
+		return f.JobWaitFunc(ctx, jobID, returnedResult)
+	}
+	return nil
+}
+
+// Volumes calls VolumesCtx with a background context.
+func (f *Client) Volumes(search ...string) ([]lsm.Volume, error) {
+	return f.VolumesCtx(context.Background(), search...)
+}
+
+// VolumesCtx records the call and returns the result of VolumesFunc, if set.
+func (f *Client) VolumesCtx(ctx context.Context, search ...string) ([]lsm.Volume, error) {
+	f.record("Volumes", search)
+	if f.VolumesFunc != nil {
+		return f.VolumesFunc(ctx, search...)
+	}
+	return nil, nil
+}
+
+// VolumesBy calls VolumesCtx with the key and value of the search.
+func (f *Client) VolumesBy(ctx context.Context, search lsm.Search) ([]lsm.Volume, error) {
+	return f.VolumesCtx(ctx, search.Key, search.Value)
+}
+
+// Disks calls DisksCtx with a background context.
+func (f *Client) Disks() ([]lsm.Disk, error) {
+	return f.DisksCtx(context.Background())
+}
+
+// DisksCtx records the call and returns the result of DisksFunc, if set.
+func (f *Client) DisksCtx(ctx context.Context) ([]lsm.Disk, error) {
+	f.record("Disks")
+	if f.DisksFunc != nil {
+		return f.DisksFunc(ctx)
+	}
+	return nil, nil
+}
+
+// VolumeCreate calls VolumeCreateCtx with a background context.
+func (f *Client) VolumeCreate(
+	pool *lsm.Pool, volumeName string,
+	size uint64,
+	provisioning lsm.VolumeProvisionType, sync bool) (*lsm.Volume, *string, error) {
+	return f.VolumeCreateCtx(context.Background(), pool, volumeName, size, provisioning, sync)
+}
+
+// VolumeCreateCtx records the call and returns the result of VolumeCreateFunc, if set.
+func (f *Client) VolumeCreateCtx(
+	ctx context.Context,
+	pool *lsm.Pool, volumeName string,
+	size uint64,
+	provisioning lsm.VolumeProvisionType, sync bool) (*lsm.Volume, *string, error) {
+	f.record("VolumeCreate", pool, volumeName, size, provisioning, sync)
+	if f.VolumeCreateFunc != nil {
+		return f.VolumeCreateFunc(ctx, pool, volumeName, size, provisioning, sync)
+	}
+	return nil, nil, nil
+}
+
+// VolumeDelete calls VolumeDeleteCtx with a background context.
+func (f *Client) VolumeDelete(vol *lsm.Volume, sync bool) (*string, error) {
+	return f.VolumeDeleteCtx(context.Background(), vol, sync)
+}
+
+// VolumeDeleteCtx records the call and returns the result of VolumeDeleteFunc, if set.
+func (f *Client) VolumeDeleteCtx(ctx context.Context, vol *lsm.Volume, sync bool) (*string, error) {
+	f.record("VolumeDelete", vol, sync)
+	if f.VolumeDeleteFunc != nil {
+		return f.VolumeDeleteFunc(ctx, vol, sync)
+	}
+	return nil, nil
+}
+
+// VolumeResize calls VolumeResizeCtx with a background context.
+func (f *Client) VolumeResize(vol *lsm.Volume, newSizeBytes uint64, sync bool) (*lsm.Volume, *string, error) {
+	return f.VolumeResizeCtx(context.Background(), vol, newSizeBytes, sync)
+}
+
+// VolumeResizeCtx records the call and returns the result of VolumeResizeFunc, if set.
+func (f *Client) VolumeResizeCtx(ctx context.Context, vol *lsm.Volume, newSizeBytes uint64, sync bool) (*lsm.Volume, *string, error) {
+	f.record("VolumeResize", vol, newSizeBytes, sync)
+	if f.VolumeResizeFunc != nil {
+		return f.VolumeResizeFunc(ctx, vol, newSizeBytes, sync)
+	}
+	return nil, nil, nil
+}
+
+// VolumeReplicate calls VolumeReplicateCtx with a background context.
+func (f *Client) VolumeReplicate(
+	optionalPool *lsm.Pool, repType lsm.VolumeReplicateType, sourceVolume *lsm.Volume, name string,
+	sync bool) (*lsm.Volume, *string, error) {
+	return f.VolumeReplicateCtx(context.Background(), optionalPool, repType, sourceVolume, name, sync)
+}
+
+// VolumeReplicateCtx records the call and returns the result of VolumeReplicateFunc, if set.
+func (f *Client) VolumeReplicateCtx(
+	ctx context.Context,
+	optionalPool *lsm.Pool, repType lsm.VolumeReplicateType, sourceVolume *lsm.Volume, name string,
+	sync bool) (*lsm.Volume, *string, error) {
+	f.record("VolumeReplicate", optionalPool, repType, sourceVolume, name, sync)
+	if f.VolumeReplicateFunc != nil {
+		return f.VolumeReplicateFunc(ctx, optionalPool, repType, sourceVolume, name, sync)
+	}
+	return nil, nil, nil
+}
+
+// VolumeRepRangeBlkSize calls VolumeRepRangeBlkSizeCtx with a background context.
+func (f *Client) VolumeRepRangeBlkSize(system *lsm.System) (uint32, error) {
+	return f.VolumeRepRangeBlkSizeCtx(context.Background(), system)
+}
+
+// VolumeRepRangeBlkSizeCtx records the call and returns the result of VolumeRepRangeBlkSizeFunc, if set.
+func (f *Client) VolumeRepRangeBlkSizeCtx(ctx context.Context, system *lsm.System) (uint32, error) {
+	f.record("VolumeRepRangeBlkSize", system)
+	if f.VolumeRepRangeBlkSizeFunc != nil {
+		return f.VolumeRepRangeBlkSizeFunc(ctx, system)
+	}
+	return 0, nil
+}
+
+// VolumeReplicateRange calls VolumeReplicateRangeCtx with a background context.
+func (f *Client) VolumeReplicateRange(
+	repType lsm.VolumeReplicateType, srcVol *lsm.Volume, dstVol *lsm.Volume, ranges []lsm.BlockRange, sync bool) (*string, error) {
+	return f.VolumeReplicateRangeCtx(context.Background(), repType, srcVol, dstVol, ranges, sync)
+}
+
+// VolumeReplicateRangeCtx records the call and returns the result of VolumeReplicateRangeFunc, if set.
+func (f *Client) VolumeReplicateRangeCtx(
+	ctx context.Context,
+	repType lsm.VolumeReplicateType, srcVol *lsm.Volume, dstVol *lsm.Volume, ranges []lsm.BlockRange, sync bool) (*string, error) {
+	f.record("VolumeReplicateRange", repType, srcVol, dstVol, ranges, sync)
+	if f.VolumeReplicateRangeFunc != nil {
+		return f.VolumeReplicateRangeFunc(ctx, repType, srcVol, dstVol, ranges, sync)
+	}
+	return nil, nil
+}
+
+// VolumeEnable calls VolumeEnableCtx with a background context.
+func (f *Client) VolumeEnable(vol *lsm.Volume) error {
+	return f.VolumeEnableCtx(context.Background(), vol)
+}
+
+// VolumeEnableCtx records the call and returns the result of VolumeEnableFunc, if set.
+func (f *Client) VolumeEnableCtx(ctx context.Context, vol *lsm.Volume) error {
+	f.record("VolumeEnable", vol)
+	if f.VolumeEnableFunc != nil {
+		return f.VolumeEnableFunc(ctx, vol)
+	}
+	return nil
+}
+
+// VolumeDisable calls VolumeDisableCtx with a background context.
+func (f *Client) VolumeDisable(vol *lsm.Volume) error {
+	return f.VolumeDisableCtx(context.Background(), vol)
+}
+
+// VolumeDisableCtx records the call and returns the result of VolumeDisableFunc, if set.
+func (f *Client) VolumeDisableCtx(ctx context.Context, vol *lsm.Volume) error {
+	f.record("VolumeDisable", vol)
+	if f.VolumeDisableFunc != nil {
+		return f.VolumeDisableFunc(ctx, vol)
+	}
+	return nil
+}
+
+// VolumeMask calls VolumeMaskCtx with a background context.
+func (f *Client) VolumeMask(vol *lsm.Volume, ag *lsm.AccessGroup) error {
+	return f.VolumeMaskCtx(context.Background(), vol, ag)
+}
+
+// VolumeMaskCtx records the call and returns the result of VolumeMaskFunc, if set.
+func (f *Client) VolumeMaskCtx(ctx context.Context, vol *lsm.Volume, ag *lsm.AccessGroup) error {
+	f.record("VolumeMask", vol, ag)
+	if f.VolumeMaskFunc != nil {
+		return f.VolumeMaskFunc(ctx, vol, ag)
+	}
+	return nil
+}
+
+// VolumeUnMask calls VolumeUnMaskCtx with a background context.
+func (f *Client) VolumeUnMask(vol *lsm.Volume, ag *lsm.AccessGroup) error {
+	return f.VolumeUnMaskCtx(context.Background(), vol, ag)
+}
+
+// VolumeUnMaskCtx records the call and returns the result of VolumeUnMaskFunc, if set.
+func (f *Client) VolumeUnMaskCtx(ctx context.Context, vol *lsm.Volume, ag *lsm.AccessGroup) error {
+	f.record("VolumeUnMask", vol, ag)
+	if f.VolumeUnMaskFunc != nil {
+		return f.VolumeUnMaskFunc(ctx, vol, ag)
+	}
+	return nil
+}
+
+// VolsMaskedToAg calls VolsMaskedToAgCtx with a background context.
+func (f *Client) VolsMaskedToAg(ag *lsm.AccessGroup) ([]lsm.Volume, error) {
+	return f.VolsMaskedToAgCtx(context.Background(), ag)
+}
+
+// VolsMaskedToAgCtx records the call and returns the result of VolsMaskedToAgFunc, if set.
+func (f *Client) VolsMaskedToAgCtx(ctx context.Context, ag *lsm.AccessGroup) ([]lsm.Volume, error) {
+	f.record("VolsMaskedToAg", ag)
+	if f.VolsMaskedToAgFunc != nil {
+		return f.VolsMaskedToAgFunc(ctx, ag)
+	}
+	return nil, nil
+}
+
+// AgsGrantedToVol calls AgsGrantedToVolCtx with a background context.
+func (f *Client) AgsGrantedToVol(vol *lsm.Volume) ([]lsm.AccessGroup, error) {
+	return f.AgsGrantedToVolCtx(context.Background(), vol)
+}
+
+// AgsGrantedToVolCtx records the call and returns the result of AgsGrantedToVolFunc, if set.
+func (f *Client) AgsGrantedToVolCtx(ctx context.Context, vol *lsm.Volume) ([]lsm.AccessGroup, error) {
+	f.record("AgsGrantedToVol", vol)
+	if f.AgsGrantedToVolFunc != nil {
+		return f.AgsGrantedToVolFunc(ctx, vol)
+	}
+	return nil, nil
+}
+
+// VolHasChildDep calls VolHasChildDepCtx with a background context.
+func (f *Client) VolHasChildDep(vol *lsm.Volume) (bool, error) {
+	return f.VolHasChildDepCtx(context.Background(), vol)
+}
+
+// VolHasChildDepCtx records the call and returns the result of VolHasChildDepFunc, if set.
+func (f *Client) VolHasChildDepCtx(ctx context.Context, vol *lsm.Volume) (bool, error) {
+	f.record("VolHasChildDep", vol)
+	if f.VolHasChildDepFunc != nil {
+		return f.VolHasChildDepFunc(ctx, vol)
+	}
+	return false, nil
+}
+
+// VolChildDepRm calls VolChildDepRmCtx with a background context.
+func (f *Client) VolChildDepRm(vol *lsm.Volume, sync bool) (*string, error) {
+	return f.VolChildDepRmCtx(context.Background(), vol, sync)
+}
+
+// VolChildDepRmCtx records the call and returns the result of VolChildDepRmFunc, if set.
+func (f *Client) VolChildDepRmCtx(ctx context.Context, vol *lsm.Volume, sync bool) (*string, error) {
+	f.record("VolChildDepRm", vol, sync)
+	if f.VolChildDepRmFunc != nil {
+		return f.VolChildDepRmFunc(ctx, vol, sync)
+	}
+	return nil, nil
+}
+
+// AccessGroups calls AccessGroupsCtx with a background context.
+func (f *Client) AccessGroups() ([]lsm.AccessGroup, error) {
+	return f.AccessGroupsCtx(context.Background())
+}
+
+// AccessGroupsCtx records the call and returns the result of AccessGroupsFunc, if set.
+func (f *Client) AccessGroupsCtx(ctx context.Context) ([]lsm.AccessGroup, error) {
+	f.record("AccessGroups")
+	if f.AccessGroupsFunc != nil {
+		return f.AccessGroupsFunc(ctx)
+	}
+	return nil, nil
+}
+
+// AccessGroupCreate calls AccessGroupCreateCtx with a background context.
+func (f *Client) AccessGroupCreate(name string, initID string,
+	initType lsm.InitiatorType, system *lsm.System) (*lsm.AccessGroup, error) {
+	return f.AccessGroupCreateCtx(context.Background(), name, initID, initType, system)
+}
+
+// AccessGroupCreateCtx records the call and returns the result of AccessGroupCreateFunc, if set.
+func (f *Client) AccessGroupCreateCtx(ctx context.Context, name string, initID string,
+	initType lsm.InitiatorType, system *lsm.System) (*lsm.AccessGroup, error) {
+	f.record("AccessGroupCreate", name, initID, initType, system)
+	if f.AccessGroupCreateFunc != nil {
+		return f.AccessGroupCreateFunc(ctx, name, initID, initType, system)
+	}
+	return nil, nil
+}
+
+// AccessGroupDelete calls AccessGroupDeleteCtx with a background context.
+func (f *Client) AccessGroupDelete(ag *lsm.AccessGroup) error {
+	return f.AccessGroupDeleteCtx(context.Background(), ag)
+}
+
+// AccessGroupDeleteCtx records the call and returns the result of AccessGroupDeleteFunc, if set.
+func (f *Client) AccessGroupDeleteCtx(ctx context.Context, ag *lsm.AccessGroup) error {
+	f.record("AccessGroupDelete", ag)
+	if f.AccessGroupDeleteFunc != nil {
+		return f.AccessGroupDeleteFunc(ctx, ag)
+	}
+	return nil
+}
+
+// AccessGroupInitAdd calls AccessGroupInitAddCtx with a background context.
+func (f *Client) AccessGroupInitAdd(ag *lsm.AccessGroup, initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error) {
+	return f.AccessGroupInitAddCtx(context.Background(), ag, initID, initType)
+}
+
+// AccessGroupInitAddCtx records the call and returns the result of AccessGroupInitAddFunc, if set.
+func (f *Client) AccessGroupInitAddCtx(ctx context.Context, ag *lsm.AccessGroup, initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error) {
+	f.record("AccessGroupInitAdd", ag, initID, initType)
+	if f.AccessGroupInitAddFunc != nil {
+		return f.AccessGroupInitAddFunc(ctx, ag, initID, initType)
+	}
+	return nil, nil
+}
+
+// AccessGroupInitDelete calls AccessGroupInitDeleteCtx with a background context.
+func (f *Client) AccessGroupInitDelete(ag *lsm.AccessGroup, initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error) {
+	return f.AccessGroupInitDeleteCtx(context.Background(), ag, initID, initType)
+}
+
+// AccessGroupInitDeleteCtx records the call and returns the result of AccessGroupInitDeleteFunc, if set.
+func (f *Client) AccessGroupInitDeleteCtx(ctx context.Context, ag *lsm.AccessGroup, initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error) {
+	f.record("AccessGroupInitDelete", ag, initID, initType)
+	if f.AccessGroupInitDeleteFunc != nil {
+		return f.AccessGroupInitDeleteFunc(ctx, ag, initID, initType)
+	}
+	return nil, nil
+}
+
+// IscsiChapAuthSet calls IscsiChapAuthSetCtx with a background context.
+func (f *Client) IscsiChapAuthSet(initID string, inUser *string, inPassword *string,
+	outUser *string, outPassword *string) error {
+	return f.IscsiChapAuthSetCtx(context.Background(), initID, inUser, inPassword, outUser, outPassword)
+}
+
+// IscsiChapAuthSetCtx records the call and returns the result of IscsiChapAuthSetFunc, if set.
+func (f *Client) IscsiChapAuthSetCtx(ctx context.Context, initID string, inUser *string, inPassword *string,
+	outUser *string, outPassword *string) error {
+	f.record("IscsiChapAuthSet", initID, inUser, inPassword, outUser, outPassword)
+	if f.IscsiChapAuthSetFunc != nil {
+		return f.IscsiChapAuthSetFunc(ctx, initID, inUser, inPassword, outUser, outPassword)
+	}
+	return nil
+}
+
+// TargetPorts calls TargetPortsCtx with a background context.
+func (f *Client) TargetPorts() ([]lsm.TargetPort, error) {
+	return f.TargetPortsCtx(context.Background())
+}
+
+// TargetPortsCtx records the call and returns the result of TargetPortsFunc, if set.
+func (f *Client) TargetPortsCtx(ctx context.Context) ([]lsm.TargetPort, error) {
+	f.record("TargetPorts")
+	if f.TargetPortsFunc != nil {
+		return f.TargetPortsFunc(ctx)
+	}
+	return nil, nil
+}
+
+// VolIdentLedOn calls VolIdentLedOnCtx with a background context.
+func (f *Client) VolIdentLedOn(volume *lsm.Volume) error {
+	return f.VolIdentLedOnCtx(context.Background(), volume)
+}
+
+// VolIdentLedOnCtx records the call and returns the result of VolIdentLedOnFunc, if set.
+func (f *Client) VolIdentLedOnCtx(ctx context.Context, volume *lsm.Volume) error {
+	f.record("VolIdentLedOn", volume)
+	if f.VolIdentLedOnFunc != nil {
+		return f.VolIdentLedOnFunc(ctx, volume)
+	}
+	return nil
+}
+
+// VolIdentLedOff calls VolIdentLedOffCtx with a background context.
+func (f *Client) VolIdentLedOff(volume *lsm.Volume) error {
+	return f.VolIdentLedOffCtx(context.Background(), volume)
+}
+
+// VolIdentLedOffCtx records the call and returns the result of VolIdentLedOffFunc, if set.
+func (f *Client) VolIdentLedOffCtx(ctx context.Context, volume *lsm.Volume) error {
+	f.record("VolIdentLedOff", volume)
+	if f.VolIdentLedOffFunc != nil {
+		return f.VolIdentLedOffFunc(ctx, volume)
+	}
+	return nil
+}
+
+// FileSystems calls FileSystemsCtx with a background context.
+func (f *Client) FileSystems(search ...string) ([]lsm.FileSystem, error) {
+	return f.FileSystemsCtx(context.Background(), search...)
+}
+
+// FileSystemsCtx records the call and returns the result of FileSystemsFunc, if set.
+func (f *Client) FileSystemsCtx(ctx context.Context, search ...string) ([]lsm.FileSystem, error) {
+	f.record("FileSystems", search)
+	if f.FileSystemsFunc != nil {
+		return f.FileSystemsFunc(ctx, search...)
+	}
+	return nil, nil
+}
+
+// FileSystemsBy calls FileSystemsCtx with the key and value of the search.
+func (f *Client) FileSystemsBy(ctx context.Context, search lsm.Search) ([]lsm.FileSystem, error) {
+	return f.FileSystemsCtx(ctx, search.Key, search.Value)
+}
+
+// FsCreate calls FsCreateCtx with a background context.
+func (f *Client) FsCreate(
+	pool *lsm.Pool, name string,
+	size uint64,
+	sync bool) (*lsm.FileSystem, *string, error) {
+	return f.FsCreateCtx(context.Background(), pool, name, size, sync)
+}
+
+// FsCreateCtx records the call and returns the result of FsCreateFunc, if set.
+func (f *Client) FsCreateCtx(
+	ctx context.Context,
+	pool *lsm.Pool, name string,
+	size uint64,
+	sync bool) (*lsm.FileSystem, *string, error) {
+	f.record("FsCreate", pool, name, size, sync)
+	if f.FsCreateFunc != nil {
+		return f.FsCreateFunc(ctx, pool, name, size, sync)
+	}
+	return nil, nil, nil
+}
+
+// FsResize calls FsResizeCtx with a background context.
+func (f *Client) FsResize(
+	fs *lsm.FileSystem, newSizeBytes uint64, sync bool) (*lsm.FileSystem, *string, error) {
+	return f.FsResizeCtx(context.Background(), fs, newSizeBytes, sync)
+}
+
+// FsResizeCtx records the call and returns the result of FsResizeFunc, if set.
+func (f *Client) FsResizeCtx(
+	ctx context.Context,
+	fs *lsm.FileSystem, newSizeBytes uint64, sync bool) (*lsm.FileSystem, *string, error) {
+	f.record("FsResize", fs, newSizeBytes, sync)
+	if f.FsResizeFunc != nil {
+		return f.FsResizeFunc(ctx, fs, newSizeBytes, sync)
+	}
+	return nil, nil, nil
+}
+
+// FsDelete calls FsDeleteCtx with a background context.
+func (f *Client) FsDelete(fs *lsm.FileSystem, sync bool) (*string, error) {
+	return f.FsDeleteCtx(context.Background(), fs, sync)
+}
+
+// FsDeleteCtx records the call and returns the result of FsDeleteFunc, if set.
+func (f *Client) FsDeleteCtx(ctx context.Context, fs *lsm.FileSystem, sync bool) (*string, error) {
+	f.record("FsDelete", fs, sync)
+	if f.FsDeleteFunc != nil {
+		return f.FsDeleteFunc(ctx, fs, sync)
+	}
+	return nil, nil
+}
+
+// FsClone calls FsCloneCtx with a background context.
+func (f *Client) FsClone(
+	srcFs *lsm.FileSystem, destName string,
+	optionalSnapShot *lsm.FileSystemSnapShot, sync bool) (*lsm.FileSystem, *string, error) {
+	return f.FsCloneCtx(context.Background(), srcFs, destName, optionalSnapShot, sync)
+}
+
+// FsCloneCtx records the call and returns the result of FsCloneFunc, if set.
+func (f *Client) FsCloneCtx(
+	ctx context.Context,
+	srcFs *lsm.FileSystem, destName string,
+	optionalSnapShot *lsm.FileSystemSnapShot, sync bool) (*lsm.FileSystem, *string, error) {
+	f.record("FsClone", srcFs, destName, optionalSnapShot, sync)
+	if f.FsCloneFunc != nil {
+		return f.FsCloneFunc(ctx, srcFs, destName, optionalSnapShot, sync)
+	}
+	return nil, nil, nil
+}
+
+// FsFileClone calls FsFileCloneCtx with a background context.
+func (f *Client) FsFileClone(
+	fs *lsm.FileSystem, srcFileName string,
+	dstFileName string,
+	optionalSnapShot *lsm.FileSystemSnapShot, sync bool,
+) (*string, error) {
+	return f.FsFileCloneCtx(context.Background(), fs, srcFileName, dstFileName, optionalSnapShot, sync)
+}
+
+// FsFileCloneCtx records the call and returns the result of FsFileCloneFunc, if set.
+func (f *Client) FsFileCloneCtx(
+	ctx context.Context,
+	fs *lsm.FileSystem, srcFileName string,
+	dstFileName string,
+	optionalSnapShot *lsm.FileSystemSnapShot, sync bool,
+) (*string, error) {
+	f.record("FsFileClone", fs, srcFileName, dstFileName, optionalSnapShot, sync)
+	if f.FsFileCloneFunc != nil {
+		return f.FsFileCloneFunc(ctx, fs, srcFileName, dstFileName, optionalSnapShot, sync)
+	}
+	return nil, nil
+}
+
+// FsSnapShotCreate calls FsSnapShotCreateCtx with a background context.
+func (f *Client) FsSnapShotCreate(fs *lsm.FileSystem, name string, sync bool) (*lsm.FileSystemSnapShot, *string, error) {
+	return f.FsSnapShotCreateCtx(context.Background(), fs, name, sync)
+}
+
+// FsSnapShotCreateCtx records the call and returns the result of FsSnapShotCreateFunc, if set.
+func (f *Client) FsSnapShotCreateCtx(ctx context.Context, fs *lsm.FileSystem, name string, sync bool) (*lsm.FileSystemSnapShot, *string, error) {
+	f.record("FsSnapShotCreate", fs, name, sync)
+	if f.FsSnapShotCreateFunc != nil {
+		return f.FsSnapShotCreateFunc(ctx, fs, name, sync)
+	}
+	return nil, nil, nil
+}
+
+// FsSnapShotDelete calls FsSnapShotDeleteCtx with a background context.
+func (f *Client) FsSnapShotDelete(fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot, sync bool) (*string, error) {
+	return f.FsSnapShotDeleteCtx(context.Background(), fs, snapShot, sync)
+}
+
+// FsSnapShotDeleteCtx records the call and returns the result of FsSnapShotDeleteFunc, if set.
+func (f *Client) FsSnapShotDeleteCtx(ctx context.Context, fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot, sync bool) (*string, error) {
+	f.record("FsSnapShotDelete", fs, snapShot, sync)
+	if f.FsSnapShotDeleteFunc != nil {
+		return f.FsSnapShotDeleteFunc(ctx, fs, snapShot, sync)
+	}
+	return nil, nil
+}
+
+// FsSnapShots calls FsSnapShotsCtx with a background context.
+func (f *Client) FsSnapShots(fs *lsm.FileSystem) ([]lsm.FileSystemSnapShot, error) {
+	return f.FsSnapShotsCtx(context.Background(), fs)
+}
+
+// FsSnapShotsCtx records the call and returns the result of FsSnapShotsFunc, if set.
+func (f *Client) FsSnapShotsCtx(ctx context.Context, fs *lsm.FileSystem) ([]lsm.FileSystemSnapShot, error) {
+	f.record("FsSnapShots", fs)
+	if f.FsSnapShotsFunc != nil {
+		return f.FsSnapShotsFunc(ctx, fs)
+	}
+	return nil, nil
+}
+
+// FsSnapShotRestore calls FsSnapShotRestoreCtx with a background context.
+func (f *Client) FsSnapShotRestore(
+	fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot, allFiles bool,
+	files []string, restoreFiles []string, sync bool) (*string, error) {
+	return f.FsSnapShotRestoreCtx(context.Background(), fs, snapShot, allFiles, files, restoreFiles, sync)
+}
+
+// FsSnapShotRestoreCtx records the call and returns the result of FsSnapShotRestoreFunc, if set.
+func (f *Client) FsSnapShotRestoreCtx(
+	ctx context.Context,
+	fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot, allFiles bool,
+	files []string, restoreFiles []string, sync bool) (*string, error) {
+	f.record("FsSnapShotRestore", fs, snapShot, allFiles, files, restoreFiles, sync)
+	if f.FsSnapShotRestoreFunc != nil {
+		return f.FsSnapShotRestoreFunc(ctx, fs, snapShot, allFiles, files, restoreFiles, sync)
+	}
+	return nil, nil
+}
+
+// FsHasChildDep calls FsHasChildDepCtx with a background context.
+func (f *Client) FsHasChildDep(fs *lsm.FileSystem, files []string) (bool, error) {
+	return f.FsHasChildDepCtx(context.Background(), fs, files)
+}
+
+// FsHasChildDepCtx records the call and returns the result of FsHasChildDepFunc, if set.
+func (f *Client) FsHasChildDepCtx(ctx context.Context, fs *lsm.FileSystem, files []string) (bool, error) {
+	f.record("FsHasChildDep", fs, files)
+	if f.FsHasChildDepFunc != nil {
+		return f.FsHasChildDepFunc(ctx, fs, files)
+	}
+	return false, nil
+}
+
+// FsChildDepRm calls FsChildDepRmCtx with a background context.
+func (f *Client) FsChildDepRm(
+	fs *lsm.FileSystem, files []string, sync bool) (*string, error) {
+	return f.FsChildDepRmCtx(context.Background(), fs, files, sync)
+}
+
+// FsChildDepRmCtx records the call and returns the result of FsChildDepRmFunc, if set.
+func (f *Client) FsChildDepRmCtx(
+	ctx context.Context,
+	fs *lsm.FileSystem, files []string, sync bool) (*string, error) {
+	f.record("FsChildDepRm", fs, files, sync)
+	if f.FsChildDepRmFunc != nil {
+		return f.FsChildDepRmFunc(ctx, fs, files, sync)
+	}
+	return nil, nil
+}
+
+// NfsExports calls NfsExportsCtx with a background context.
+func (f *Client) NfsExports(search ...string) ([]lsm.NfsExport, error) {
+	return f.NfsExportsCtx(context.Background(), search...)
+}
+
+// NfsExportsCtx records the call and returns the result of NfsExportsFunc, if set.
+func (f *Client) NfsExportsCtx(ctx context.Context, search ...string) ([]lsm.NfsExport, error) {
+	f.record("NfsExports", search)
+	if f.NfsExportsFunc != nil {
+		return f.NfsExportsFunc(ctx, search...)
+	}
+	return nil, nil
+}
+
+// NfsExportsBy calls NfsExportsCtx with the key and value of the search.
+func (f *Client) NfsExportsBy(ctx context.Context, search lsm.Search) ([]lsm.NfsExport, error) {
+	return f.NfsExportsCtx(ctx, search.Key, search.Value)
+}
+
+// NfsExportAuthTypes calls NfsExportAuthTypesCtx with a background context.
+func (f *Client) NfsExportAuthTypes() ([]string, error) {
+	return f.NfsExportAuthTypesCtx(context.Background())
+}
+
+// NfsExportAuthTypesCtx records the call and returns the result of NfsExportAuthTypesFunc, if set.
+func (f *Client) NfsExportAuthTypesCtx(ctx context.Context) ([]string, error) {
+	f.record("NfsExportAuthTypes")
+	if f.NfsExportAuthTypesFunc != nil {
+		return f.NfsExportAuthTypesFunc(ctx)
+	}
+	return nil, nil
+}
+
+// FsExport calls FsExportCtx with a background context.
+func (f *Client) FsExport(fs *lsm.FileSystem, exportPath *string,
+	access *lsm.NfsAccess, authType *string, options *string) (*lsm.NfsExport, error) {
+	return f.FsExportCtx(context.Background(), fs, exportPath, access, authType, options)
+}
+
+// FsExportCtx records the call and returns the result of FsExportFunc, if set.
+func (f *Client) FsExportCtx(ctx context.Context, fs *lsm.FileSystem, exportPath *string,
+	access *lsm.NfsAccess, authType *string, options *string) (*lsm.NfsExport, error) {
+	f.record("FsExport", fs, exportPath, access, authType, options)
+	if f.FsExportFunc != nil {
+		return f.FsExportFunc(ctx, fs, exportPath, access, authType, options)
+	}
+	return nil, nil
+}
+
+// FsUnExport calls FsUnExportCtx with a background context.
+func (f *Client) FsUnExport(export *lsm.NfsExport) error {
+	return f.FsUnExportCtx(context.Background(), export)
+}
+
+// FsUnExportCtx records the call and returns the result of FsUnExportFunc, if set.
+func (f *Client) FsUnExportCtx(ctx context.Context, export *lsm.NfsExport) error {
+	f.record("FsUnExport", export)
+	if f.FsUnExportFunc != nil {
+		return f.FsUnExportFunc(ctx, export)
+	}
+	return nil
+}
+
+// VolRaidInfo calls VolRaidInfoCtx with a background context.
+func (f *Client) VolRaidInfo(vol *lsm.Volume) (*lsm.VolumeRaidInfo, error) {
+	return f.VolRaidInfoCtx(context.Background(), vol)
+}
+
+// VolRaidInfoCtx records the call and returns the result of VolRaidInfoFunc, if set.
+func (f *Client) VolRaidInfoCtx(ctx context.Context, vol *lsm.Volume) (*lsm.VolumeRaidInfo, error) {
+	f.record("VolRaidInfo", vol)
+	if f.VolRaidInfoFunc != nil {
+		return f.VolRaidInfoFunc(ctx, vol)
+	}
+	return nil, nil
+}
+
+// PoolMemberInfo calls PoolMemberInfoCtx with a background context.
+func (f *Client) PoolMemberInfo(pool *lsm.Pool) (*lsm.PoolMemberInfo, error) {
+	return f.PoolMemberInfoCtx(context.Background(), pool)
+}
+
+// PoolMemberInfoCtx records the call and returns the result of PoolMemberInfoFunc, if set.
+func (f *Client) PoolMemberInfoCtx(ctx context.Context, pool *lsm.Pool) (*lsm.PoolMemberInfo, error) {
+	f.record("PoolMemberInfo", pool)
+	if f.PoolMemberInfoFunc != nil {
+		return f.PoolMemberInfoFunc(ctx, pool)
+	}
+	return nil, nil
+}
+
+// VolRaidCreateCapGet calls VolRaidCreateCapGetCtx with a background context.
+func (f *Client) VolRaidCreateCapGet(system *lsm.System) (*lsm.SupportedRaidCapability, error) {
+	return f.VolRaidCreateCapGetCtx(context.Background(), system)
+}
+
+// VolRaidCreateCapGetCtx records the call and returns the result of VolRaidCreateCapGetFunc, if set.
+func (f *Client) VolRaidCreateCapGetCtx(ctx context.Context, system *lsm.System) (*lsm.SupportedRaidCapability, error) {
+	f.record("VolRaidCreateCapGet", system)
+	if f.VolRaidCreateCapGetFunc != nil {
+		return f.VolRaidCreateCapGetFunc(ctx, system)
+	}
+	return nil, nil
+}
+
+// VolRaidCreate calls VolRaidCreateCtx with a background context.
+func (f *Client) VolRaidCreate(name string,
+	raidType lsm.RaidType, disks []lsm.Disk, stripSize uint32) (*lsm.Volume, error) {
+	return f.VolRaidCreateCtx(context.Background(), name, raidType, disks, stripSize)
+}
+
+// VolRaidCreateCtx records the call and returns the result of VolRaidCreateFunc, if set.
+func (f *Client) VolRaidCreateCtx(ctx context.Context, name string,
+	raidType lsm.RaidType, disks []lsm.Disk, stripSize uint32) (*lsm.Volume, error) {
+	f.record("VolRaidCreate", name, raidType, disks, stripSize)
+	if f.VolRaidCreateFunc != nil {
+		return f.VolRaidCreateFunc(ctx, name, raidType, disks, stripSize)
+	}
+	return nil, nil
+}
+
+// Batteries calls BatteriesCtx with a background context.
+func (f *Client) Batteries() ([]lsm.Battery, error) {
+	return f.BatteriesCtx(context.Background())
+}
+
+// BatteriesCtx records the call and returns the result of BatteriesFunc, if set.
+func (f *Client) BatteriesCtx(ctx context.Context) ([]lsm.Battery, error) {
+	f.record("Batteries")
+	if f.BatteriesFunc != nil {
+		return f.BatteriesFunc(ctx)
+	}
+	return nil, nil
+}
+
+// SysReadCachePctSet calls SysReadCachePctSetCtx with a background context.
+func (f *Client) SysReadCachePctSet(system *lsm.System, readPercent uint32) error {
+	return f.SysReadCachePctSetCtx(context.Background(), system, readPercent)
+}
+
+// SysReadCachePctSetCtx records the call and returns the result of SysReadCachePctSetFunc, if set.
+func (f *Client) SysReadCachePctSetCtx(ctx context.Context, system *lsm.System, readPercent uint32) error {
+	f.record("SysReadCachePctSet", system, readPercent)
+	if f.SysReadCachePctSetFunc != nil {
+		return f.SysReadCachePctSetFunc(ctx, system, readPercent)
+	}
+	return nil
+}
+
+// VolCacheInfo calls VolCacheInfoCtx with a background context.
+func (f *Client) VolCacheInfo(volume *lsm.Volume) (*lsm.VolumeCacheInfo, error) {
+	return f.VolCacheInfoCtx(context.Background(), volume)
+}
+
+// VolCacheInfoCtx records the call and returns the result of VolCacheInfoFunc, if set.
+func (f *Client) VolCacheInfoCtx(ctx context.Context, volume *lsm.Volume) (*lsm.VolumeCacheInfo, error) {
+	f.record("VolCacheInfo", volume)
+	if f.VolCacheInfoFunc != nil {
+		return f.VolCacheInfoFunc(ctx, volume)
+	}
+	return nil, nil
+}
+
+// VolPhyDiskCacheSet calls VolPhyDiskCacheSetCtx with a background context.
+func (f *Client) VolPhyDiskCacheSet(volume *lsm.Volume, pdc lsm.PhysicalDiskCache) error {
+	return f.VolPhyDiskCacheSetCtx(context.Background(), volume, pdc)
+}
+
+// VolPhyDiskCacheSetCtx records the call and returns the result of VolPhyDiskCacheSetFunc, if set.
+func (f *Client) VolPhyDiskCacheSetCtx(ctx context.Context, volume *lsm.Volume, pdc lsm.PhysicalDiskCache) error {
+	f.record("VolPhyDiskCacheSet", volume, pdc)
+	if f.VolPhyDiskCacheSetFunc != nil {
+		return f.VolPhyDiskCacheSetFunc(ctx, volume, pdc)
+	}
+	return nil
+}
+
+// VolWriteCacheSet calls VolWriteCacheSetCtx with a background context.
+func (f *Client) VolWriteCacheSet(volume *lsm.Volume, wcp lsm.WriteCachePolicy) error {
+	return f.VolWriteCacheSetCtx(context.Background(), volume, wcp)
+}
+
+// VolWriteCacheSetCtx records the call and returns the result of VolWriteCacheSetFunc, if set.
+func (f *Client) VolWriteCacheSetCtx(ctx context.Context, volume *lsm.Volume, wcp lsm.WriteCachePolicy) error {
+	f.record("VolWriteCacheSet", volume, wcp)
+	if f.VolWriteCacheSetFunc != nil {
+		return f.VolWriteCacheSetFunc(ctx, volume, wcp)
+	}
+	return nil
+}
+
+// VolReadCacheSet calls VolReadCacheSetCtx with a background context.
+func (f *Client) VolReadCacheSet(volume *lsm.Volume, rcp lsm.ReadCachePolicy) error {
+	return f.VolReadCacheSetCtx(context.Background(), volume, rcp)
+}
+
+// VolReadCacheSetCtx records the call and returns the result of VolReadCacheSetFunc, if set.
+func (f *Client) VolReadCacheSetCtx(ctx context.Context, volume *lsm.Volume, rcp lsm.ReadCachePolicy) error {
+	f.record("VolReadCacheSet", volume, rcp)
+	if f.VolReadCacheSetFunc != nil {
+		return f.VolReadCacheSetFunc(ctx, volume, rcp)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: 0BSD
+
+package fake
+
+import (
+	"context"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+)
+
+func volumeJob(c lsm.ManagementClient, vol *lsm.Volume, jobID *string, err error) (*lsm.Volume, *lsm.Job, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	if vol == nil {
+		vol = &lsm.Volume{}
+	}
+	return vol, lsm.AsyncJob(c, jobID, vol), nil
+}
+
+func fsJob(c lsm.ManagementClient, fs *lsm.FileSystem, jobID *string, err error) (*lsm.FileSystem, *lsm.Job, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	if fs == nil {
+		fs = &lsm.FileSystem{}
+	}
+	return fs, lsm.AsyncJob(c, jobID, fs), nil
+}
+
+func noneJob(c lsm.ManagementClient, jobID *string, err error) (*lsm.Job, error) {
+	if err != nil {
+		return nil, err
+	}
+	return lsm.AsyncJob(c, jobID, nil), nil
+}
+
+// VolumeCreateJob calls VolumeCreateCtx with sync false and returns a Job using f.
+func (f *Client) VolumeCreateJob(
+	ctx context.Context,
+	pool *lsm.Pool,
+	volumeName string,
+	size uint64,
+	provisioning lsm.VolumeProvisionType) (*lsm.Volume, *lsm.Job, error) {
+	vol, jobID, err := f.VolumeCreateCtx(ctx, pool, volumeName, size, provisioning, false)
+	return volumeJob(f, vol, jobID, err)
+}
+
+// VolumeDeleteJob calls VolumeDeleteCtx with sync false and returns a Job using f.
+func (f *Client) VolumeDeleteJob(ctx context.Context, vol *lsm.Volume) (*lsm.Job, error) {
+	jobID, err := f.VolumeDeleteCtx(ctx, vol, false)
+	return noneJob(f, jobID, err)
+}
+
+// VolumeResizeJob calls VolumeResizeCtx with sync false and returns a Job using f.
+func (f *Client) VolumeResizeJob(ctx context.Context, vol *lsm.Volume, newSizeBytes uint64) (*lsm.Volume, *lsm.Job, error) {
+	resized, jobID, err := f.VolumeResizeCtx(ctx, vol, newSizeBytes, false)
+	return volumeJob(f, resized, jobID, err)
+}
+
+// VolumeReplicateJob calls VolumeReplicateCtx with sync false and returns a Job using f.
+func (f *Client) VolumeReplicateJob(
+	ctx context.Context,
+	optionalPool *lsm.Pool, repType lsm.VolumeReplicateType, sourceVolume *lsm.Volume, name string) (*lsm.Volume, *lsm.Job, error) {
+	vol, jobID, err := f.VolumeReplicateCtx(ctx, optionalPool, repType, sourceVolume, name, false)
+	return volumeJob(f, vol, jobID, err)
+}
+
+// VolumeReplicateRangeJob calls VolumeReplicateRangeCtx with sync false and returns a Job using f.
+func (f *Client) VolumeReplicateRangeJob(
+	ctx context.Context,
+	repType lsm.VolumeReplicateType, srcVol *lsm.Volume, dstVol *lsm.Volume,
+	ranges []lsm.BlockRange) (*lsm.Job, error) {
+	jobID, err := f.VolumeReplicateRangeCtx(ctx, repType, srcVol, dstVol, ranges, false)
+	return noneJob(f, jobID, err)
+}
+
+// VolChildDepRmJob calls VolChildDepRmCtx with sync false and returns a Job using f.
+func (f *Client) VolChildDepRmJob(ctx context.Context, vol *lsm.Volume) (*lsm.Job, error) {
+	jobID, err := f.VolChildDepRmCtx(ctx, vol, false)
+	return noneJob(f, jobID, err)
+}
+
+// FsCreateJob calls FsCreateCtx with sync false and returns a Job using f.
+func (f *Client) FsCreateJob(ctx context.Context, pool *lsm.Pool, name string, size uint64) (*lsm.FileSystem, *lsm.Job, error) {
+	fs, jobID, err := f.FsCreateCtx(ctx, pool, name, size, false)
+	return fsJob(f, fs, jobID, err)
+}
+
+// FsResizeJob calls FsResizeCtx with sync false and returns a Job using f.
+func (f *Client) FsResizeJob(ctx context.Context, fs *lsm.FileSystem, newSizeBytes uint64) (*lsm.FileSystem, *lsm.Job, error) {
+	resized, jobID, err := f.FsResizeCtx(ctx, fs, newSizeBytes, false)
+	return fsJob(f, resized, jobID, err)
+}
+
+// FsDeleteJob calls FsDeleteCtx with sync false and returns a Job using f.
+func (f *Client) FsDeleteJob(ctx context.Context, fs *lsm.FileSystem) (*lsm.Job, error) {
+	jobID, err := f.FsDeleteCtx(ctx, fs, false)
+	return noneJob(f, jobID, err)
+}
+
+// FsCloneJob calls FsCloneCtx with sync false and returns a Job using f.
+func (f *Client) FsCloneJob(
+	ctx context.Context,
+	srcFs *lsm.FileSystem,
+	destName string,
+	optionalSnapShot *lsm.FileSystemSnapShot) (*lsm.FileSystem, *lsm.Job, error) {
+	fs, jobID, err := f.FsCloneCtx(ctx, srcFs, destName, optionalSnapShot, false)
+	return fsJob(f, fs, jobID, err)
+}
+
+// FsFileCloneJob calls FsFileCloneCtx with sync false and returns a Job using f.
+func (f *Client) FsFileCloneJob(
+	ctx context.Context,
+	fs *lsm.FileSystem,
+	srcFileName string,
+	dstFileName string,
+	optionalSnapShot *lsm.FileSystemSnapShot) (*lsm.Job, error) {
+	jobID, err := f.FsFileCloneCtx(ctx, fs, srcFileName, dstFileName, optionalSnapShot, false)
+	return noneJob(f, jobID, err)
+}
+
+// FsSnapShotCreateJob calls FsSnapShotCreateCtx with sync false and returns a Job using f.
+func (f *Client) FsSnapShotCreateJob(ctx context.Context, fs *lsm.FileSystem, name string) (*lsm.FileSystemSnapShot, *lsm.Job, error) {
+	ss, jobID, err := f.FsSnapShotCreateCtx(ctx, fs, name, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ss == nil {
+		ss = &lsm.FileSystemSnapShot{}
+	}
+	return ss, lsm.AsyncJob(f, jobID, ss), nil
+}
+
+// FsSnapShotDeleteJob calls FsSnapShotDeleteCtx with sync false and returns a Job using f.
+func (f *Client) FsSnapShotDeleteJob(ctx context.Context, fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot) (*lsm.Job, error) {
+	jobID, err := f.FsSnapShotDeleteCtx(ctx, fs, snapShot, false)
+	return noneJob(f, jobID, err)
+}
+
+// FsSnapShotRestoreJob calls FsSnapShotRestoreCtx with sync false and returns a Job using f.
+func (f *Client) FsSnapShotRestoreJob(
+	ctx context.Context,
+	fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot, allFiles bool,
+	files []string, restoreFiles []string) (*lsm.Job, error) {
+	jobID, err := f.FsSnapShotRestoreCtx(ctx, fs, snapShot, allFiles, files, restoreFiles, false)
+	return noneJob(f, jobID, err)
+}
+
+// FsChildDepRmJob calls FsChildDepRmCtx with sync false and returns a Job using f.
+func (f *Client) FsChildDepRmJob(ctx context.Context, fs *lsm.FileSystem, files []string) (*lsm.Job, error) {
+	jobID, err := f.FsChildDepRmCtx(ctx, fs, files, false)
+	return noneJob(f, jobID, err)
+}
+
+// VolumeCreateSize calls VolumeCreateJob with the size as is.
+func (f *Client) VolumeCreateSize(ctx context.Context, pool *lsm.Pool, volumeName string, size lsm.Size,
+	provisioning lsm.VolumeProvisionType) (*lsm.Volume, *lsm.Job, error) {
+	return f.VolumeCreateJob(ctx, pool, volumeName, uint64(size), provisioning)
+}
+
+// VolumeResizeSize calls VolumeResizeJob with the size as is.
+func (f *Client) VolumeResizeSize(ctx context.Context, vol *lsm.Volume, newSize lsm.Size) (*lsm.Volume, *lsm.Job, error) {
+	return f.VolumeResizeJob(ctx, vol, uint64(newSize))
+}
+
+// FsCreateSize calls FsCreateJob.
+func (f *Client) FsCreateSize(ctx context.Context, pool *lsm.Pool, name string, size lsm.Size) (*lsm.FileSystem, *lsm.Job, error) {
+	return f.FsCreateJob(ctx, pool, name, uint64(size))
+}
+
+// FsResizeSize calls FsResizeJob.
+func (f *Client) FsResizeSize(ctx context.Context, fs *lsm.FileSystem, newSize lsm.Size) (*lsm.FileSystem, *lsm.Job, error) {
+	return f.FsResizeJob(ctx, fs, uint64(newSize))
+}
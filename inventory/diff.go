@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: 0BSD
+
+package inventory
+
+import (
+	"fmt"
+	"reflect"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+)
+
+// Kind is the kind of item an event is about.
+type Kind string
+
+const (
+	// KindSystem events carry *lsm.System
+	KindSystem Kind = "system"
+
+	// KindPool events carry *lsm.Pool
+	KindPool Kind = "pool"
+
+	// KindVolume events carry *lsm.Volume
+	KindVolume Kind = "volume"
+
+	// KindDisk events carry *lsm.Disk
+	KindDisk Kind = "disk"
+
+	// KindFileSystem events carry *lsm.FileSystem
+	KindFileSystem Kind = "fs"
+
+	// KindNfsExport events carry *lsm.NfsExport
+	KindNfsExport Kind = "export"
+
+	// KindAccessGroup events carry *lsm.AccessGroup
+	KindAccessGroup Kind = "access_group"
+
+	// KindTargetPort events carry *lsm.TargetPort
+	KindTargetPort Kind = "target_port"
+
+	// KindBattery events carry *lsm.Battery
+	KindBattery Kind = "battery"
+)
+
+// Change is what happened to the item.
+type Change int
+
+const (
+	// Added item wasn't in the previous snapshot
+	Added Change = iota + 1
+
+	// Removed item isn't in the current snapshot
+	Removed
+
+	// Changed item has properties other than its status changed
+	Changed
+
+	// StatusChanged item has status bits raised or cleared, other
+	// properties may have changed too
+	StatusChanged
+)
+
+var changeNames = map[Change]string{
+	Added:         "added",
+	Removed:       "removed",
+	Changed:       "changed",
+	StatusChanged: "status changed",
+}
+
+func (c Change) String() string {
+	if name, ok := changeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Change(%d)", int(c))
+}
+
+// Event is a difference between two snapshots.
+type Event struct {
+	Change Change
+	Kind   Kind
+	ID     string
+
+	// Old is the item in the previous snapshot, nil when Added
+	Old interface{}
+
+	// New is the item in the current snapshot, nil when Removed
+	New interface{}
+
+	// Raised and Cleared are the status bits set and unset since the
+	// previous snapshot, for the items with a status: systems
+	// (lsm.SystemStatusType), pools (lsm.PoolStatusType), disks
+	// (lsm.DiskStatusType) and batteries (lsm.BatteryStatus)
+	Raised  uint64
+	Cleared uint64
+}
+
+func (e Event) String() string {
+	s := fmt.Sprintf("%s %s %s", e.Kind, e.ID, e.Change)
+	if e.Raised != 0 {
+		s += fmt.Sprintf(", raised %s", statusString(e.Kind, e.Raised))
+	}
+	if e.Cleared != 0 {
+		s += fmt.Sprintf(", cleared %s", statusString(e.Kind, e.Cleared))
+	}
+	return s
+}
+
+// statusString names the status bits of the kind.
+func statusString(kind Kind, bits uint64) string {
+	switch kind {
+	case KindSystem:
+		return lsm.SystemStatusType(bits).String()
+	case KindPool:
+		return lsm.PoolStatusType(bits).String()
+	case KindDisk:
+		return lsm.DiskStatusType(bits).String()
+	case KindBattery:
+		return lsm.BatteryStatus(bits).String()
+	}
+	return fmt.Sprintf("%#x", bits)
+}
+
+// items returns the items of each kind by ID, in the order events are
+// reported.
+func (inv *Inventory) items() []struct {
+	kind Kind
+	byID interface{}
+} {
+	return []struct {
+		kind Kind
+		byID interface{}
+	}{
+		{KindSystem, inv.Systems},
+		{KindPool, inv.Pools},
+		{KindDisk, inv.Disks},
+		{KindVolume, inv.Volumes},
+		{KindFileSystem, inv.FileSystems},
+		{KindNfsExport, inv.NfsExports},
+		{KindAccessGroup, inv.AccessGroups},
+		{KindTargetPort, inv.TargetPorts},
+		{KindBattery, inv.Batteries},
+	}
+}
+
+// Diff returns the differences from one snapshot to the next, by kind
+// then ID.  Masking and pool members aren't compared.
+func Diff(from *Inventory, to *Inventory) []Event {
+	var events []Event
+	fromItems := from.items()
+	for i, items := range to.items() {
+		events = append(events, diffItems(items.kind, fromItems[i].byID, items.byID)...)
+	}
+	return events
+}
+
+// diffItems compares the maps of items of the kind, indexed by ID.
+func diffItems(kind Kind, from interface{}, to interface{}) []Event {
+	var events []Event
+	fromMap := reflect.ValueOf(from)
+	toMap := reflect.ValueOf(to)
+
+	for _, id := range sortedIDs(from) {
+		if !toMap.MapIndex(reflect.ValueOf(id)).IsValid() {
+			events = append(events, Event{Change: Removed, Kind: kind, ID: id,
+				Old: fromMap.MapIndex(reflect.ValueOf(id)).Interface()})
+		}
+	}
+
+	for _, id := range sortedIDs(to) {
+		n := toMap.MapIndex(reflect.ValueOf(id))
+		o := fromMap.MapIndex(reflect.ValueOf(id))
+		if !o.IsValid() {
+			events = append(events, Event{Change: Added, Kind: kind, ID: id, New: n.Interface()})
+			continue
+		}
+		if reflect.DeepEqual(o.Interface(), n.Interface()) {
+			continue
+		}
+
+		e := Event{Change: Changed, Kind: kind, ID: id, Old: o.Interface(), New: n.Interface()}
+		if oldStatus := o.Elem().FieldByName("Status"); oldStatus.IsValid() {
+			before := oldStatus.Uint()
+			after := n.Elem().FieldByName("Status").Uint()
+			e.Raised = after &^ before
+			e.Cleared = before &^ after
+			if before != after {
+				e.Change = StatusChanged
+			}
+		}
+		events = append(events, e)
+	}
+	return events
+}
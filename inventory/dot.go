@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: 0BSD
+
+package inventory
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the topology as a Graphviz digraph, eg. for
+// "dot -Tsvg".  Node IDs are prefixed by kind as items of different kinds
+// may share an ID.
+func (inv *Inventory) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph inventory {")
+	fmt.Fprintln(b, "\trankdir=LR;")
+
+	node := func(kind string, id string, label string, shape string) {
+		fmt.Fprintf(b, "\t%s [label=%s, shape=%s];\n",
+			quote(kind+":"+id), quote(kind+"\n"+label), shape)
+	}
+	edge := func(fromKind string, from string, toKind string, to string, label string) {
+		fmt.Fprintf(b, "\t%s -> %s", quote(fromKind+":"+from), quote(toKind+":"+to))
+		if len(label) > 0 {
+			fmt.Fprintf(b, " [label=%s]", quote(label))
+		}
+		fmt.Fprintln(b, ";")
+	}
+
+	for _, id := range sortedIDs(inv.Systems) {
+		s := inv.Systems[id]
+		node("system", id, fmt.Sprintf("%s\n%s", s.Name, s.Status), "box3d")
+	}
+	for _, id := range sortedIDs(inv.Pools) {
+		p := inv.Pools[id]
+		node("pool", id, fmt.Sprintf("%s\n%s", p.Name, p.Status), "cylinder")
+		edge("system", p.SystemID, "pool", id, "")
+	}
+	for _, id := range sortedIDs(inv.PoolMembers) {
+		m := inv.PoolMembers[id]
+		kind := strings.ToLower(m.Member.String())
+		for _, member := range m.IDs {
+			edge(kind, member, "pool", id, m.Raid.String())
+		}
+	}
+	for _, id := range sortedIDs(inv.Disks) {
+		d := inv.Disks[id]
+		node("disk", id, fmt.Sprintf("%s\n%s", d.Name, d.Status), "circle")
+	}
+	for _, id := range sortedIDs(inv.Volumes) {
+		v := inv.Volumes[id]
+		node("volume", id, v.Name, "box")
+		edge("pool", v.PoolID, "volume", id, "")
+		for _, ag := range inv.Masking[id] {
+			edge("volume", id, "access_group", ag, "masked")
+		}
+	}
+	for _, id := range sortedIDs(inv.FileSystems) {
+		fs := inv.FileSystems[id]
+		node("fs", id, fs.Name, "folder")
+		edge("pool", fs.PoolID, "fs", id, "")
+	}
+	for _, id := range sortedIDs(inv.NfsExports) {
+		e := inv.NfsExports[id]
+		node("export", id, e.ExportPath, "note")
+		edge("fs", e.FsID, "export", id, "")
+	}
+
+	initiators := map[string]bool{}
+	for _, id := range sortedIDs(inv.AccessGroups) {
+		ag := inv.AccessGroups[id]
+		node("access_group", id, ag.Name, "hexagon")
+		for _, init := range ag.InitIDs {
+			if !initiators[init] {
+				initiators[init] = true
+				node("initiator", init, ag.InitiatorType.String(), "ellipse")
+			}
+			edge("access_group", id, "initiator", init, "")
+		}
+	}
+
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote returns s as a DOT string, new lines break the label.
+func quote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
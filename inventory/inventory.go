@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package inventory collects everything a plugin manages in one snapshot,
+// indexed by ID and linked, so consumers don't have to stitch the listings
+// together themselves.
+//
+// Systems hold pools, pools hold volumes and file systems, file systems are
+// exported over NFS, volumes are masked to access groups which hold
+// initiators.  The Inventory encodes to JSON as is and to Graphviz DOT with
+// WriteDOT.
+package inventory
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Inventory is a snapshot of a plugin, the items are indexed by ID.
+type Inventory struct {
+	Taken        time.Time                   `json:"taken"`
+	Systems      map[string]*lsm.System      `json:"systems"`
+	Pools        map[string]*lsm.Pool        `json:"pools"`
+	Volumes      map[string]*lsm.Volume      `json:"volumes"`
+	Disks        map[string]*lsm.Disk        `json:"disks"`
+	FileSystems  map[string]*lsm.FileSystem  `json:"file_systems"`
+	NfsExports   map[string]*lsm.NfsExport   `json:"nfs_exports"`
+	AccessGroups map[string]*lsm.AccessGroup `json:"access_groups"`
+	TargetPorts  map[string]*lsm.TargetPort  `json:"target_ports"`
+	Batteries    map[string]*lsm.Battery     `json:"batteries"`
+
+	// Masking holds the IDs of the access groups each volume is masked to
+	Masking map[string][]string `json:"masking"`
+
+	// PoolMembers holds what the pools are made of, for plugins telling
+	PoolMembers map[string]*PoolMembers `json:"pool_members"`
+}
+
+// PoolMembers are the disks or pools a pool is made of.
+type PoolMembers struct {
+	Raid   lsm.RaidType   `json:"raid_type"`
+	Member lsm.MemberType `json:"member_type"`
+	IDs    []string       `json:"member_ids"`
+}
+
+// Collect takes an inventory through the client.  Listings the plugin
+// doesn't support are left empty.
+func Collect(ctx context.Context, client lsm.StorageClient) (*Inventory, error) {
+	inv := &Inventory{
+		Taken:        time.Now(),
+		Systems:      make(map[string]*lsm.System),
+		Pools:        make(map[string]*lsm.Pool),
+		Volumes:      make(map[string]*lsm.Volume),
+		Disks:        make(map[string]*lsm.Disk),
+		FileSystems:  make(map[string]*lsm.FileSystem),
+		NfsExports:   make(map[string]*lsm.NfsExport),
+		AccessGroups: make(map[string]*lsm.AccessGroup),
+		TargetPorts:  make(map[string]*lsm.TargetPort),
+		Batteries:    make(map[string]*lsm.Battery),
+		Masking:      make(map[string][]string),
+		PoolMembers:  make(map[string]*PoolMembers),
+	}
+
+	systems, err := client.SystemsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range systems {
+		inv.Systems[systems[i].ID] = &systems[i]
+	}
+
+	pools, err := client.PoolsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pools {
+		inv.Pools[pools[i].ID] = &pools[i]
+	}
+
+	volumes, err := client.VolumesCtx(ctx)
+	if supported(err) != nil {
+		return nil, err
+	}
+	for i := range volumes {
+		inv.Volumes[volumes[i].ID] = &volumes[i]
+	}
+
+	disks, err := client.DisksCtx(ctx)
+	if supported(err) != nil {
+		return nil, err
+	}
+	for i := range disks {
+		inv.Disks[disks[i].ID] = &disks[i]
+	}
+
+	fileSystems, err := client.FileSystemsCtx(ctx)
+	if supported(err) != nil {
+		return nil, err
+	}
+	for i := range fileSystems {
+		inv.FileSystems[fileSystems[i].ID] = &fileSystems[i]
+	}
+
+	exports, err := client.NfsExportsCtx(ctx)
+	if supported(err) != nil {
+		return nil, err
+	}
+	for i := range exports {
+		inv.NfsExports[exports[i].ID] = &exports[i]
+	}
+
+	groups, err := client.AccessGroupsCtx(ctx)
+	if supported(err) != nil {
+		return nil, err
+	}
+	for i := range groups {
+		inv.AccessGroups[groups[i].ID] = &groups[i]
+	}
+
+	ports, err := client.TargetPortsCtx(ctx)
+	if supported(err) != nil {
+		return nil, err
+	}
+	for i := range ports {
+		inv.TargetPorts[ports[i].ID] = &ports[i]
+	}
+
+	batteries, err := client.BatteriesCtx(ctx)
+	if supported(err) != nil {
+		return nil, err
+	}
+	for i := range batteries {
+		inv.Batteries[batteries[i].ID] = &batteries[i]
+	}
+
+	for _, ag := range groups {
+		masked, err := client.VolsMaskedToAgCtx(ctx, inv.AccessGroups[ag.ID])
+		if supported(err) != nil {
+			return nil, err
+		}
+		for _, v := range masked {
+			inv.Masking[v.ID] = append(inv.Masking[v.ID], ag.ID)
+		}
+	}
+	for id := range inv.Masking {
+		sort.Strings(inv.Masking[id])
+	}
+
+	for _, p := range pools {
+		info, err := client.PoolMemberInfoCtx(ctx, inv.Pools[p.ID])
+		if supported(err) != nil {
+			return nil, err
+		}
+		if info != nil {
+			inv.PoolMembers[p.ID] = &PoolMembers{Raid: info.Raid, Member: info.Member, IDs: info.ID}
+		}
+	}
+	return inv, nil
+}
+
+// supported returns err unless it says the call isn't supported.
+func supported(err error) error {
+	if code, ok := errors.CodeOf(err); ok && code == errors.NoSupport {
+		return nil
+	}
+	return err
+}
+
+// PoolsOf returns the pools of the system.
+func (inv *Inventory) PoolsOf(systemID string) []*lsm.Pool {
+	var pools []*lsm.Pool
+	for _, id := range sortedIDs(inv.Pools) {
+		if inv.Pools[id].SystemID == systemID {
+			pools = append(pools, inv.Pools[id])
+		}
+	}
+	return pools
+}
+
+// VolumesOf returns the volumes of the pool.
+func (inv *Inventory) VolumesOf(poolID string) []*lsm.Volume {
+	var volumes []*lsm.Volume
+	for _, id := range sortedIDs(inv.Volumes) {
+		if inv.Volumes[id].PoolID == poolID {
+			volumes = append(volumes, inv.Volumes[id])
+		}
+	}
+	return volumes
+}
+
+// FileSystemsOf returns the file systems of the pool.
+func (inv *Inventory) FileSystemsOf(poolID string) []*lsm.FileSystem {
+	var fileSystems []*lsm.FileSystem
+	for _, id := range sortedIDs(inv.FileSystems) {
+		if inv.FileSystems[id].PoolID == poolID {
+			fileSystems = append(fileSystems, inv.FileSystems[id])
+		}
+	}
+	return fileSystems
+}
+
+// ExportsOf returns the NFS exports of the file system.
+func (inv *Inventory) ExportsOf(fsID string) []*lsm.NfsExport {
+	var exports []*lsm.NfsExport
+	for _, id := range sortedIDs(inv.NfsExports) {
+		if inv.NfsExports[id].FsID == fsID {
+			exports = append(exports, inv.NfsExports[id])
+		}
+	}
+	return exports
+}
+
+// AccessGroupsOf returns the access groups the volume is masked to.
+func (inv *Inventory) AccessGroupsOf(volumeID string) []*lsm.AccessGroup {
+	var groups []*lsm.AccessGroup
+	for _, id := range inv.Masking[volumeID] {
+		if ag, ok := inv.AccessGroups[id]; ok {
+			groups = append(groups, ag)
+		}
+	}
+	return groups
+}
+
+// MaskedTo returns the volumes masked to the access group.
+func (inv *Inventory) MaskedTo(agID string) []*lsm.Volume {
+	var volumes []*lsm.Volume
+	for _, id := range sortedIDs(inv.Masking) {
+		for _, ag := range inv.Masking[id] {
+			if v, ok := inv.Volumes[id]; ok && ag == agID {
+				volumes = append(volumes, v)
+			}
+		}
+	}
+	return volumes
+}
+
+// InitiatorGroups returns the access groups holding the initiator.
+func (inv *Inventory) InitiatorGroups(initID string) []*lsm.AccessGroup {
+	var groups []*lsm.AccessGroup
+	for _, id := range sortedIDs(inv.AccessGroups) {
+		for _, i := range inv.AccessGroups[id].InitIDs {
+			if i == initID {
+				groups = append(groups, inv.AccessGroups[id])
+			}
+		}
+	}
+	return groups
+}
+
+// sortedIDs returns the keys of the map m, indexed by ID, in order.
+func sortedIDs(m interface{}) []string {
+	var ids []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		ids = append(ids, k.String())
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: 0BSD
+
+package inventory
+
+import (
+	"context"
+	"time"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Watcher takes an inventory periodically and reports what changed since
+// the previous one as events, eg. a pool going degraded, a disk failing or a
+// volume created by someone else.
+type Watcher struct {
+	client   lsm.StorageClient
+	interval time.Duration
+	events   chan Event
+	last     *Inventory
+}
+
+// NewWatcher returns a watcher taking an inventory through the client every
+// interval.
+func NewWatcher(client lsm.StorageClient, interval time.Duration) *Watcher {
+	return &Watcher{client: client, interval: interval, events: make(chan Event)}
+}
+
+// Events returns the channel the events are sent on by Run, it is closed
+// when Run returns.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Last returns the latest inventory taken, nil before the first.
+func (w *Watcher) Last() *Inventory {
+	return w.last
+}
+
+// Poll takes an inventory and returns the differences from the previous one.
+// The first inventory is the baseline, nothing is reported for it.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	inv, err := Collect(ctx, w.client)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	if w.last != nil {
+		events = Diff(w.last, inv)
+	}
+	w.last = inv
+	return events, nil
+}
+
+// Run polls every interval sending the events on the Events channel until
+// the context is done or an inventory fails, returning why.  An inventory
+// failing with an error which may clear up, see errors.IsRetryable, only
+// skips the tick.  A connection to the plugin that failed stays failed, so
+// TransPortComunication ends Run.  The first inventory is the baseline unless
+// Poll was called before.
+// Run may be called once, Poll and Last are not to be called while it runs.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := w.Poll(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && !transient(err) {
+			return err
+		}
+		for _, e := range events {
+			select {
+			case w.events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// transient reports whether the next inventory may succeed where this one
+// failed with err.
+func transient(err error) bool {
+	code, _ := errors.CodeOf(err)
+	return errors.IsRetryable(err) && code != errors.TransPortComunication
+}
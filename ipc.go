@@ -3,11 +3,17 @@
 package libstoragemgmt
 
 import (
+	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	errors "github.com/libstorage/libstoragemgmt-golang/errors"
 )
@@ -18,13 +24,48 @@ const (
 	headerLen      = 10
 )
 
+// legacyID is the id used by plugins which do not echo back the id of the
+// request they are responding to.
+const legacyID = 100
+
+// writeDeadliner is implemented by connections whose writes can be
+// interrupted, eg. net.Conn
+type writeDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// transPort frames requests and responses, a 10 digit length header followed
+// by the JSON, over any stream connection.
 type transPort struct {
-	uds   net.Conn
+	conn  io.ReadWriteCloser
 	debug bool
+
+	// Client side state, a connection may be shared by many goroutines.  Requests
+	// are written whole under writeLock and the reply is matched up by a single
+	// reader goroutine.  Plugins process requests in the order they are received,
+	// so pending is a FIFO of callers waiting for a reply.
+	lastID     uint32
+	writeLock  sync.Mutex
+	lock       sync.Mutex
+	pending    []*pendingCall
+	err        error
+	readerOnce sync.Once
 }
 
-func newTransport(pluginUdsPath string, checkErrors bool) (*transPort, error) {
-	var c, cError = net.Dial("unix", pluginUdsPath)
+type pendingCall struct {
+	id    int
+	cmd   string
+	reply chan callReply
+}
+
+type callReply struct {
+	msg *responseMsg
+	err error
+}
+
+func newTransport(ctx context.Context, pluginUdsPath string, checkErrors bool) (*transPort, error) {
+	var d net.Dialer
+	var c, cError = d.DialContext(ctx, "unix", pluginUdsPath)
 	if cError != nil {
 
 		// checkDaemonExists calls newTransport, to prevent unbounded recursion we
@@ -44,12 +85,17 @@ func newTransport(pluginUdsPath string, checkErrors bool) (*transPort, error) {
 		return nil, cError
 	}
 
+	return newTransPortConn(c), nil
+}
+
+// newTransPortConn returns a client side transPort using conn.
+func newTransPortConn(conn io.ReadWriteCloser) *transPort {
 	debug := len(os.Getenv("LSM_GO_DEBUG")) > 0
-	return &transPort{uds: c, debug: debug}, nil
+	return &transPort{conn: conn, debug: debug}
 }
 
-func (t transPort) close() {
-	t.uds.Close()
+func (t *transPort) close() {
+	t.conn.Close()
 }
 
 type responseMsg struct {
@@ -68,12 +114,126 @@ func (r *requestMsg) String() string {
 	return fmt.Sprintf("ID: %d, Method: %s, Parms: %s", r.ID, r.Method, string(r.Params))
 }
 
-func (t *transPort) invoke(cmd string, args map[string]interface{}, result interface{}) error {
+func (t *transPort) newID() int {
+	id := atomic.AddUint32(&t.lastID, 1)
+	if id == legacyID {
+		// Keep clear of the id used by plugins that don't echo it back
+		id = atomic.AddUint32(&t.lastID, 1)
+	}
+	return int(id & 0x7FFFFFFF)
+}
+
+// watchWrite applies the context deadline to writes on the connection and
+// arranges for a blocked write to return when the context is done.  The
+// returned function must be called once the write is complete.  Writes on
+// connections without write deadlines can't be interrupted.
+func (t *transPort) watchWrite(ctx context.Context) func() {
+	conn, ok := t.conn.(writeDeadliner)
+	if !ok {
+		return func() {}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetWriteDeadline(deadline)
+	}
+
+	if ctx.Done() == nil {
+		// Context can never be cancelled, nothing to watch for.
+		return func() {}
+	}
 
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			// Setting a deadline in the past unblocks a pending write
+			conn.SetWriteDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-exited
+		conn.SetWriteDeadline(time.Time{})
+	}
+}
+
+// fail marks the connection as unusable and errors out every waiting caller.
+func (t *transPort) fail(err error) {
+	t.lock.Lock()
+	if t.err == nil {
+		t.err = err
+	}
+	waiting := t.pending
+	t.pending = nil
+	t.lock.Unlock()
+
+	for _, call := range waiting {
+		call.reply <- callReply{err: err}
+	}
+}
+
+func (t *transPort) readReplies() {
+	for {
+		reply, replyError := t.recv()
+		if replyError != nil {
+			t.fail(&errors.LsmError{
+				Code:    errors.TransPortComunication,
+				Message: fmt.Sprintf("Error reading from unix domain socket %v\n", replyError)})
+			return
+		}
+
+		var what responseMsg
+		if replyUnmarsal := json.Unmarshal(reply, &what); replyUnmarsal != nil {
+			t.fail(&errors.LsmError{
+				Code:    errors.PluginBug,
+				Message: fmt.Sprintf("Unparsable response from plugin %v\n", replyUnmarsal)})
+			return
+		}
+
+		t.lock.Lock()
+		if len(t.pending) == 0 {
+			t.lock.Unlock()
+			t.fail(&errors.LsmError{
+				Code:    errors.PluginBug,
+				Message: fmt.Sprintf("Unsolicited response from plugin %s\n", reply)})
+			return
+		}
+		call := t.pending[0]
+		t.pending = t.pending[1:]
+		t.lock.Unlock()
+
+		if what.ID != call.id && what.ID != legacyID {
+			err := &errors.LsmError{
+				Code: errors.PluginBug,
+				Message: fmt.Sprintf("Response id %d does not match request id %d (%s)\n",
+					what.ID, call.id, call.cmd)}
+			call.reply <- callReply{err: err}
+			t.fail(err)
+			return
+		}
+
+		// Buffered, if the caller gave up waiting the reply is simply dropped.
+		call.reply <- callReply{msg: &what}
+	}
+}
+
+func (t *transPort) invoke(ctx context.Context, cmd string, args map[string]interface{}, result interface{}) error {
+
+	if ctxError := ctx.Err(); ctxError != nil {
+		return ctxError
+	}
+
+	t.readerOnce.Do(func() { go t.readReplies() })
+
+	id := t.newID()
 	args["flags"] = 0
 	msg := map[string]interface{}{
 		"method": cmd,
-		"id":     100,
+		"id":     id,
 		"params": args,
 	}
 
@@ -81,29 +241,72 @@ func (t *transPort) invoke(cmd string, args map[string]interface{}, result inter
 	if serialError != nil {
 		return &errors.LsmError{
 			Code:    errors.LibBug,
-			Message: fmt.Sprintf("Errors serializing parameters %w\n", serialError)}
+			Message: fmt.Sprintf("Errors serializing parameters %v\n", serialError)}
 	}
 
-	if sendError := t.send(string(msgSerialized)); sendError != nil {
-		return &errors.LsmError{
-			Code:    errors.TransPortComunication,
-			Message: fmt.Sprintf("Error writing to unix domain socket %w\n", sendError)}
+	call := &pendingCall{id: id, cmd: cmd, reply: make(chan callReply, 1)}
+
+	t.writeLock.Lock()
+
+	// We may have waited a while for our turn
+	if ctxError := ctx.Err(); ctxError != nil {
+		t.writeLock.Unlock()
+		return ctxError
+	}
+
+	t.lock.Lock()
+	if t.err != nil {
+		err := t.err
+		t.lock.Unlock()
+		t.writeLock.Unlock()
+		return err
 	}
+	t.pending = append(t.pending, call)
+	t.lock.Unlock()
+
+	stop := t.watchWrite(ctx)
+	written, sendError := t.write(string(msgSerialized))
+	stop()
+
+	if sendError != nil {
+		if written == 0 {
+			// Nothing went out, so no reply is coming and the stream is intact.  We
+			// hold writeLock, so we are still last in line.
+			t.lock.Lock()
+			if n := len(t.pending); n > 0 && t.pending[n-1] == call {
+				t.pending = t.pending[:n-1]
+			}
+			t.lock.Unlock()
+		} else {
+			// We sent part of the request, nothing that follows can be trusted.
+			t.fail(&errors.LsmError{
+				Code:    errors.TransPortComunication,
+				Message: fmt.Sprintf("Error writing to unix domain socket %v\n", sendError)})
+		}
+		t.writeLock.Unlock()
 
-	var reply, replyError = t.recv()
-	if replyError != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		return &errors.LsmError{
 			Code:    errors.TransPortComunication,
-			Message: fmt.Sprintf("Error reading from unix domain socket %w\n", replyError)}
+			Message: fmt.Sprintf("Error writing to unix domain socket %v\n", sendError)}
+	}
+	t.writeLock.Unlock()
+
+	var reply callReply
+	select {
+	case reply = <-call.reply:
+	case <-ctx.Done():
+		// The reply will be read and discarded when it arrives
+		return ctx.Err()
 	}
 
-	var what responseMsg
-	if replyUnmarsal := json.Unmarshal(reply, &what); replyUnmarsal != nil {
-		return &errors.LsmError{
-			Code:    errors.PluginBug,
-			Message: fmt.Sprintf("Unparsable response from plugin %w\n", replyUnmarsal)}
+	if reply.err != nil {
+		return reply.err
 	}
 
+	what := reply.msg
 	if what.Error != nil {
 		return what.Error
 	}
@@ -123,84 +326,101 @@ func (t *transPort) invoke(cmd string, args map[string]interface{}, result inter
 
 	return &errors.LsmError{
 		Code:    errors.PluginBug,
-		Message: fmt.Sprintf("Unexpected response from plugin %s\n", reply)}
-
+		Message: fmt.Sprintf("Unexpected response from plugin for (%s)\n", cmd)}
 }
 
+// readRequest returns io.EOF when the client closed the connection between
+// requests.
 func (t *transPort) readRequest() (*requestMsg, error) {
 	request, requestError := t.recv()
+	if requestError == io.EOF {
+		return nil, io.EOF
+	}
 	if requestError != nil {
 		return nil, &errors.LsmError{
 			Code:    errors.TransPortComunication,
-			Message: fmt.Sprintf("Error reading from unix domain socket %w\n", requestError)}
+			Message: fmt.Sprintf("Error reading from unix domain socket %v\n", requestError)}
 	}
 
 	var what requestMsg
 	if requestUnmarsal := json.Unmarshal(request, &what); requestUnmarsal != nil {
 		return nil, &errors.LsmError{
 			Code:    errors.TransPortInvalidArg,
-			Message: fmt.Sprintf("Unparsable request from client %w\n", requestUnmarsal)}
+			Message: fmt.Sprintf("Unparsable request from client %v\n", requestUnmarsal)}
 	}
 	return &what, nil
 }
 
-func (t *transPort) sendResponse(response interface{}) error {
+func (t *transPort) sendResponse(id int, response interface{}) error {
 	msg := map[string]interface{}{
 		"result": response,
-		"id":     100,
+		"id":     id,
 	}
 
 	var msgSerialized, serialError = json.Marshal(msg)
 	if serialError != nil {
 		return &errors.LsmError{
 			Code:    errors.PluginBug,
-			Message: fmt.Sprintf("Errors serializing response %w\n", serialError)}
+			Message: fmt.Sprintf("Errors serializing response %v\n", serialError)}
 	}
 
 	if sendError := t.send(string(msgSerialized)); sendError != nil {
 		return &errors.LsmError{
 			Code:    errors.TransPortComunication,
-			Message: fmt.Sprintf("Error writing to unix domain socket %w\n", sendError)}
+			Message: fmt.Sprintf("Error writing to unix domain socket %v\n", sendError)}
 	}
 	return nil
 }
 
-func (t *transPort) sendError(err error) error {
+func (t *transPort) sendError(id int, err error) error {
+
+	// Clients only understand LsmErrors, anything else is a bug in the plugin
+	var lsmError *errors.LsmError
+	if !stderrors.As(err, &lsmError) {
+		lsmError = &errors.LsmError{
+			Code:    errors.PluginBug,
+			Message: err.Error()}
+	}
 
-	// TODO Make this work for lsm errors and generic errors
 	msg := map[string]interface{}{
-		"error": err,
-		"id":    100,
+		"error": lsmError,
+		"id":    id,
 	}
 
 	var msgSerialized, serialError = json.Marshal(msg)
 	if serialError != nil {
 		return &errors.LsmError{
 			Code:    errors.PluginBug,
-			Message: fmt.Sprintf("Errors serializing error %w\n", serialError)}
+			Message: fmt.Sprintf("Errors serializing error %v\n", serialError)}
 	}
 
 	if sendError := t.send(string(msgSerialized)); sendError != nil {
 		return &errors.LsmError{
 			Code:    errors.TransPortComunication,
-			Message: fmt.Sprintf("Error writing to unix domain socket %w\n", sendError)}
+			Message: fmt.Sprintf("Error writing to unix domain socket %v\n", sendError)}
 	}
 	return nil
 }
 
 func (t *transPort) send(msg string) error {
+	_, err := t.write(msg)
+	return err
+}
+
+// write frames and sends msg, returning how many bytes made it out.
+func (t *transPort) write(msg string) (int, error) {
 
 	var toSend = fmt.Sprintf("%010d%s", len(msg), msg)
 	if t.debug {
 		fmt.Printf("go-send: %s\n", msg)
 	}
-	return writeExact(t.uds, []byte(toSend))
+	return writeExact(t.conn, []byte(toSend))
 }
 
 func (t *transPort) recv() ([]byte, error) {
 	hdrLenBuf := make([]byte, headerLen)
 
-	if readError := readExact(t.uds, hdrLenBuf); readError != nil {
+	if readError := readExact(t.conn, hdrLenBuf); readError != nil {
 		return make([]byte, 0), readError
 	}
 
@@ -210,7 +430,11 @@ func (t *transPort) recv() ([]byte, error) {
 	}
 
 	msgBuffer := make([]byte, msgLen)
-	readError := readExact(t.uds, msgBuffer)
+	readError := readExact(t.conn, msgBuffer)
+	if readError == io.EOF {
+		// The header came without its message
+		readError = io.ErrUnexpectedEOF
+	}
 
 	if t.debug {
 		fmt.Printf("go-recv: %s\n", string(msgBuffer))
@@ -219,7 +443,7 @@ func (t *transPort) recv() ([]byte, error) {
 	return msgBuffer, readError
 }
 
-func readExact(c net.Conn, buf []byte) error {
+func readExact(c io.Reader, buf []byte) error {
 	const tmpBufSize = 1024
 	requested := len(buf)
 	tmpBuffer := make([]byte, tmpBufSize)
@@ -233,6 +457,10 @@ func readExact(c net.Conn, buf []byte) error {
 
 		num, readError := c.Read(tmpBuffer[:remain])
 		if readError != nil {
+			if readError == io.EOF && current+num > 0 {
+				// Part of the message is missing
+				return io.ErrUnexpectedEOF
+			}
 			return readError
 		}
 
@@ -242,17 +470,17 @@ func readExact(c net.Conn, buf []byte) error {
 	return nil
 }
 
-func writeExact(c net.Conn, buf []byte) error {
+func writeExact(c io.Writer, buf []byte) (int, error) {
 	wanted := len(buf)
 	var written int
 
 	for written < wanted {
 		num, writeError := c.Write(buf[written:])
+		written += num
 		if writeError != nil {
-			return writeError
+			return written, writeError
 		}
-		written += num
 	}
 
-	return nil
+	return written, nil
 }
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Backoff controls how often the status of a job is polled.  The first poll
+// happens right away, then after Initial, each delay after that is the last
+// one multiplied by Factor and capped at Max.  A Factor of 1 or less polls
+// every Initial, a Max of 0 is no cap.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// DefaultBackoff is the Backoff jobs start out with.
+var DefaultBackoff = Backoff{Initial: 250 * time.Millisecond, Max: 2 * time.Second, Factor: 1.5}
+
+func (b Backoff) next(delay time.Duration) time.Duration {
+	if b.Factor > 1 {
+		delay = time.Duration(float64(delay) * b.Factor)
+	}
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+	return delay
+}
+
+// Job is a handle on an asynchronous operation running in the plugin.  The
+// methods of a Job are safe for concurrent use by multiple goroutines.
+type Job struct {
+	// ID as returned by the asynchronous call
+	ID string
+
+	// Backoff used while waiting, change it before calling Wait
+	Backoff Backoff
+
+	c        ManagementClient
+	result   interface{}
+	progress chan uint8
+
+	lock    sync.Mutex
+	done    bool
+	err     error
+	percent int
+}
+
+// Job returns a handle on the job with jobID.  On completion the result of
+// the job, if any, is stored in result, as with JobWait.
+func (c *ClientConnection) Job(jobID string, result interface{}) *Job {
+	return NewJob(c, jobID, result)
+}
+
+// NewJob returns a handle on the job with jobID started through c.
+func NewJob(c ManagementClient, jobID string, result interface{}) *Job {
+	return &Job{
+		ID:       jobID,
+		Backoff:  DefaultBackoff,
+		c:        c,
+		result:   result,
+		progress: make(chan uint8, 1),
+		percent:  -1,
+	}
+}
+
+// Progress returns a channel receiving the percent complete whenever it
+// changes while the job is being waited on.  A slow reader only sees the
+// latest value.  The channel is closed once the job has finished.
+func (j *Job) Progress() <-chan uint8 {
+	return j.progress
+}
+
+// Caller must hold the lock
+func (j *Job) report(percent uint8) {
+	if int(percent) == j.percent {
+		return
+	}
+	j.percent = int(percent)
+
+	select {
+	case j.progress <- percent:
+	default:
+		// Replace the value nobody has read yet
+		select {
+		case <-j.progress:
+		default:
+		}
+		j.progress <- percent
+	}
+}
+
+// Caller must hold the lock
+func (j *Job) finish(err error) error {
+	j.done = true
+	j.err = err
+	close(j.progress)
+	return err
+}
+
+// AsyncJob returns a handle on the job an asynchronous call made with sync
+// false returned, the result of the job, if any, is stored in result.  A nil
+// jobID is a call the plugin completed without a job, the handle returned has
+// an empty ID and has finished already.
+func AsyncJob(c ManagementClient, jobID *string, result interface{}) *Job {
+	if jobID != nil {
+		return NewJob(c, *jobID, result)
+	}
+
+	j := NewJob(c, "", result)
+	j.report(100)
+	j.finish(nil)
+	return j
+}
+
+// Wait polls the job until it finishes, then frees it.  If the context is
+// done first its error is returned and the job is left running, Wait may be
+// called again later.  Once the job has finished Wait returns its outcome
+// straight away.
+func (j *Job) Wait(ctx context.Context) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	delay := j.Backoff.Initial
+	for {
+		status, _, err := j.poll(ctx)
+		if err != nil || status != JobStatusInprogress {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = j.Backoff.next(delay)
+	}
+}
+
+// Status polls the job once, without waiting.  A job found finished is freed
+// as Wait does, Status and Wait return its outcome from then on.
+func (j *Job) Status(ctx context.Context) (JobStatusType, uint8, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return j.poll(ctx)
+}
+
+// poll gets the status of the job, freeing it once it has finished.  Caller
+// must hold the lock.
+func (j *Job) poll(ctx context.Context) (JobStatusType, uint8, error) {
+	if j.done {
+		if j.err != nil {
+			return JobStatusError, 0, j.err
+		}
+		return JobStatusComplete, 100, nil
+	}
+
+	status, percent, err := j.c.JobStatusCtx(ctx, j.ID, j.result)
+	if err != nil {
+		if ctx.Err() != nil {
+			return JobStatusInprogress, 0, ctx.Err()
+		}
+		// The plugin may have forgotten the job already, so that freeing
+		// it fails is of no interest
+		j.c.JobFreeCtx(ctx, j.ID)
+		return JobStatusError, 0, j.finish(err)
+	}
+
+	if status != JobStatusComplete {
+		j.report(percent)
+		return status, percent, nil
+	}
+
+	j.report(100)
+	if freeError := j.c.JobFreeCtx(ctx, j.ID); freeError != nil {
+		if ctx.Err() != nil {
+			// Still there to be waited on again
+			return JobStatusInprogress, 100, ctx.Err()
+		}
+		return JobStatusError, 0, j.finish(&errors.LsmError{
+			Code: errors.PluginBug,
+			Message: fmt.Sprintf(
+				"We successfully waited for job %s, but got an error freeing it: %s", j.ID, freeError)})
+	}
+	return JobStatusComplete, 100, j.finish(nil)
+}
+
+// WaitAll waits for all the jobs to finish, returning the first error any of
+// them finished with.
+func WaitAll(ctx context.Context, jobs ...*Job) error {
+	errs := make([]error, len(jobs))
+
+	var wg sync.WaitGroup
+	for i, j := range jobs {
+		wg.Add(1)
+		go func(i int, j *Job) {
+			defer wg.Done()
+			errs[i] = j.Wait(ctx)
+		}(i, j)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitAny waits for one of the jobs to finish, returning its index in jobs and
+// the error it finished with.  The other jobs are left for the caller to wait
+// on.  If the context is done first the index is -1.
+func WaitAny(ctx context.Context, jobs ...*Job) (int, error) {
+	if len(jobs) == 0 {
+		return -1, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: "WaitAny requires at least one job"}
+	}
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		index int
+		err   error
+	}
+	finished := make(chan outcome, len(jobs))
+
+	for i, j := range jobs {
+		go func(i int, j *Job) {
+			finished <- outcome{i, j.Wait(waitCtx)}
+		}(i, j)
+	}
+
+	for range jobs {
+		o := <-finished
+		if waitCtx.Err() == nil || o.err != waitCtx.Err() {
+			return o.index, o.err
+		}
+	}
+	return -1, ctx.Err()
+}
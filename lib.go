@@ -17,6 +17,17 @@ func udsPath() string {
 	return udsPathDefault
 }
 
+// IpcPath returns the folder where lsmd creates the plugin sockets, which is
+// LSM_UDS_PATH when set.
+func IpcPath() string {
+	return udsPath()
+}
+
+// PluginSockets returns the paths of the plugin sockets in dir, eg. IpcPath().
+func PluginSockets(dir string) []string {
+	return getPlugins(dir)
+}
+
 func contains(s []string, v string) bool {
 	for _, a := range s {
 		if a == v {
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: 0BSD
+
+package lsmgrpc
+
+import (
+	"context"
+	"fmt"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+func fromSystem(s *lsm.System) *System {
+	return &System{
+		Id:           s.ID,
+		Name:         s.Name,
+		Status:       uint32(s.Status),
+		StatusInfo:   s.StatusInfo,
+		PluginData:   s.PluginData,
+		FwVersion:    s.FwVersion,
+		ReadCachePct: int32(s.ReadCachePct),
+		Mode:         int32(s.SystemMode),
+	}
+}
+
+func fromPool(p *lsm.Pool) *Pool {
+	return &Pool{
+		Id:                 p.ID,
+		Name:               p.Name,
+		ElementType:        uint64(p.ElementType),
+		UnsupportedActions: uint64(p.UnsupportedActions),
+		TotalSpace:         p.TotalSpace,
+		FreeSpace:          p.FreeSpace,
+		Status:             uint64(p.Status),
+		StatusInfo:         p.StatusInfo,
+		PluginData:         p.PluginData,
+		SystemId:           p.SystemID,
+	}
+}
+
+func fromVolume(v *lsm.Volume) *Volume {
+	if v == nil {
+		return nil
+	}
+	return &Volume{
+		Id:          v.ID,
+		Name:        v.Name,
+		Enabled:     bool(v.Enabled),
+		BlockSize:   v.BlockSize,
+		NumOfBlocks: v.NumOfBlocks,
+		PluginData:  v.PluginData,
+		Vpd83:       v.Vpd83,
+		SystemId:    v.SystemID,
+		PoolId:      v.PoolID,
+	}
+}
+
+func fromDisk(d *lsm.Disk) *Disk {
+	return &Disk{
+		Id:          d.ID,
+		Name:        d.Name,
+		DiskType:    int32(d.DiskType),
+		BlockSize:   d.BlockSize,
+		NumOfBlocks: d.NumOfBlocks,
+		Status:      uint64(d.Status),
+		PluginData:  d.PluginData,
+		SystemId:    d.SystemID,
+		Location:    d.Location,
+		Rpm:         int32(d.Rpm),
+		LinkType:    int32(d.LinkType),
+		Vpd83:       d.Vpd83,
+	}
+}
+
+func fromFileSystem(fs *lsm.FileSystem) *FileSystem {
+	if fs == nil {
+		return nil
+	}
+	return &FileSystem{
+		Id:         fs.ID,
+		Name:       fs.Name,
+		TotalSpace: fs.TotalSpace,
+		FreeSpace:  fs.FreeSpace,
+		PluginData: fs.PluginData,
+		SystemId:   fs.SystemID,
+		PoolId:     fs.PoolID,
+	}
+}
+
+func fromSnapShot(ss *lsm.FileSystemSnapShot) *FileSystemSnapShot {
+	return &FileSystemSnapShot{
+		Id:         ss.ID,
+		Name:       ss.Name,
+		Ts:         ss.Ts,
+		PluginData: ss.PluginData,
+	}
+}
+
+func fromNfsExport(e *lsm.NfsExport) *NfsExport {
+	return &NfsExport{
+		Id:         e.ID,
+		FsId:       e.FsID,
+		ExportPath: e.ExportPath,
+		Auth:       e.Auth,
+		Root:       e.Root,
+		Rw:         e.Rw,
+		Ro:         e.Ro,
+		AnonUid:    e.AnonUID,
+		AnonGid:    e.AnonGID,
+		Options:    e.Options,
+		PluginData: e.PluginData,
+	}
+}
+
+func fromAccessGroup(ag *lsm.AccessGroup) *AccessGroup {
+	return &AccessGroup{
+		Id:         ag.ID,
+		Name:       ag.Name,
+		InitIds:    ag.InitIDs,
+		InitType:   int32(ag.InitiatorType),
+		PluginData: ag.PluginData,
+		SystemId:   ag.SystemID,
+	}
+}
+
+func fromTargetPort(tp *lsm.TargetPort) *TargetPort {
+	return &TargetPort{
+		Id:              tp.ID,
+		PortType:        int32(tp.PortType),
+		ServiceAddress:  tp.ServiceAddress,
+		NetworkAddress:  tp.NetworkAddress,
+		PhysicalAddress: tp.PhysicalAddress,
+		PhysicalName:    tp.PhysicalName,
+		PluginData:      tp.PluginData,
+		SystemId:        tp.SystemID,
+	}
+}
+
+func fromBattery(b *lsm.Battery) *Battery {
+	return &Battery{
+		Id:         b.ID,
+		Name:       b.Name,
+		Type:       int32(b.BatteryType),
+		PluginData: b.PluginData,
+		Status:     uint64(b.Status),
+		SystemId:   b.SystemID,
+	}
+}
+
+// The lookups turn the IDs of the requests into the items the client calls
+// take.
+
+func (s *Server) system(ctx context.Context, id string) (*lsm.System, error) {
+	systems, err := s.client.SystemsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range systems {
+		if systems[i].ID == id {
+			return &systems[i], nil
+		}
+	}
+	return nil, notFound(errors.NotFoundSystem, "system", id)
+}
+
+func (s *Server) pool(ctx context.Context, id string) (*lsm.Pool, error) {
+	pools, err := s.client.PoolsBy(ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		return nil, notFound(errors.NotFoundPool, "pool", id)
+	}
+	return &pools[0], nil
+}
+
+func (s *Server) volume(ctx context.Context, id string) (*lsm.Volume, error) {
+	volumes, err := s.client.VolumesBy(ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(volumes) == 0 {
+		return nil, notFound(errors.NotFoundVolume, "volume", id)
+	}
+	return &volumes[0], nil
+}
+
+func (s *Server) fs(ctx context.Context, id string) (*lsm.FileSystem, error) {
+	fileSystems, err := s.client.FileSystemsBy(ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(fileSystems) == 0 {
+		return nil, notFound(errors.NotFoundFs, "file system", id)
+	}
+	return &fileSystems[0], nil
+}
+
+func (s *Server) accessGroup(ctx context.Context, id string) (*lsm.AccessGroup, error) {
+	groups, err := s.client.AccessGroupsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i].ID == id {
+			return &groups[i], nil
+		}
+	}
+	return nil, notFound(errors.NotFoundAccessGroup, "access group", id)
+}
+
+func (s *Server) volumeAndGroup(ctx context.Context, id string, agID string) (*lsm.Volume, *lsm.AccessGroup, error) {
+	volume, err := s.volume(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	ag, err := s.accessGroup(ctx, agID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return volume, ag, nil
+}
+
+func notFound(code int32, what string, id string) error {
+	return &errors.LsmError{
+		Code:    code,
+		Message: fmt.Sprintf("%s %s not found", what, id)}
+}
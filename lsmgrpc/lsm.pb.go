@@ -0,0 +1,3159 @@
+// SPDX-License-Identifier: 0BSD
+
+// The storage API of libStorageMgmt as a gRPC service, the messages mirror
+// the types of data.go.  Enumerated values and bit fields use the numbers of
+// the corresponding Go types, eg. Pool.status holds lsm.PoolStatusType bits.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: lsm.proto
+
+package lsmgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type JobStatus int32
+
+const (
+	JobStatus_JOB_STATUS_UNKNOWN    JobStatus = 0
+	JobStatus_JOB_STATUS_INPROGRESS JobStatus = 1
+	JobStatus_JOB_STATUS_COMPLETE   JobStatus = 2
+	JobStatus_JOB_STATUS_ERROR      JobStatus = 3
+)
+
+// Enum value maps for JobStatus.
+var (
+	JobStatus_name = map[int32]string{
+		0: "JOB_STATUS_UNKNOWN",
+		1: "JOB_STATUS_INPROGRESS",
+		2: "JOB_STATUS_COMPLETE",
+		3: "JOB_STATUS_ERROR",
+	}
+	JobStatus_value = map[string]int32{
+		"JOB_STATUS_UNKNOWN":    0,
+		"JOB_STATUS_INPROGRESS": 1,
+		"JOB_STATUS_COMPLETE":   2,
+		"JOB_STATUS_ERROR":      3,
+	}
+)
+
+func (x JobStatus) Enum() *JobStatus {
+	p := new(JobStatus)
+	*p = x
+	return p
+}
+
+func (x JobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_lsm_proto_enumTypes[0].Descriptor()
+}
+
+func (JobStatus) Type() protoreflect.EnumType {
+	return &file_lsm_proto_enumTypes[0]
+}
+
+func (x JobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobStatus.Descriptor instead.
+func (JobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{0}
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_lsm_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{0}
+}
+
+// Error carries the LsmError of a failed call as status detail and the
+// error of a failed job.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_lsm_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+// SearchRequest optionally restricts a listing to the items where key has
+// value, eg. key "pool_id".
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_lsm_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PluginInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginInfo) Reset() {
+	*x = PluginInfo{}
+	mi := &file_lsm_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginInfo) ProtoMessage() {}
+
+func (x *PluginInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginInfo.ProtoReflect.Descriptor instead.
+func (*PluginInfo) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PluginInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type System struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        uint32                 `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	StatusInfo    string                 `protobuf:"bytes,4,opt,name=status_info,json=statusInfo,proto3" json:"status_info,omitempty"`
+	PluginData    *string                `protobuf:"bytes,5,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	FwVersion     string                 `protobuf:"bytes,6,opt,name=fw_version,json=fwVersion,proto3" json:"fw_version,omitempty"`
+	ReadCachePct  int32                  `protobuf:"varint,7,opt,name=read_cache_pct,json=readCachePct,proto3" json:"read_cache_pct,omitempty"`
+	Mode          int32                  `protobuf:"varint,8,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *System) Reset() {
+	*x = System{}
+	mi := &file_lsm_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *System) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*System) ProtoMessage() {}
+
+func (x *System) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use System.ProtoReflect.Descriptor instead.
+func (*System) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *System) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *System) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *System) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *System) GetStatusInfo() string {
+	if x != nil {
+		return x.StatusInfo
+	}
+	return ""
+}
+
+func (x *System) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *System) GetFwVersion() string {
+	if x != nil {
+		return x.FwVersion
+	}
+	return ""
+}
+
+func (x *System) GetReadCachePct() int32 {
+	if x != nil {
+		return x.ReadCachePct
+	}
+	return 0
+}
+
+func (x *System) GetMode() int32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+type Pool struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ElementType        uint64                 `protobuf:"varint,3,opt,name=element_type,json=elementType,proto3" json:"element_type,omitempty"`
+	UnsupportedActions uint64                 `protobuf:"varint,4,opt,name=unsupported_actions,json=unsupportedActions,proto3" json:"unsupported_actions,omitempty"`
+	TotalSpace         uint64                 `protobuf:"varint,5,opt,name=total_space,json=totalSpace,proto3" json:"total_space,omitempty"`
+	FreeSpace          uint64                 `protobuf:"varint,6,opt,name=free_space,json=freeSpace,proto3" json:"free_space,omitempty"`
+	Status             uint64                 `protobuf:"varint,7,opt,name=status,proto3" json:"status,omitempty"`
+	StatusInfo         string                 `protobuf:"bytes,8,opt,name=status_info,json=statusInfo,proto3" json:"status_info,omitempty"`
+	PluginData         *string                `protobuf:"bytes,9,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	SystemId           string                 `protobuf:"bytes,10,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Pool) Reset() {
+	*x = Pool{}
+	mi := &file_lsm_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pool) ProtoMessage() {}
+
+func (x *Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pool.ProtoReflect.Descriptor instead.
+func (*Pool) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Pool) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Pool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pool) GetElementType() uint64 {
+	if x != nil {
+		return x.ElementType
+	}
+	return 0
+}
+
+func (x *Pool) GetUnsupportedActions() uint64 {
+	if x != nil {
+		return x.UnsupportedActions
+	}
+	return 0
+}
+
+func (x *Pool) GetTotalSpace() uint64 {
+	if x != nil {
+		return x.TotalSpace
+	}
+	return 0
+}
+
+func (x *Pool) GetFreeSpace() uint64 {
+	if x != nil {
+		return x.FreeSpace
+	}
+	return 0
+}
+
+func (x *Pool) GetStatus() uint64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Pool) GetStatusInfo() string {
+	if x != nil {
+		return x.StatusInfo
+	}
+	return ""
+}
+
+func (x *Pool) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *Pool) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+type Volume struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	BlockSize     uint64                 `protobuf:"varint,4,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	NumOfBlocks   uint64                 `protobuf:"varint,5,opt,name=num_of_blocks,json=numOfBlocks,proto3" json:"num_of_blocks,omitempty"`
+	PluginData    *string                `protobuf:"bytes,6,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	Vpd83         string                 `protobuf:"bytes,7,opt,name=vpd83,proto3" json:"vpd83,omitempty"`
+	SystemId      string                 `protobuf:"bytes,8,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	PoolId        string                 `protobuf:"bytes,9,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Volume) Reset() {
+	*x = Volume{}
+	mi := &file_lsm_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volume) ProtoMessage() {}
+
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Volume) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Volume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Volume) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Volume) GetBlockSize() uint64 {
+	if x != nil {
+		return x.BlockSize
+	}
+	return 0
+}
+
+func (x *Volume) GetNumOfBlocks() uint64 {
+	if x != nil {
+		return x.NumOfBlocks
+	}
+	return 0
+}
+
+func (x *Volume) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *Volume) GetVpd83() string {
+	if x != nil {
+		return x.Vpd83
+	}
+	return ""
+}
+
+func (x *Volume) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+func (x *Volume) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+type Disk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DiskType      int32                  `protobuf:"varint,3,opt,name=disk_type,json=diskType,proto3" json:"disk_type,omitempty"`
+	BlockSize     uint64                 `protobuf:"varint,4,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	NumOfBlocks   uint64                 `protobuf:"varint,5,opt,name=num_of_blocks,json=numOfBlocks,proto3" json:"num_of_blocks,omitempty"`
+	Status        uint64                 `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`
+	PluginData    *string                `protobuf:"bytes,7,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	SystemId      string                 `protobuf:"bytes,8,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	Location      string                 `protobuf:"bytes,9,opt,name=location,proto3" json:"location,omitempty"`
+	Rpm           int32                  `protobuf:"varint,10,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	LinkType      int32                  `protobuf:"varint,11,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	Vpd83         string                 `protobuf:"bytes,12,opt,name=vpd83,proto3" json:"vpd83,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Disk) Reset() {
+	*x = Disk{}
+	mi := &file_lsm_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Disk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Disk) ProtoMessage() {}
+
+func (x *Disk) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Disk.ProtoReflect.Descriptor instead.
+func (*Disk) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Disk) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Disk) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Disk) GetDiskType() int32 {
+	if x != nil {
+		return x.DiskType
+	}
+	return 0
+}
+
+func (x *Disk) GetBlockSize() uint64 {
+	if x != nil {
+		return x.BlockSize
+	}
+	return 0
+}
+
+func (x *Disk) GetNumOfBlocks() uint64 {
+	if x != nil {
+		return x.NumOfBlocks
+	}
+	return 0
+}
+
+func (x *Disk) GetStatus() uint64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Disk) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *Disk) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+func (x *Disk) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Disk) GetRpm() int32 {
+	if x != nil {
+		return x.Rpm
+	}
+	return 0
+}
+
+func (x *Disk) GetLinkType() int32 {
+	if x != nil {
+		return x.LinkType
+	}
+	return 0
+}
+
+func (x *Disk) GetVpd83() string {
+	if x != nil {
+		return x.Vpd83
+	}
+	return ""
+}
+
+type FileSystem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TotalSpace    uint64                 `protobuf:"varint,3,opt,name=total_space,json=totalSpace,proto3" json:"total_space,omitempty"`
+	FreeSpace     uint64                 `protobuf:"varint,4,opt,name=free_space,json=freeSpace,proto3" json:"free_space,omitempty"`
+	PluginData    *string                `protobuf:"bytes,5,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	SystemId      string                 `protobuf:"bytes,6,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	PoolId        string                 `protobuf:"bytes,7,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSystem) Reset() {
+	*x = FileSystem{}
+	mi := &file_lsm_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSystem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSystem) ProtoMessage() {}
+
+func (x *FileSystem) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSystem.ProtoReflect.Descriptor instead.
+func (*FileSystem) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FileSystem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileSystem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileSystem) GetTotalSpace() uint64 {
+	if x != nil {
+		return x.TotalSpace
+	}
+	return 0
+}
+
+func (x *FileSystem) GetFreeSpace() uint64 {
+	if x != nil {
+		return x.FreeSpace
+	}
+	return 0
+}
+
+func (x *FileSystem) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *FileSystem) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+func (x *FileSystem) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+type FileSystemSnapShot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ts            uint64                 `protobuf:"varint,3,opt,name=ts,proto3" json:"ts,omitempty"`
+	PluginData    *string                `protobuf:"bytes,4,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSystemSnapShot) Reset() {
+	*x = FileSystemSnapShot{}
+	mi := &file_lsm_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSystemSnapShot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSystemSnapShot) ProtoMessage() {}
+
+func (x *FileSystemSnapShot) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSystemSnapShot.ProtoReflect.Descriptor instead.
+func (*FileSystemSnapShot) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FileSystemSnapShot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileSystemSnapShot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileSystemSnapShot) GetTs() uint64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+func (x *FileSystemSnapShot) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+type NfsExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FsId          string                 `protobuf:"bytes,2,opt,name=fs_id,json=fsId,proto3" json:"fs_id,omitempty"`
+	ExportPath    string                 `protobuf:"bytes,3,opt,name=export_path,json=exportPath,proto3" json:"export_path,omitempty"`
+	Auth          string                 `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
+	Root          []string               `protobuf:"bytes,5,rep,name=root,proto3" json:"root,omitempty"`
+	Rw            []string               `protobuf:"bytes,6,rep,name=rw,proto3" json:"rw,omitempty"`
+	Ro            []string               `protobuf:"bytes,7,rep,name=ro,proto3" json:"ro,omitempty"`
+	AnonUid       int64                  `protobuf:"varint,8,opt,name=anon_uid,json=anonUid,proto3" json:"anon_uid,omitempty"`
+	AnonGid       int64                  `protobuf:"varint,9,opt,name=anon_gid,json=anonGid,proto3" json:"anon_gid,omitempty"`
+	Options       string                 `protobuf:"bytes,10,opt,name=options,proto3" json:"options,omitempty"`
+	PluginData    *string                `protobuf:"bytes,11,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NfsExport) Reset() {
+	*x = NfsExport{}
+	mi := &file_lsm_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NfsExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NfsExport) ProtoMessage() {}
+
+func (x *NfsExport) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NfsExport.ProtoReflect.Descriptor instead.
+func (*NfsExport) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NfsExport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NfsExport) GetFsId() string {
+	if x != nil {
+		return x.FsId
+	}
+	return ""
+}
+
+func (x *NfsExport) GetExportPath() string {
+	if x != nil {
+		return x.ExportPath
+	}
+	return ""
+}
+
+func (x *NfsExport) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+func (x *NfsExport) GetRoot() []string {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *NfsExport) GetRw() []string {
+	if x != nil {
+		return x.Rw
+	}
+	return nil
+}
+
+func (x *NfsExport) GetRo() []string {
+	if x != nil {
+		return x.Ro
+	}
+	return nil
+}
+
+func (x *NfsExport) GetAnonUid() int64 {
+	if x != nil {
+		return x.AnonUid
+	}
+	return 0
+}
+
+func (x *NfsExport) GetAnonGid() int64 {
+	if x != nil {
+		return x.AnonGid
+	}
+	return 0
+}
+
+func (x *NfsExport) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+func (x *NfsExport) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+type AccessGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	InitIds       []string               `protobuf:"bytes,3,rep,name=init_ids,json=initIds,proto3" json:"init_ids,omitempty"`
+	InitType      int32                  `protobuf:"varint,4,opt,name=init_type,json=initType,proto3" json:"init_type,omitempty"`
+	PluginData    *string                `protobuf:"bytes,5,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	SystemId      string                 `protobuf:"bytes,6,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessGroup) Reset() {
+	*x = AccessGroup{}
+	mi := &file_lsm_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessGroup) ProtoMessage() {}
+
+func (x *AccessGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessGroup.ProtoReflect.Descriptor instead.
+func (*AccessGroup) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AccessGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AccessGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccessGroup) GetInitIds() []string {
+	if x != nil {
+		return x.InitIds
+	}
+	return nil
+}
+
+func (x *AccessGroup) GetInitType() int32 {
+	if x != nil {
+		return x.InitType
+	}
+	return 0
+}
+
+func (x *AccessGroup) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *AccessGroup) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+type TargetPort struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PortType        int32                  `protobuf:"varint,2,opt,name=port_type,json=portType,proto3" json:"port_type,omitempty"`
+	ServiceAddress  string                 `protobuf:"bytes,3,opt,name=service_address,json=serviceAddress,proto3" json:"service_address,omitempty"`
+	NetworkAddress  string                 `protobuf:"bytes,4,opt,name=network_address,json=networkAddress,proto3" json:"network_address,omitempty"`
+	PhysicalAddress string                 `protobuf:"bytes,5,opt,name=physical_address,json=physicalAddress,proto3" json:"physical_address,omitempty"`
+	PhysicalName    string                 `protobuf:"bytes,6,opt,name=physical_name,json=physicalName,proto3" json:"physical_name,omitempty"`
+	PluginData      *string                `protobuf:"bytes,7,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	SystemId        string                 `protobuf:"bytes,8,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TargetPort) Reset() {
+	*x = TargetPort{}
+	mi := &file_lsm_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetPort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetPort) ProtoMessage() {}
+
+func (x *TargetPort) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetPort.ProtoReflect.Descriptor instead.
+func (*TargetPort) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TargetPort) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TargetPort) GetPortType() int32 {
+	if x != nil {
+		return x.PortType
+	}
+	return 0
+}
+
+func (x *TargetPort) GetServiceAddress() string {
+	if x != nil {
+		return x.ServiceAddress
+	}
+	return ""
+}
+
+func (x *TargetPort) GetNetworkAddress() string {
+	if x != nil {
+		return x.NetworkAddress
+	}
+	return ""
+}
+
+func (x *TargetPort) GetPhysicalAddress() string {
+	if x != nil {
+		return x.PhysicalAddress
+	}
+	return ""
+}
+
+func (x *TargetPort) GetPhysicalName() string {
+	if x != nil {
+		return x.PhysicalName
+	}
+	return ""
+}
+
+func (x *TargetPort) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *TargetPort) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+type Battery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          int32                  `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	PluginData    *string                `protobuf:"bytes,4,opt,name=plugin_data,json=pluginData,proto3,oneof" json:"plugin_data,omitempty"`
+	Status        uint64                 `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	SystemId      string                 `protobuf:"bytes,6,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Battery) Reset() {
+	*x = Battery{}
+	mi := &file_lsm_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Battery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Battery) ProtoMessage() {}
+
+func (x *Battery) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Battery.ProtoReflect.Descriptor instead.
+func (*Battery) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Battery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Battery) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Battery) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Battery) GetPluginData() string {
+	if x != nil && x.PluginData != nil {
+		return *x.PluginData
+	}
+	return ""
+}
+
+func (x *Battery) GetStatus() uint64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Battery) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+type SystemsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Systems       []*System              `protobuf:"bytes,1,rep,name=systems,proto3" json:"systems,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemsReply) Reset() {
+	*x = SystemsReply{}
+	mi := &file_lsm_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemsReply) ProtoMessage() {}
+
+func (x *SystemsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemsReply.ProtoReflect.Descriptor instead.
+func (*SystemsReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SystemsReply) GetSystems() []*System {
+	if x != nil {
+		return x.Systems
+	}
+	return nil
+}
+
+type PoolsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pools         []*Pool                `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolsReply) Reset() {
+	*x = PoolsReply{}
+	mi := &file_lsm_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolsReply) ProtoMessage() {}
+
+func (x *PoolsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolsReply.ProtoReflect.Descriptor instead.
+func (*PoolsReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PoolsReply) GetPools() []*Pool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+type VolumesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Volumes       []*Volume              `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumesReply) Reset() {
+	*x = VolumesReply{}
+	mi := &file_lsm_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumesReply) ProtoMessage() {}
+
+func (x *VolumesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumesReply.ProtoReflect.Descriptor instead.
+func (*VolumesReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VolumesReply) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+type DisksReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disks         []*Disk                `protobuf:"bytes,1,rep,name=disks,proto3" json:"disks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisksReply) Reset() {
+	*x = DisksReply{}
+	mi := &file_lsm_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisksReply) ProtoMessage() {}
+
+func (x *DisksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisksReply.ProtoReflect.Descriptor instead.
+func (*DisksReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DisksReply) GetDisks() []*Disk {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+type FileSystemsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileSystems   []*FileSystem          `protobuf:"bytes,1,rep,name=file_systems,json=fileSystems,proto3" json:"file_systems,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSystemsReply) Reset() {
+	*x = FileSystemsReply{}
+	mi := &file_lsm_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSystemsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSystemsReply) ProtoMessage() {}
+
+func (x *FileSystemsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSystemsReply.ProtoReflect.Descriptor instead.
+func (*FileSystemsReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FileSystemsReply) GetFileSystems() []*FileSystem {
+	if x != nil {
+		return x.FileSystems
+	}
+	return nil
+}
+
+type NfsExportsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exports       []*NfsExport           `protobuf:"bytes,1,rep,name=exports,proto3" json:"exports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NfsExportsReply) Reset() {
+	*x = NfsExportsReply{}
+	mi := &file_lsm_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NfsExportsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NfsExportsReply) ProtoMessage() {}
+
+func (x *NfsExportsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NfsExportsReply.ProtoReflect.Descriptor instead.
+func (*NfsExportsReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NfsExportsReply) GetExports() []*NfsExport {
+	if x != nil {
+		return x.Exports
+	}
+	return nil
+}
+
+type AccessGroupsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessGroups  []*AccessGroup         `protobuf:"bytes,1,rep,name=access_groups,json=accessGroups,proto3" json:"access_groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessGroupsReply) Reset() {
+	*x = AccessGroupsReply{}
+	mi := &file_lsm_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessGroupsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessGroupsReply) ProtoMessage() {}
+
+func (x *AccessGroupsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessGroupsReply.ProtoReflect.Descriptor instead.
+func (*AccessGroupsReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AccessGroupsReply) GetAccessGroups() []*AccessGroup {
+	if x != nil {
+		return x.AccessGroups
+	}
+	return nil
+}
+
+type TargetPortsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetPorts   []*TargetPort          `protobuf:"bytes,1,rep,name=target_ports,json=targetPorts,proto3" json:"target_ports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetPortsReply) Reset() {
+	*x = TargetPortsReply{}
+	mi := &file_lsm_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetPortsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetPortsReply) ProtoMessage() {}
+
+func (x *TargetPortsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetPortsReply.ProtoReflect.Descriptor instead.
+func (*TargetPortsReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TargetPortsReply) GetTargetPorts() []*TargetPort {
+	if x != nil {
+		return x.TargetPorts
+	}
+	return nil
+}
+
+type BatteriesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Batteries     []*Battery             `protobuf:"bytes,1,rep,name=batteries,proto3" json:"batteries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatteriesReply) Reset() {
+	*x = BatteriesReply{}
+	mi := &file_lsm_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatteriesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatteriesReply) ProtoMessage() {}
+
+func (x *BatteriesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatteriesReply.ProtoReflect.Descriptor instead.
+func (*BatteriesReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BatteriesReply) GetBatteries() []*Battery {
+	if x != nil {
+		return x.Batteries
+	}
+	return nil
+}
+
+type JobReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobReply) Reset() {
+	*x = JobReply{}
+	mi := &file_lsm_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobReply) ProtoMessage() {}
+
+func (x *JobReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobReply.ProtoReflect.Descriptor instead.
+func (*JobReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *JobReply) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type VolumeReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Volume        *Volume                `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeReply) Reset() {
+	*x = VolumeReply{}
+	mi := &file_lsm_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeReply) ProtoMessage() {}
+
+func (x *VolumeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeReply.ProtoReflect.Descriptor instead.
+func (*VolumeReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VolumeReply) GetVolume() *Volume {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+func (x *VolumeReply) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type FileSystemReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileSystem    *FileSystem            `protobuf:"bytes,1,opt,name=file_system,json=fileSystem,proto3" json:"file_system,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSystemReply) Reset() {
+	*x = FileSystemReply{}
+	mi := &file_lsm_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSystemReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSystemReply) ProtoMessage() {}
+
+func (x *FileSystemReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSystemReply.ProtoReflect.Descriptor instead.
+func (*FileSystemReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FileSystemReply) GetFileSystem() *FileSystem {
+	if x != nil {
+		return x.FileSystem
+	}
+	return nil
+}
+
+func (x *FileSystemReply) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type VolumeCreateRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PoolId    string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SizeBytes uint64                 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// lsm.VolumeProvisionType, 0 is the default of the plugin
+	Provisioning  int32 `protobuf:"varint,4,opt,name=provisioning,proto3" json:"provisioning,omitempty"`
+	Sync          bool  `protobuf:"varint,5,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeCreateRequest) Reset() {
+	*x = VolumeCreateRequest{}
+	mi := &file_lsm_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeCreateRequest) ProtoMessage() {}
+
+func (x *VolumeCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeCreateRequest.ProtoReflect.Descriptor instead.
+func (*VolumeCreateRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *VolumeCreateRequest) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *VolumeCreateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VolumeCreateRequest) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *VolumeCreateRequest) GetProvisioning() int32 {
+	if x != nil {
+		return x.Provisioning
+	}
+	return 0
+}
+
+func (x *VolumeCreateRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+type VolumeResizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId      string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	SizeBytes     uint64                 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sync          bool                   `protobuf:"varint,3,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeResizeRequest) Reset() {
+	*x = VolumeResizeRequest{}
+	mi := &file_lsm_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeResizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeResizeRequest) ProtoMessage() {}
+
+func (x *VolumeResizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeResizeRequest.ProtoReflect.Descriptor instead.
+func (*VolumeResizeRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *VolumeResizeRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *VolumeResizeRequest) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *VolumeResizeRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+type VolumeDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId      string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	Sync          bool                   `protobuf:"varint,2,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeDeleteRequest) Reset() {
+	*x = VolumeDeleteRequest{}
+	mi := &file_lsm_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeDeleteRequest) ProtoMessage() {}
+
+func (x *VolumeDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeDeleteRequest.ProtoReflect.Descriptor instead.
+func (*VolumeDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VolumeDeleteRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *VolumeDeleteRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+type VolumeMaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId      string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	AccessGroupId string                 `protobuf:"bytes,2,opt,name=access_group_id,json=accessGroupId,proto3" json:"access_group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeMaskRequest) Reset() {
+	*x = VolumeMaskRequest{}
+	mi := &file_lsm_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeMaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeMaskRequest) ProtoMessage() {}
+
+func (x *VolumeMaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeMaskRequest.ProtoReflect.Descriptor instead.
+func (*VolumeMaskRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VolumeMaskRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *VolumeMaskRequest) GetAccessGroupId() string {
+	if x != nil {
+		return x.AccessGroupId
+	}
+	return ""
+}
+
+type AccessGroupCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	InitId        string                 `protobuf:"bytes,2,opt,name=init_id,json=initId,proto3" json:"init_id,omitempty"`
+	InitType      int32                  `protobuf:"varint,3,opt,name=init_type,json=initType,proto3" json:"init_type,omitempty"`
+	SystemId      string                 `protobuf:"bytes,4,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessGroupCreateRequest) Reset() {
+	*x = AccessGroupCreateRequest{}
+	mi := &file_lsm_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessGroupCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessGroupCreateRequest) ProtoMessage() {}
+
+func (x *AccessGroupCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessGroupCreateRequest.ProtoReflect.Descriptor instead.
+func (*AccessGroupCreateRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AccessGroupCreateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccessGroupCreateRequest) GetInitId() string {
+	if x != nil {
+		return x.InitId
+	}
+	return ""
+}
+
+func (x *AccessGroupCreateRequest) GetInitType() int32 {
+	if x != nil {
+		return x.InitType
+	}
+	return 0
+}
+
+func (x *AccessGroupCreateRequest) GetSystemId() string {
+	if x != nil {
+		return x.SystemId
+	}
+	return ""
+}
+
+type AccessGroupDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessGroupId string                 `protobuf:"bytes,1,opt,name=access_group_id,json=accessGroupId,proto3" json:"access_group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessGroupDeleteRequest) Reset() {
+	*x = AccessGroupDeleteRequest{}
+	mi := &file_lsm_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessGroupDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessGroupDeleteRequest) ProtoMessage() {}
+
+func (x *AccessGroupDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessGroupDeleteRequest.ProtoReflect.Descriptor instead.
+func (*AccessGroupDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AccessGroupDeleteRequest) GetAccessGroupId() string {
+	if x != nil {
+		return x.AccessGroupId
+	}
+	return ""
+}
+
+type FsCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PoolId        string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SizeBytes     uint64                 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sync          bool                   `protobuf:"varint,4,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsCreateRequest) Reset() {
+	*x = FsCreateRequest{}
+	mi := &file_lsm_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsCreateRequest) ProtoMessage() {}
+
+func (x *FsCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsCreateRequest.ProtoReflect.Descriptor instead.
+func (*FsCreateRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FsCreateRequest) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *FsCreateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FsCreateRequest) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *FsCreateRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+type FsResizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FsId          string                 `protobuf:"bytes,1,opt,name=fs_id,json=fsId,proto3" json:"fs_id,omitempty"`
+	SizeBytes     uint64                 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sync          bool                   `protobuf:"varint,3,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsResizeRequest) Reset() {
+	*x = FsResizeRequest{}
+	mi := &file_lsm_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsResizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsResizeRequest) ProtoMessage() {}
+
+func (x *FsResizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsResizeRequest.ProtoReflect.Descriptor instead.
+func (*FsResizeRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FsResizeRequest) GetFsId() string {
+	if x != nil {
+		return x.FsId
+	}
+	return ""
+}
+
+func (x *FsResizeRequest) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *FsResizeRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+type FsDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FsId          string                 `protobuf:"bytes,1,opt,name=fs_id,json=fsId,proto3" json:"fs_id,omitempty"`
+	Sync          bool                   `protobuf:"varint,2,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsDeleteRequest) Reset() {
+	*x = FsDeleteRequest{}
+	mi := &file_lsm_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsDeleteRequest) ProtoMessage() {}
+
+func (x *FsDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsDeleteRequest.ProtoReflect.Descriptor instead.
+func (*FsDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FsDeleteRequest) GetFsId() string {
+	if x != nil {
+		return x.FsId
+	}
+	return ""
+}
+
+func (x *FsDeleteRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+type FsExportRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FsId       string                 `protobuf:"bytes,1,opt,name=fs_id,json=fsId,proto3" json:"fs_id,omitempty"`
+	ExportPath *string                `protobuf:"bytes,2,opt,name=export_path,json=exportPath,proto3,oneof" json:"export_path,omitempty"`
+	Root       []string               `protobuf:"bytes,3,rep,name=root,proto3" json:"root,omitempty"`
+	Rw         []string               `protobuf:"bytes,4,rep,name=rw,proto3" json:"rw,omitempty"`
+	Ro         []string               `protobuf:"bytes,5,rep,name=ro,proto3" json:"ro,omitempty"`
+	// Unset is lsm.AnonUIDGIDNotApplicable
+	AnonUid       *int64  `protobuf:"varint,6,opt,name=anon_uid,json=anonUid,proto3,oneof" json:"anon_uid,omitempty"`
+	AnonGid       *int64  `protobuf:"varint,7,opt,name=anon_gid,json=anonGid,proto3,oneof" json:"anon_gid,omitempty"`
+	AuthType      *string `protobuf:"bytes,8,opt,name=auth_type,json=authType,proto3,oneof" json:"auth_type,omitempty"`
+	Options       *string `protobuf:"bytes,9,opt,name=options,proto3,oneof" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsExportRequest) Reset() {
+	*x = FsExportRequest{}
+	mi := &file_lsm_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsExportRequest) ProtoMessage() {}
+
+func (x *FsExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsExportRequest.ProtoReflect.Descriptor instead.
+func (*FsExportRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FsExportRequest) GetFsId() string {
+	if x != nil {
+		return x.FsId
+	}
+	return ""
+}
+
+func (x *FsExportRequest) GetExportPath() string {
+	if x != nil && x.ExportPath != nil {
+		return *x.ExportPath
+	}
+	return ""
+}
+
+func (x *FsExportRequest) GetRoot() []string {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *FsExportRequest) GetRw() []string {
+	if x != nil {
+		return x.Rw
+	}
+	return nil
+}
+
+func (x *FsExportRequest) GetRo() []string {
+	if x != nil {
+		return x.Ro
+	}
+	return nil
+}
+
+func (x *FsExportRequest) GetAnonUid() int64 {
+	if x != nil && x.AnonUid != nil {
+		return *x.AnonUid
+	}
+	return 0
+}
+
+func (x *FsExportRequest) GetAnonGid() int64 {
+	if x != nil && x.AnonGid != nil {
+		return *x.AnonGid
+	}
+	return 0
+}
+
+func (x *FsExportRequest) GetAuthType() string {
+	if x != nil && x.AuthType != nil {
+		return *x.AuthType
+	}
+	return ""
+}
+
+func (x *FsExportRequest) GetOptions() string {
+	if x != nil && x.Options != nil {
+		return *x.Options
+	}
+	return ""
+}
+
+type FsUnExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsUnExportRequest) Reset() {
+	*x = FsUnExportRequest{}
+	mi := &file_lsm_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsUnExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsUnExportRequest) ProtoMessage() {}
+
+func (x *FsUnExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsUnExportRequest.ProtoReflect.Descriptor instead.
+func (*FsUnExportRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *FsUnExportRequest) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+type JobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobRequest) Reset() {
+	*x = JobRequest{}
+	mi := &file_lsm_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRequest) ProtoMessage() {}
+
+func (x *JobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRequest.ProtoReflect.Descriptor instead.
+func (*JobRequest) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *JobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type JobStatusReply struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	JobId   string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status  JobStatus              `protobuf:"varint,2,opt,name=status,proto3,enum=libstoragemgmt.JobStatus" json:"status,omitempty"`
+	Percent uint32                 `protobuf:"varint,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// The item a completed job returned, if any
+	//
+	// Types that are valid to be assigned to Result:
+	//
+	//	*JobStatusReply_Volume
+	//	*JobStatusReply_FileSystem
+	//	*JobStatusReply_Snapshot
+	Result isJobStatusReply_Result `protobuf_oneof:"result"`
+	// Why the job failed
+	Error         *Error `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobStatusReply) Reset() {
+	*x = JobStatusReply{}
+	mi := &file_lsm_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStatusReply) ProtoMessage() {}
+
+func (x *JobStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_lsm_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStatusReply.ProtoReflect.Descriptor instead.
+func (*JobStatusReply) Descriptor() ([]byte, []int) {
+	return file_lsm_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *JobStatusReply) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobStatusReply) GetStatus() JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobStatus_JOB_STATUS_UNKNOWN
+}
+
+func (x *JobStatusReply) GetPercent() uint32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *JobStatusReply) GetResult() isJobStatusReply_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *JobStatusReply) GetVolume() *Volume {
+	if x != nil {
+		if x, ok := x.Result.(*JobStatusReply_Volume); ok {
+			return x.Volume
+		}
+	}
+	return nil
+}
+
+func (x *JobStatusReply) GetFileSystem() *FileSystem {
+	if x != nil {
+		if x, ok := x.Result.(*JobStatusReply_FileSystem); ok {
+			return x.FileSystem
+		}
+	}
+	return nil
+}
+
+func (x *JobStatusReply) GetSnapshot() *FileSystemSnapShot {
+	if x != nil {
+		if x, ok := x.Result.(*JobStatusReply_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *JobStatusReply) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type isJobStatusReply_Result interface {
+	isJobStatusReply_Result()
+}
+
+type JobStatusReply_Volume struct {
+	Volume *Volume `protobuf:"bytes,4,opt,name=volume,proto3,oneof"`
+}
+
+type JobStatusReply_FileSystem struct {
+	FileSystem *FileSystem `protobuf:"bytes,5,opt,name=file_system,json=fileSystem,proto3,oneof"`
+}
+
+type JobStatusReply_Snapshot struct {
+	Snapshot *FileSystemSnapShot `protobuf:"bytes,6,opt,name=snapshot,proto3,oneof"`
+}
+
+func (*JobStatusReply_Volume) isJobStatusReply_Result() {}
+
+func (*JobStatusReply_FileSystem) isJobStatusReply_Result() {}
+
+func (*JobStatusReply_Snapshot) isJobStatusReply_Result() {}
+
+var File_lsm_proto protoreflect.FileDescriptor
+
+const file_lsm_proto_rawDesc = "" +
+	"\n" +
+	"\tlsm.proto\x12\x0elibstoragemgmt\"\a\n" +
+	"\x05Empty\"I\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\"7\n" +
+	"\rSearchRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\\\n" +
+	"\n" +
+	"PluginInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\xf4\x01\n" +
+	"\x06System\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\rR\x06status\x12\x1f\n" +
+	"\vstatus_info\x18\x04 \x01(\tR\n" +
+	"statusInfo\x12$\n" +
+	"\vplugin_data\x18\x05 \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"fw_version\x18\x06 \x01(\tR\tfwVersion\x12$\n" +
+	"\x0eread_cache_pct\x18\a \x01(\x05R\freadCachePct\x12\x12\n" +
+	"\x04mode\x18\b \x01(\x05R\x04modeB\x0e\n" +
+	"\f_plugin_data\"\xca\x02\n" +
+	"\x04Pool\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\felement_type\x18\x03 \x01(\x04R\velementType\x12/\n" +
+	"\x13unsupported_actions\x18\x04 \x01(\x04R\x12unsupportedActions\x12\x1f\n" +
+	"\vtotal_space\x18\x05 \x01(\x04R\n" +
+	"totalSpace\x12\x1d\n" +
+	"\n" +
+	"free_space\x18\x06 \x01(\x04R\tfreeSpace\x12\x16\n" +
+	"\x06status\x18\a \x01(\x04R\x06status\x12\x1f\n" +
+	"\vstatus_info\x18\b \x01(\tR\n" +
+	"statusInfo\x12$\n" +
+	"\vplugin_data\x18\t \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x1b\n" +
+	"\tsystem_id\x18\n" +
+	" \x01(\tR\bsystemIdB\x0e\n" +
+	"\f_plugin_data\"\x8b\x02\n" +
+	"\x06Volume\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"block_size\x18\x04 \x01(\x04R\tblockSize\x12\"\n" +
+	"\rnum_of_blocks\x18\x05 \x01(\x04R\vnumOfBlocks\x12$\n" +
+	"\vplugin_data\x18\x06 \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x14\n" +
+	"\x05vpd83\x18\a \x01(\tR\x05vpd83\x12\x1b\n" +
+	"\tsystem_id\x18\b \x01(\tR\bsystemId\x12\x17\n" +
+	"\apool_id\x18\t \x01(\tR\x06poolIdB\x0e\n" +
+	"\f_plugin_data\"\xd6\x02\n" +
+	"\x04Disk\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tdisk_type\x18\x03 \x01(\x05R\bdiskType\x12\x1d\n" +
+	"\n" +
+	"block_size\x18\x04 \x01(\x04R\tblockSize\x12\"\n" +
+	"\rnum_of_blocks\x18\x05 \x01(\x04R\vnumOfBlocks\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\x04R\x06status\x12$\n" +
+	"\vplugin_data\x18\a \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x1b\n" +
+	"\tsystem_id\x18\b \x01(\tR\bsystemId\x12\x1a\n" +
+	"\blocation\x18\t \x01(\tR\blocation\x12\x10\n" +
+	"\x03rpm\x18\n" +
+	" \x01(\x05R\x03rpm\x12\x1b\n" +
+	"\tlink_type\x18\v \x01(\x05R\blinkType\x12\x14\n" +
+	"\x05vpd83\x18\f \x01(\tR\x05vpd83B\x0e\n" +
+	"\f_plugin_data\"\xdc\x01\n" +
+	"\n" +
+	"FileSystem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vtotal_space\x18\x03 \x01(\x04R\n" +
+	"totalSpace\x12\x1d\n" +
+	"\n" +
+	"free_space\x18\x04 \x01(\x04R\tfreeSpace\x12$\n" +
+	"\vplugin_data\x18\x05 \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x1b\n" +
+	"\tsystem_id\x18\x06 \x01(\tR\bsystemId\x12\x17\n" +
+	"\apool_id\x18\a \x01(\tR\x06poolIdB\x0e\n" +
+	"\f_plugin_data\"~\n" +
+	"\x12FileSystemSnapShot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02ts\x18\x03 \x01(\x04R\x02ts\x12$\n" +
+	"\vplugin_data\x18\x04 \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01B\x0e\n" +
+	"\f_plugin_data\"\x9f\x02\n" +
+	"\tNfsExport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x13\n" +
+	"\x05fs_id\x18\x02 \x01(\tR\x04fsId\x12\x1f\n" +
+	"\vexport_path\x18\x03 \x01(\tR\n" +
+	"exportPath\x12\x12\n" +
+	"\x04auth\x18\x04 \x01(\tR\x04auth\x12\x12\n" +
+	"\x04root\x18\x05 \x03(\tR\x04root\x12\x0e\n" +
+	"\x02rw\x18\x06 \x03(\tR\x02rw\x12\x0e\n" +
+	"\x02ro\x18\a \x03(\tR\x02ro\x12\x19\n" +
+	"\banon_uid\x18\b \x01(\x03R\aanonUid\x12\x19\n" +
+	"\banon_gid\x18\t \x01(\x03R\aanonGid\x12\x18\n" +
+	"\aoptions\x18\n" +
+	" \x01(\tR\aoptions\x12$\n" +
+	"\vplugin_data\x18\v \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01B\x0e\n" +
+	"\f_plugin_data\"\xbc\x01\n" +
+	"\vAccessGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\binit_ids\x18\x03 \x03(\tR\ainitIds\x12\x1b\n" +
+	"\tinit_type\x18\x04 \x01(\x05R\binitType\x12$\n" +
+	"\vplugin_data\x18\x05 \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x1b\n" +
+	"\tsystem_id\x18\x06 \x01(\tR\bsystemIdB\x0e\n" +
+	"\f_plugin_data\"\xae\x02\n" +
+	"\n" +
+	"TargetPort\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tport_type\x18\x02 \x01(\x05R\bportType\x12'\n" +
+	"\x0fservice_address\x18\x03 \x01(\tR\x0eserviceAddress\x12'\n" +
+	"\x0fnetwork_address\x18\x04 \x01(\tR\x0enetworkAddress\x12)\n" +
+	"\x10physical_address\x18\x05 \x01(\tR\x0fphysicalAddress\x12#\n" +
+	"\rphysical_name\x18\x06 \x01(\tR\fphysicalName\x12$\n" +
+	"\vplugin_data\x18\a \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x1b\n" +
+	"\tsystem_id\x18\b \x01(\tR\bsystemIdB\x0e\n" +
+	"\f_plugin_data\"\xac\x01\n" +
+	"\aBattery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\x05R\x04type\x12$\n" +
+	"\vplugin_data\x18\x04 \x01(\tH\x00R\n" +
+	"pluginData\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\x04R\x06status\x12\x1b\n" +
+	"\tsystem_id\x18\x06 \x01(\tR\bsystemIdB\x0e\n" +
+	"\f_plugin_data\"@\n" +
+	"\fSystemsReply\x120\n" +
+	"\asystems\x18\x01 \x03(\v2\x16.libstoragemgmt.SystemR\asystems\"8\n" +
+	"\n" +
+	"PoolsReply\x12*\n" +
+	"\x05pools\x18\x01 \x03(\v2\x14.libstoragemgmt.PoolR\x05pools\"@\n" +
+	"\fVolumesReply\x120\n" +
+	"\avolumes\x18\x01 \x03(\v2\x16.libstoragemgmt.VolumeR\avolumes\"8\n" +
+	"\n" +
+	"DisksReply\x12*\n" +
+	"\x05disks\x18\x01 \x03(\v2\x14.libstoragemgmt.DiskR\x05disks\"Q\n" +
+	"\x10FileSystemsReply\x12=\n" +
+	"\ffile_systems\x18\x01 \x03(\v2\x1a.libstoragemgmt.FileSystemR\vfileSystems\"F\n" +
+	"\x0fNfsExportsReply\x123\n" +
+	"\aexports\x18\x01 \x03(\v2\x19.libstoragemgmt.NfsExportR\aexports\"U\n" +
+	"\x11AccessGroupsReply\x12@\n" +
+	"\raccess_groups\x18\x01 \x03(\v2\x1b.libstoragemgmt.AccessGroupR\faccessGroups\"Q\n" +
+	"\x10TargetPortsReply\x12=\n" +
+	"\ftarget_ports\x18\x01 \x03(\v2\x1a.libstoragemgmt.TargetPortR\vtargetPorts\"G\n" +
+	"\x0eBatteriesReply\x125\n" +
+	"\tbatteries\x18\x01 \x03(\v2\x17.libstoragemgmt.BatteryR\tbatteries\"!\n" +
+	"\bJobReply\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"T\n" +
+	"\vVolumeReply\x12.\n" +
+	"\x06volume\x18\x01 \x01(\v2\x16.libstoragemgmt.VolumeR\x06volume\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"e\n" +
+	"\x0fFileSystemReply\x12;\n" +
+	"\vfile_system\x18\x01 \x01(\v2\x1a.libstoragemgmt.FileSystemR\n" +
+	"fileSystem\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"\x99\x01\n" +
+	"\x13VolumeCreateRequest\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x04R\tsizeBytes\x12\"\n" +
+	"\fprovisioning\x18\x04 \x01(\x05R\fprovisioning\x12\x12\n" +
+	"\x04sync\x18\x05 \x01(\bR\x04sync\"e\n" +
+	"\x13VolumeResizeRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x04R\tsizeBytes\x12\x12\n" +
+	"\x04sync\x18\x03 \x01(\bR\x04sync\"F\n" +
+	"\x13VolumeDeleteRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12\x12\n" +
+	"\x04sync\x18\x02 \x01(\bR\x04sync\"X\n" +
+	"\x11VolumeMaskRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12&\n" +
+	"\x0faccess_group_id\x18\x02 \x01(\tR\raccessGroupId\"\x81\x01\n" +
+	"\x18AccessGroupCreateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\ainit_id\x18\x02 \x01(\tR\x06initId\x12\x1b\n" +
+	"\tinit_type\x18\x03 \x01(\x05R\binitType\x12\x1b\n" +
+	"\tsystem_id\x18\x04 \x01(\tR\bsystemId\"B\n" +
+	"\x18AccessGroupDeleteRequest\x12&\n" +
+	"\x0faccess_group_id\x18\x01 \x01(\tR\raccessGroupId\"q\n" +
+	"\x0fFsCreateRequest\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x04R\tsizeBytes\x12\x12\n" +
+	"\x04sync\x18\x04 \x01(\bR\x04sync\"Y\n" +
+	"\x0fFsResizeRequest\x12\x13\n" +
+	"\x05fs_id\x18\x01 \x01(\tR\x04fsId\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x04R\tsizeBytes\x12\x12\n" +
+	"\x04sync\x18\x03 \x01(\bR\x04sync\":\n" +
+	"\x0fFsDeleteRequest\x12\x13\n" +
+	"\x05fs_id\x18\x01 \x01(\tR\x04fsId\x12\x12\n" +
+	"\x04sync\x18\x02 \x01(\bR\x04sync\"\xc5\x02\n" +
+	"\x0fFsExportRequest\x12\x13\n" +
+	"\x05fs_id\x18\x01 \x01(\tR\x04fsId\x12$\n" +
+	"\vexport_path\x18\x02 \x01(\tH\x00R\n" +
+	"exportPath\x88\x01\x01\x12\x12\n" +
+	"\x04root\x18\x03 \x03(\tR\x04root\x12\x0e\n" +
+	"\x02rw\x18\x04 \x03(\tR\x02rw\x12\x0e\n" +
+	"\x02ro\x18\x05 \x03(\tR\x02ro\x12\x1e\n" +
+	"\banon_uid\x18\x06 \x01(\x03H\x01R\aanonUid\x88\x01\x01\x12\x1e\n" +
+	"\banon_gid\x18\a \x01(\x03H\x02R\aanonGid\x88\x01\x01\x12 \n" +
+	"\tauth_type\x18\b \x01(\tH\x03R\bauthType\x88\x01\x01\x12\x1d\n" +
+	"\aoptions\x18\t \x01(\tH\x04R\aoptions\x88\x01\x01B\x0e\n" +
+	"\f_export_pathB\v\n" +
+	"\t_anon_uidB\v\n" +
+	"\t_anon_gidB\f\n" +
+	"\n" +
+	"_auth_typeB\n" +
+	"\n" +
+	"\b_options\"0\n" +
+	"\x11FsUnExportRequest\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\"#\n" +
+	"\n" +
+	"JobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xde\x02\n" +
+	"\x0eJobStatusReply\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.libstoragemgmt.JobStatusR\x06status\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\rR\apercent\x120\n" +
+	"\x06volume\x18\x04 \x01(\v2\x16.libstoragemgmt.VolumeH\x00R\x06volume\x12=\n" +
+	"\vfile_system\x18\x05 \x01(\v2\x1a.libstoragemgmt.FileSystemH\x00R\n" +
+	"fileSystem\x12@\n" +
+	"\bsnapshot\x18\x06 \x01(\v2\".libstoragemgmt.FileSystemSnapShotH\x00R\bsnapshot\x12+\n" +
+	"\x05error\x18\a \x01(\v2\x15.libstoragemgmt.ErrorR\x05errorB\b\n" +
+	"\x06result*m\n" +
+	"\tJobStatus\x12\x16\n" +
+	"\x12JOB_STATUS_UNKNOWN\x10\x00\x12\x19\n" +
+	"\x15JOB_STATUS_INPROGRESS\x10\x01\x12\x17\n" +
+	"\x13JOB_STATUS_COMPLETE\x10\x02\x12\x14\n" +
+	"\x10JOB_STATUS_ERROR\x10\x032\xc1\x0e\n" +
+	"\aStorage\x12?\n" +
+	"\n" +
+	"PluginInfo\x12\x15.libstoragemgmt.Empty\x1a\x1a.libstoragemgmt.PluginInfo\x12>\n" +
+	"\aSystems\x12\x15.libstoragemgmt.Empty\x1a\x1c.libstoragemgmt.SystemsReply\x12B\n" +
+	"\x05Pools\x12\x1d.libstoragemgmt.SearchRequest\x1a\x1a.libstoragemgmt.PoolsReply\x12F\n" +
+	"\aVolumes\x12\x1d.libstoragemgmt.SearchRequest\x1a\x1c.libstoragemgmt.VolumesReply\x12:\n" +
+	"\x05Disks\x12\x15.libstoragemgmt.Empty\x1a\x1a.libstoragemgmt.DisksReply\x12N\n" +
+	"\vFileSystems\x12\x1d.libstoragemgmt.SearchRequest\x1a .libstoragemgmt.FileSystemsReply\x12L\n" +
+	"\n" +
+	"NfsExports\x12\x1d.libstoragemgmt.SearchRequest\x1a\x1f.libstoragemgmt.NfsExportsReply\x12H\n" +
+	"\fAccessGroups\x12\x15.libstoragemgmt.Empty\x1a!.libstoragemgmt.AccessGroupsReply\x12F\n" +
+	"\vTargetPorts\x12\x15.libstoragemgmt.Empty\x1a .libstoragemgmt.TargetPortsReply\x12B\n" +
+	"\tBatteries\x12\x15.libstoragemgmt.Empty\x1a\x1e.libstoragemgmt.BatteriesReply\x12P\n" +
+	"\fVolumeCreate\x12#.libstoragemgmt.VolumeCreateRequest\x1a\x1b.libstoragemgmt.VolumeReply\x12P\n" +
+	"\fVolumeResize\x12#.libstoragemgmt.VolumeResizeRequest\x1a\x1b.libstoragemgmt.VolumeReply\x12M\n" +
+	"\fVolumeDelete\x12#.libstoragemgmt.VolumeDeleteRequest\x1a\x18.libstoragemgmt.JobReply\x12F\n" +
+	"\n" +
+	"VolumeMask\x12!.libstoragemgmt.VolumeMaskRequest\x1a\x15.libstoragemgmt.Empty\x12H\n" +
+	"\fVolumeUnMask\x12!.libstoragemgmt.VolumeMaskRequest\x1a\x15.libstoragemgmt.Empty\x12Z\n" +
+	"\x11AccessGroupCreate\x12(.libstoragemgmt.AccessGroupCreateRequest\x1a\x1b.libstoragemgmt.AccessGroup\x12T\n" +
+	"\x11AccessGroupDelete\x12(.libstoragemgmt.AccessGroupDeleteRequest\x1a\x15.libstoragemgmt.Empty\x12L\n" +
+	"\bFsCreate\x12\x1f.libstoragemgmt.FsCreateRequest\x1a\x1f.libstoragemgmt.FileSystemReply\x12L\n" +
+	"\bFsResize\x12\x1f.libstoragemgmt.FsResizeRequest\x1a\x1f.libstoragemgmt.FileSystemReply\x12E\n" +
+	"\bFsDelete\x12\x1f.libstoragemgmt.FsDeleteRequest\x1a\x18.libstoragemgmt.JobReply\x12F\n" +
+	"\bFsExport\x12\x1f.libstoragemgmt.FsExportRequest\x1a\x19.libstoragemgmt.NfsExport\x12F\n" +
+	"\n" +
+	"FsUnExport\x12!.libstoragemgmt.FsUnExportRequest\x1a\x15.libstoragemgmt.Empty\x12G\n" +
+	"\tJobStatus\x12\x1a.libstoragemgmt.JobRequest\x1a\x1e.libstoragemgmt.JobStatusReply\x12<\n" +
+	"\aJobFree\x12\x1a.libstoragemgmt.JobRequest\x1a\x15.libstoragemgmt.Empty\x12H\n" +
+	"\bJobWatch\x12\x1a.libstoragemgmt.JobRequest\x1a\x1e.libstoragemgmt.JobStatusReply0\x01B5Z3github.com/libstorage/libstoragemgmt-golang/lsmgrpcb\x06proto3"
+
+var (
+	file_lsm_proto_rawDescOnce sync.Once
+	file_lsm_proto_rawDescData []byte
+)
+
+func file_lsm_proto_rawDescGZIP() []byte {
+	file_lsm_proto_rawDescOnce.Do(func() {
+		file_lsm_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lsm_proto_rawDesc), len(file_lsm_proto_rawDesc)))
+	})
+	return file_lsm_proto_rawDescData
+}
+
+var file_lsm_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_lsm_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_lsm_proto_goTypes = []any{
+	(JobStatus)(0),                   // 0: libstoragemgmt.JobStatus
+	(*Empty)(nil),                    // 1: libstoragemgmt.Empty
+	(*Error)(nil),                    // 2: libstoragemgmt.Error
+	(*SearchRequest)(nil),            // 3: libstoragemgmt.SearchRequest
+	(*PluginInfo)(nil),               // 4: libstoragemgmt.PluginInfo
+	(*System)(nil),                   // 5: libstoragemgmt.System
+	(*Pool)(nil),                     // 6: libstoragemgmt.Pool
+	(*Volume)(nil),                   // 7: libstoragemgmt.Volume
+	(*Disk)(nil),                     // 8: libstoragemgmt.Disk
+	(*FileSystem)(nil),               // 9: libstoragemgmt.FileSystem
+	(*FileSystemSnapShot)(nil),       // 10: libstoragemgmt.FileSystemSnapShot
+	(*NfsExport)(nil),                // 11: libstoragemgmt.NfsExport
+	(*AccessGroup)(nil),              // 12: libstoragemgmt.AccessGroup
+	(*TargetPort)(nil),               // 13: libstoragemgmt.TargetPort
+	(*Battery)(nil),                  // 14: libstoragemgmt.Battery
+	(*SystemsReply)(nil),             // 15: libstoragemgmt.SystemsReply
+	(*PoolsReply)(nil),               // 16: libstoragemgmt.PoolsReply
+	(*VolumesReply)(nil),             // 17: libstoragemgmt.VolumesReply
+	(*DisksReply)(nil),               // 18: libstoragemgmt.DisksReply
+	(*FileSystemsReply)(nil),         // 19: libstoragemgmt.FileSystemsReply
+	(*NfsExportsReply)(nil),          // 20: libstoragemgmt.NfsExportsReply
+	(*AccessGroupsReply)(nil),        // 21: libstoragemgmt.AccessGroupsReply
+	(*TargetPortsReply)(nil),         // 22: libstoragemgmt.TargetPortsReply
+	(*BatteriesReply)(nil),           // 23: libstoragemgmt.BatteriesReply
+	(*JobReply)(nil),                 // 24: libstoragemgmt.JobReply
+	(*VolumeReply)(nil),              // 25: libstoragemgmt.VolumeReply
+	(*FileSystemReply)(nil),          // 26: libstoragemgmt.FileSystemReply
+	(*VolumeCreateRequest)(nil),      // 27: libstoragemgmt.VolumeCreateRequest
+	(*VolumeResizeRequest)(nil),      // 28: libstoragemgmt.VolumeResizeRequest
+	(*VolumeDeleteRequest)(nil),      // 29: libstoragemgmt.VolumeDeleteRequest
+	(*VolumeMaskRequest)(nil),        // 30: libstoragemgmt.VolumeMaskRequest
+	(*AccessGroupCreateRequest)(nil), // 31: libstoragemgmt.AccessGroupCreateRequest
+	(*AccessGroupDeleteRequest)(nil), // 32: libstoragemgmt.AccessGroupDeleteRequest
+	(*FsCreateRequest)(nil),          // 33: libstoragemgmt.FsCreateRequest
+	(*FsResizeRequest)(nil),          // 34: libstoragemgmt.FsResizeRequest
+	(*FsDeleteRequest)(nil),          // 35: libstoragemgmt.FsDeleteRequest
+	(*FsExportRequest)(nil),          // 36: libstoragemgmt.FsExportRequest
+	(*FsUnExportRequest)(nil),        // 37: libstoragemgmt.FsUnExportRequest
+	(*JobRequest)(nil),               // 38: libstoragemgmt.JobRequest
+	(*JobStatusReply)(nil),           // 39: libstoragemgmt.JobStatusReply
+}
+var file_lsm_proto_depIdxs = []int32{
+	5,  // 0: libstoragemgmt.SystemsReply.systems:type_name -> libstoragemgmt.System
+	6,  // 1: libstoragemgmt.PoolsReply.pools:type_name -> libstoragemgmt.Pool
+	7,  // 2: libstoragemgmt.VolumesReply.volumes:type_name -> libstoragemgmt.Volume
+	8,  // 3: libstoragemgmt.DisksReply.disks:type_name -> libstoragemgmt.Disk
+	9,  // 4: libstoragemgmt.FileSystemsReply.file_systems:type_name -> libstoragemgmt.FileSystem
+	11, // 5: libstoragemgmt.NfsExportsReply.exports:type_name -> libstoragemgmt.NfsExport
+	12, // 6: libstoragemgmt.AccessGroupsReply.access_groups:type_name -> libstoragemgmt.AccessGroup
+	13, // 7: libstoragemgmt.TargetPortsReply.target_ports:type_name -> libstoragemgmt.TargetPort
+	14, // 8: libstoragemgmt.BatteriesReply.batteries:type_name -> libstoragemgmt.Battery
+	7,  // 9: libstoragemgmt.VolumeReply.volume:type_name -> libstoragemgmt.Volume
+	9,  // 10: libstoragemgmt.FileSystemReply.file_system:type_name -> libstoragemgmt.FileSystem
+	0,  // 11: libstoragemgmt.JobStatusReply.status:type_name -> libstoragemgmt.JobStatus
+	7,  // 12: libstoragemgmt.JobStatusReply.volume:type_name -> libstoragemgmt.Volume
+	9,  // 13: libstoragemgmt.JobStatusReply.file_system:type_name -> libstoragemgmt.FileSystem
+	10, // 14: libstoragemgmt.JobStatusReply.snapshot:type_name -> libstoragemgmt.FileSystemSnapShot
+	2,  // 15: libstoragemgmt.JobStatusReply.error:type_name -> libstoragemgmt.Error
+	1,  // 16: libstoragemgmt.Storage.PluginInfo:input_type -> libstoragemgmt.Empty
+	1,  // 17: libstoragemgmt.Storage.Systems:input_type -> libstoragemgmt.Empty
+	3,  // 18: libstoragemgmt.Storage.Pools:input_type -> libstoragemgmt.SearchRequest
+	3,  // 19: libstoragemgmt.Storage.Volumes:input_type -> libstoragemgmt.SearchRequest
+	1,  // 20: libstoragemgmt.Storage.Disks:input_type -> libstoragemgmt.Empty
+	3,  // 21: libstoragemgmt.Storage.FileSystems:input_type -> libstoragemgmt.SearchRequest
+	3,  // 22: libstoragemgmt.Storage.NfsExports:input_type -> libstoragemgmt.SearchRequest
+	1,  // 23: libstoragemgmt.Storage.AccessGroups:input_type -> libstoragemgmt.Empty
+	1,  // 24: libstoragemgmt.Storage.TargetPorts:input_type -> libstoragemgmt.Empty
+	1,  // 25: libstoragemgmt.Storage.Batteries:input_type -> libstoragemgmt.Empty
+	27, // 26: libstoragemgmt.Storage.VolumeCreate:input_type -> libstoragemgmt.VolumeCreateRequest
+	28, // 27: libstoragemgmt.Storage.VolumeResize:input_type -> libstoragemgmt.VolumeResizeRequest
+	29, // 28: libstoragemgmt.Storage.VolumeDelete:input_type -> libstoragemgmt.VolumeDeleteRequest
+	30, // 29: libstoragemgmt.Storage.VolumeMask:input_type -> libstoragemgmt.VolumeMaskRequest
+	30, // 30: libstoragemgmt.Storage.VolumeUnMask:input_type -> libstoragemgmt.VolumeMaskRequest
+	31, // 31: libstoragemgmt.Storage.AccessGroupCreate:input_type -> libstoragemgmt.AccessGroupCreateRequest
+	32, // 32: libstoragemgmt.Storage.AccessGroupDelete:input_type -> libstoragemgmt.AccessGroupDeleteRequest
+	33, // 33: libstoragemgmt.Storage.FsCreate:input_type -> libstoragemgmt.FsCreateRequest
+	34, // 34: libstoragemgmt.Storage.FsResize:input_type -> libstoragemgmt.FsResizeRequest
+	35, // 35: libstoragemgmt.Storage.FsDelete:input_type -> libstoragemgmt.FsDeleteRequest
+	36, // 36: libstoragemgmt.Storage.FsExport:input_type -> libstoragemgmt.FsExportRequest
+	37, // 37: libstoragemgmt.Storage.FsUnExport:input_type -> libstoragemgmt.FsUnExportRequest
+	38, // 38: libstoragemgmt.Storage.JobStatus:input_type -> libstoragemgmt.JobRequest
+	38, // 39: libstoragemgmt.Storage.JobFree:input_type -> libstoragemgmt.JobRequest
+	38, // 40: libstoragemgmt.Storage.JobWatch:input_type -> libstoragemgmt.JobRequest
+	4,  // 41: libstoragemgmt.Storage.PluginInfo:output_type -> libstoragemgmt.PluginInfo
+	15, // 42: libstoragemgmt.Storage.Systems:output_type -> libstoragemgmt.SystemsReply
+	16, // 43: libstoragemgmt.Storage.Pools:output_type -> libstoragemgmt.PoolsReply
+	17, // 44: libstoragemgmt.Storage.Volumes:output_type -> libstoragemgmt.VolumesReply
+	18, // 45: libstoragemgmt.Storage.Disks:output_type -> libstoragemgmt.DisksReply
+	19, // 46: libstoragemgmt.Storage.FileSystems:output_type -> libstoragemgmt.FileSystemsReply
+	20, // 47: libstoragemgmt.Storage.NfsExports:output_type -> libstoragemgmt.NfsExportsReply
+	21, // 48: libstoragemgmt.Storage.AccessGroups:output_type -> libstoragemgmt.AccessGroupsReply
+	22, // 49: libstoragemgmt.Storage.TargetPorts:output_type -> libstoragemgmt.TargetPortsReply
+	23, // 50: libstoragemgmt.Storage.Batteries:output_type -> libstoragemgmt.BatteriesReply
+	25, // 51: libstoragemgmt.Storage.VolumeCreate:output_type -> libstoragemgmt.VolumeReply
+	25, // 52: libstoragemgmt.Storage.VolumeResize:output_type -> libstoragemgmt.VolumeReply
+	24, // 53: libstoragemgmt.Storage.VolumeDelete:output_type -> libstoragemgmt.JobReply
+	1,  // 54: libstoragemgmt.Storage.VolumeMask:output_type -> libstoragemgmt.Empty
+	1,  // 55: libstoragemgmt.Storage.VolumeUnMask:output_type -> libstoragemgmt.Empty
+	12, // 56: libstoragemgmt.Storage.AccessGroupCreate:output_type -> libstoragemgmt.AccessGroup
+	1,  // 57: libstoragemgmt.Storage.AccessGroupDelete:output_type -> libstoragemgmt.Empty
+	26, // 58: libstoragemgmt.Storage.FsCreate:output_type -> libstoragemgmt.FileSystemReply
+	26, // 59: libstoragemgmt.Storage.FsResize:output_type -> libstoragemgmt.FileSystemReply
+	24, // 60: libstoragemgmt.Storage.FsDelete:output_type -> libstoragemgmt.JobReply
+	11, // 61: libstoragemgmt.Storage.FsExport:output_type -> libstoragemgmt.NfsExport
+	1,  // 62: libstoragemgmt.Storage.FsUnExport:output_type -> libstoragemgmt.Empty
+	39, // 63: libstoragemgmt.Storage.JobStatus:output_type -> libstoragemgmt.JobStatusReply
+	1,  // 64: libstoragemgmt.Storage.JobFree:output_type -> libstoragemgmt.Empty
+	39, // 65: libstoragemgmt.Storage.JobWatch:output_type -> libstoragemgmt.JobStatusReply
+	41, // [41:66] is the sub-list for method output_type
+	16, // [16:41] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_lsm_proto_init() }
+func file_lsm_proto_init() {
+	if File_lsm_proto != nil {
+		return
+	}
+	file_lsm_proto_msgTypes[4].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[5].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[6].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[7].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[8].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[9].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[10].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[11].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[12].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[13].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[35].OneofWrappers = []any{}
+	file_lsm_proto_msgTypes[38].OneofWrappers = []any{
+		(*JobStatusReply_Volume)(nil),
+		(*JobStatusReply_FileSystem)(nil),
+		(*JobStatusReply_Snapshot)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lsm_proto_rawDesc), len(file_lsm_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   39,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lsm_proto_goTypes,
+		DependencyIndexes: file_lsm_proto_depIdxs,
+		EnumInfos:         file_lsm_proto_enumTypes,
+		MessageInfos:      file_lsm_proto_msgTypes,
+	}.Build()
+	File_lsm_proto = out.File
+	file_lsm_proto_goTypes = nil
+	file_lsm_proto_depIdxs = nil
+}
@@ -0,0 +1,1047 @@
+// SPDX-License-Identifier: 0BSD
+
+// The storage API of libStorageMgmt as a gRPC service, the messages mirror
+// the types of data.go.  Enumerated values and bit fields use the numbers of
+// the corresponding Go types, eg. Pool.status holds lsm.PoolStatusType bits.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: lsm.proto
+
+package lsmgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Storage_PluginInfo_FullMethodName        = "/libstoragemgmt.Storage/PluginInfo"
+	Storage_Systems_FullMethodName           = "/libstoragemgmt.Storage/Systems"
+	Storage_Pools_FullMethodName             = "/libstoragemgmt.Storage/Pools"
+	Storage_Volumes_FullMethodName           = "/libstoragemgmt.Storage/Volumes"
+	Storage_Disks_FullMethodName             = "/libstoragemgmt.Storage/Disks"
+	Storage_FileSystems_FullMethodName       = "/libstoragemgmt.Storage/FileSystems"
+	Storage_NfsExports_FullMethodName        = "/libstoragemgmt.Storage/NfsExports"
+	Storage_AccessGroups_FullMethodName      = "/libstoragemgmt.Storage/AccessGroups"
+	Storage_TargetPorts_FullMethodName       = "/libstoragemgmt.Storage/TargetPorts"
+	Storage_Batteries_FullMethodName         = "/libstoragemgmt.Storage/Batteries"
+	Storage_VolumeCreate_FullMethodName      = "/libstoragemgmt.Storage/VolumeCreate"
+	Storage_VolumeResize_FullMethodName      = "/libstoragemgmt.Storage/VolumeResize"
+	Storage_VolumeDelete_FullMethodName      = "/libstoragemgmt.Storage/VolumeDelete"
+	Storage_VolumeMask_FullMethodName        = "/libstoragemgmt.Storage/VolumeMask"
+	Storage_VolumeUnMask_FullMethodName      = "/libstoragemgmt.Storage/VolumeUnMask"
+	Storage_AccessGroupCreate_FullMethodName = "/libstoragemgmt.Storage/AccessGroupCreate"
+	Storage_AccessGroupDelete_FullMethodName = "/libstoragemgmt.Storage/AccessGroupDelete"
+	Storage_FsCreate_FullMethodName          = "/libstoragemgmt.Storage/FsCreate"
+	Storage_FsResize_FullMethodName          = "/libstoragemgmt.Storage/FsResize"
+	Storage_FsDelete_FullMethodName          = "/libstoragemgmt.Storage/FsDelete"
+	Storage_FsExport_FullMethodName          = "/libstoragemgmt.Storage/FsExport"
+	Storage_FsUnExport_FullMethodName        = "/libstoragemgmt.Storage/FsUnExport"
+	Storage_JobStatus_FullMethodName         = "/libstoragemgmt.Storage/JobStatus"
+	Storage_JobFree_FullMethodName           = "/libstoragemgmt.Storage/JobFree"
+	Storage_JobWatch_FullMethodName          = "/libstoragemgmt.Storage/JobWatch"
+)
+
+// StorageClient is the client API for Storage service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StorageClient interface {
+	PluginInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginInfo, error)
+	Systems(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemsReply, error)
+	Pools(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*PoolsReply, error)
+	Volumes(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*VolumesReply, error)
+	Disks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DisksReply, error)
+	FileSystems(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*FileSystemsReply, error)
+	NfsExports(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*NfsExportsReply, error)
+	AccessGroups(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccessGroupsReply, error)
+	TargetPorts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TargetPortsReply, error)
+	Batteries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BatteriesReply, error)
+	VolumeCreate(ctx context.Context, in *VolumeCreateRequest, opts ...grpc.CallOption) (*VolumeReply, error)
+	VolumeResize(ctx context.Context, in *VolumeResizeRequest, opts ...grpc.CallOption) (*VolumeReply, error)
+	VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*JobReply, error)
+	VolumeMask(ctx context.Context, in *VolumeMaskRequest, opts ...grpc.CallOption) (*Empty, error)
+	VolumeUnMask(ctx context.Context, in *VolumeMaskRequest, opts ...grpc.CallOption) (*Empty, error)
+	AccessGroupCreate(ctx context.Context, in *AccessGroupCreateRequest, opts ...grpc.CallOption) (*AccessGroup, error)
+	AccessGroupDelete(ctx context.Context, in *AccessGroupDeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	FsCreate(ctx context.Context, in *FsCreateRequest, opts ...grpc.CallOption) (*FileSystemReply, error)
+	FsResize(ctx context.Context, in *FsResizeRequest, opts ...grpc.CallOption) (*FileSystemReply, error)
+	FsDelete(ctx context.Context, in *FsDeleteRequest, opts ...grpc.CallOption) (*JobReply, error)
+	FsExport(ctx context.Context, in *FsExportRequest, opts ...grpc.CallOption) (*NfsExport, error)
+	FsUnExport(ctx context.Context, in *FsUnExportRequest, opts ...grpc.CallOption) (*Empty, error)
+	JobStatus(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*JobStatusReply, error)
+	JobFree(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*Empty, error)
+	// JobWatch sends the status of the job whenever its progress changes, the
+	// last message has the outcome.  A finished job has been freed.
+	JobWatch(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobStatusReply], error)
+}
+
+type storageClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStorageClient(cc grpc.ClientConnInterface) StorageClient {
+	return &storageClient{cc}
+}
+
+func (c *storageClient) PluginInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PluginInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginInfo)
+	err := c.cc.Invoke(ctx, Storage_PluginInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Systems(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemsReply)
+	err := c.cc.Invoke(ctx, Storage_Systems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Pools(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*PoolsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolsReply)
+	err := c.cc.Invoke(ctx, Storage_Pools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Volumes(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*VolumesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumesReply)
+	err := c.cc.Invoke(ctx, Storage_Volumes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Disks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DisksReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisksReply)
+	err := c.cc.Invoke(ctx, Storage_Disks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) FileSystems(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*FileSystemsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileSystemsReply)
+	err := c.cc.Invoke(ctx, Storage_FileSystems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) NfsExports(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*NfsExportsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NfsExportsReply)
+	err := c.cc.Invoke(ctx, Storage_NfsExports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) AccessGroups(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccessGroupsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessGroupsReply)
+	err := c.cc.Invoke(ctx, Storage_AccessGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) TargetPorts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TargetPortsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TargetPortsReply)
+	err := c.cc.Invoke(ctx, Storage_TargetPorts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) Batteries(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BatteriesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatteriesReply)
+	err := c.cc.Invoke(ctx, Storage_Batteries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) VolumeCreate(ctx context.Context, in *VolumeCreateRequest, opts ...grpc.CallOption) (*VolumeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeReply)
+	err := c.cc.Invoke(ctx, Storage_VolumeCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) VolumeResize(ctx context.Context, in *VolumeResizeRequest, opts ...grpc.CallOption) (*VolumeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeReply)
+	err := c.cc.Invoke(ctx, Storage_VolumeResize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*JobReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobReply)
+	err := c.cc.Invoke(ctx, Storage_VolumeDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) VolumeMask(ctx context.Context, in *VolumeMaskRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Storage_VolumeMask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) VolumeUnMask(ctx context.Context, in *VolumeMaskRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Storage_VolumeUnMask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) AccessGroupCreate(ctx context.Context, in *AccessGroupCreateRequest, opts ...grpc.CallOption) (*AccessGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessGroup)
+	err := c.cc.Invoke(ctx, Storage_AccessGroupCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) AccessGroupDelete(ctx context.Context, in *AccessGroupDeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Storage_AccessGroupDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) FsCreate(ctx context.Context, in *FsCreateRequest, opts ...grpc.CallOption) (*FileSystemReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileSystemReply)
+	err := c.cc.Invoke(ctx, Storage_FsCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) FsResize(ctx context.Context, in *FsResizeRequest, opts ...grpc.CallOption) (*FileSystemReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileSystemReply)
+	err := c.cc.Invoke(ctx, Storage_FsResize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) FsDelete(ctx context.Context, in *FsDeleteRequest, opts ...grpc.CallOption) (*JobReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobReply)
+	err := c.cc.Invoke(ctx, Storage_FsDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) FsExport(ctx context.Context, in *FsExportRequest, opts ...grpc.CallOption) (*NfsExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NfsExport)
+	err := c.cc.Invoke(ctx, Storage_FsExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) FsUnExport(ctx context.Context, in *FsUnExportRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Storage_FsUnExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) JobStatus(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*JobStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobStatusReply)
+	err := c.cc.Invoke(ctx, Storage_JobStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) JobFree(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Storage_JobFree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) JobWatch(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobStatusReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Storage_ServiceDesc.Streams[0], Storage_JobWatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JobRequest, JobStatusReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Storage_JobWatchClient = grpc.ServerStreamingClient[JobStatusReply]
+
+// StorageServer is the server API for Storage service.
+// All implementations must embed UnimplementedStorageServer
+// for forward compatibility.
+type StorageServer interface {
+	PluginInfo(context.Context, *Empty) (*PluginInfo, error)
+	Systems(context.Context, *Empty) (*SystemsReply, error)
+	Pools(context.Context, *SearchRequest) (*PoolsReply, error)
+	Volumes(context.Context, *SearchRequest) (*VolumesReply, error)
+	Disks(context.Context, *Empty) (*DisksReply, error)
+	FileSystems(context.Context, *SearchRequest) (*FileSystemsReply, error)
+	NfsExports(context.Context, *SearchRequest) (*NfsExportsReply, error)
+	AccessGroups(context.Context, *Empty) (*AccessGroupsReply, error)
+	TargetPorts(context.Context, *Empty) (*TargetPortsReply, error)
+	Batteries(context.Context, *Empty) (*BatteriesReply, error)
+	VolumeCreate(context.Context, *VolumeCreateRequest) (*VolumeReply, error)
+	VolumeResize(context.Context, *VolumeResizeRequest) (*VolumeReply, error)
+	VolumeDelete(context.Context, *VolumeDeleteRequest) (*JobReply, error)
+	VolumeMask(context.Context, *VolumeMaskRequest) (*Empty, error)
+	VolumeUnMask(context.Context, *VolumeMaskRequest) (*Empty, error)
+	AccessGroupCreate(context.Context, *AccessGroupCreateRequest) (*AccessGroup, error)
+	AccessGroupDelete(context.Context, *AccessGroupDeleteRequest) (*Empty, error)
+	FsCreate(context.Context, *FsCreateRequest) (*FileSystemReply, error)
+	FsResize(context.Context, *FsResizeRequest) (*FileSystemReply, error)
+	FsDelete(context.Context, *FsDeleteRequest) (*JobReply, error)
+	FsExport(context.Context, *FsExportRequest) (*NfsExport, error)
+	FsUnExport(context.Context, *FsUnExportRequest) (*Empty, error)
+	JobStatus(context.Context, *JobRequest) (*JobStatusReply, error)
+	JobFree(context.Context, *JobRequest) (*Empty, error)
+	// JobWatch sends the status of the job whenever its progress changes, the
+	// last message has the outcome.  A finished job has been freed.
+	JobWatch(*JobRequest, grpc.ServerStreamingServer[JobStatusReply]) error
+	mustEmbedUnimplementedStorageServer()
+}
+
+// UnimplementedStorageServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStorageServer struct{}
+
+func (UnimplementedStorageServer) PluginInfo(context.Context, *Empty) (*PluginInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PluginInfo not implemented")
+}
+func (UnimplementedStorageServer) Systems(context.Context, *Empty) (*SystemsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Systems not implemented")
+}
+func (UnimplementedStorageServer) Pools(context.Context, *SearchRequest) (*PoolsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pools not implemented")
+}
+func (UnimplementedStorageServer) Volumes(context.Context, *SearchRequest) (*VolumesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Volumes not implemented")
+}
+func (UnimplementedStorageServer) Disks(context.Context, *Empty) (*DisksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disks not implemented")
+}
+func (UnimplementedStorageServer) FileSystems(context.Context, *SearchRequest) (*FileSystemsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileSystems not implemented")
+}
+func (UnimplementedStorageServer) NfsExports(context.Context, *SearchRequest) (*NfsExportsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NfsExports not implemented")
+}
+func (UnimplementedStorageServer) AccessGroups(context.Context, *Empty) (*AccessGroupsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccessGroups not implemented")
+}
+func (UnimplementedStorageServer) TargetPorts(context.Context, *Empty) (*TargetPortsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TargetPorts not implemented")
+}
+func (UnimplementedStorageServer) Batteries(context.Context, *Empty) (*BatteriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Batteries not implemented")
+}
+func (UnimplementedStorageServer) VolumeCreate(context.Context, *VolumeCreateRequest) (*VolumeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeCreate not implemented")
+}
+func (UnimplementedStorageServer) VolumeResize(context.Context, *VolumeResizeRequest) (*VolumeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeResize not implemented")
+}
+func (UnimplementedStorageServer) VolumeDelete(context.Context, *VolumeDeleteRequest) (*JobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeDelete not implemented")
+}
+func (UnimplementedStorageServer) VolumeMask(context.Context, *VolumeMaskRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeMask not implemented")
+}
+func (UnimplementedStorageServer) VolumeUnMask(context.Context, *VolumeMaskRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeUnMask not implemented")
+}
+func (UnimplementedStorageServer) AccessGroupCreate(context.Context, *AccessGroupCreateRequest) (*AccessGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccessGroupCreate not implemented")
+}
+func (UnimplementedStorageServer) AccessGroupDelete(context.Context, *AccessGroupDeleteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccessGroupDelete not implemented")
+}
+func (UnimplementedStorageServer) FsCreate(context.Context, *FsCreateRequest) (*FileSystemReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FsCreate not implemented")
+}
+func (UnimplementedStorageServer) FsResize(context.Context, *FsResizeRequest) (*FileSystemReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FsResize not implemented")
+}
+func (UnimplementedStorageServer) FsDelete(context.Context, *FsDeleteRequest) (*JobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FsDelete not implemented")
+}
+func (UnimplementedStorageServer) FsExport(context.Context, *FsExportRequest) (*NfsExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FsExport not implemented")
+}
+func (UnimplementedStorageServer) FsUnExport(context.Context, *FsUnExportRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FsUnExport not implemented")
+}
+func (UnimplementedStorageServer) JobStatus(context.Context, *JobRequest) (*JobStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JobStatus not implemented")
+}
+func (UnimplementedStorageServer) JobFree(context.Context, *JobRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JobFree not implemented")
+}
+func (UnimplementedStorageServer) JobWatch(*JobRequest, grpc.ServerStreamingServer[JobStatusReply]) error {
+	return status.Errorf(codes.Unimplemented, "method JobWatch not implemented")
+}
+func (UnimplementedStorageServer) mustEmbedUnimplementedStorageServer() {}
+func (UnimplementedStorageServer) testEmbeddedByValue()                 {}
+
+// UnsafeStorageServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StorageServer will
+// result in compilation errors.
+type UnsafeStorageServer interface {
+	mustEmbedUnimplementedStorageServer()
+}
+
+func RegisterStorageServer(s grpc.ServiceRegistrar, srv StorageServer) {
+	// If the following call pancis, it indicates UnimplementedStorageServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Storage_ServiceDesc, srv)
+}
+
+func _Storage_PluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).PluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_PluginInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).PluginInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Systems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Systems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_Systems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Systems(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Pools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Pools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_Pools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Pools(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Volumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Volumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_Volumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Volumes(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Disks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Disks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_Disks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Disks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_FileSystems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).FileSystems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_FileSystems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).FileSystems(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_NfsExports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).NfsExports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_NfsExports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).NfsExports(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_AccessGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).AccessGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_AccessGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).AccessGroups(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_TargetPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).TargetPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_TargetPorts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).TargetPorts(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_Batteries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Batteries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_Batteries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Batteries(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_VolumeCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).VolumeCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_VolumeCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).VolumeCreate(ctx, req.(*VolumeCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_VolumeResize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeResizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).VolumeResize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_VolumeResize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).VolumeResize(ctx, req.(*VolumeResizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_VolumeDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).VolumeDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_VolumeDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).VolumeDelete(ctx, req.(*VolumeDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_VolumeMask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).VolumeMask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_VolumeMask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).VolumeMask(ctx, req.(*VolumeMaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_VolumeUnMask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).VolumeUnMask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_VolumeUnMask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).VolumeUnMask(ctx, req.(*VolumeMaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_AccessGroupCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessGroupCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).AccessGroupCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_AccessGroupCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).AccessGroupCreate(ctx, req.(*AccessGroupCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_AccessGroupDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessGroupDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).AccessGroupDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_AccessGroupDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).AccessGroupDelete(ctx, req.(*AccessGroupDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_FsCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).FsCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_FsCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).FsCreate(ctx, req.(*FsCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_FsResize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsResizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).FsResize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_FsResize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).FsResize(ctx, req.(*FsResizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_FsDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).FsDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_FsDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).FsDelete(ctx, req.(*FsDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_FsExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).FsExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_FsExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).FsExport(ctx, req.(*FsExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_FsUnExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsUnExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).FsUnExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_FsUnExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).FsUnExport(ctx, req.(*FsUnExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_JobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).JobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_JobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).JobStatus(ctx, req.(*JobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_JobFree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).JobFree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_JobFree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).JobFree(ctx, req.(*JobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_JobWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).JobWatch(m, &grpc.GenericServerStream[JobRequest, JobStatusReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Storage_JobWatchServer = grpc.ServerStreamingServer[JobStatusReply]
+
+// Storage_ServiceDesc is the grpc.ServiceDesc for Storage service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Storage_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "libstoragemgmt.Storage",
+	HandlerType: (*StorageServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PluginInfo",
+			Handler:    _Storage_PluginInfo_Handler,
+		},
+		{
+			MethodName: "Systems",
+			Handler:    _Storage_Systems_Handler,
+		},
+		{
+			MethodName: "Pools",
+			Handler:    _Storage_Pools_Handler,
+		},
+		{
+			MethodName: "Volumes",
+			Handler:    _Storage_Volumes_Handler,
+		},
+		{
+			MethodName: "Disks",
+			Handler:    _Storage_Disks_Handler,
+		},
+		{
+			MethodName: "FileSystems",
+			Handler:    _Storage_FileSystems_Handler,
+		},
+		{
+			MethodName: "NfsExports",
+			Handler:    _Storage_NfsExports_Handler,
+		},
+		{
+			MethodName: "AccessGroups",
+			Handler:    _Storage_AccessGroups_Handler,
+		},
+		{
+			MethodName: "TargetPorts",
+			Handler:    _Storage_TargetPorts_Handler,
+		},
+		{
+			MethodName: "Batteries",
+			Handler:    _Storage_Batteries_Handler,
+		},
+		{
+			MethodName: "VolumeCreate",
+			Handler:    _Storage_VolumeCreate_Handler,
+		},
+		{
+			MethodName: "VolumeResize",
+			Handler:    _Storage_VolumeResize_Handler,
+		},
+		{
+			MethodName: "VolumeDelete",
+			Handler:    _Storage_VolumeDelete_Handler,
+		},
+		{
+			MethodName: "VolumeMask",
+			Handler:    _Storage_VolumeMask_Handler,
+		},
+		{
+			MethodName: "VolumeUnMask",
+			Handler:    _Storage_VolumeUnMask_Handler,
+		},
+		{
+			MethodName: "AccessGroupCreate",
+			Handler:    _Storage_AccessGroupCreate_Handler,
+		},
+		{
+			MethodName: "AccessGroupDelete",
+			Handler:    _Storage_AccessGroupDelete_Handler,
+		},
+		{
+			MethodName: "FsCreate",
+			Handler:    _Storage_FsCreate_Handler,
+		},
+		{
+			MethodName: "FsResize",
+			Handler:    _Storage_FsResize_Handler,
+		},
+		{
+			MethodName: "FsDelete",
+			Handler:    _Storage_FsDelete_Handler,
+		},
+		{
+			MethodName: "FsExport",
+			Handler:    _Storage_FsExport_Handler,
+		},
+		{
+			MethodName: "FsUnExport",
+			Handler:    _Storage_FsUnExport_Handler,
+		},
+		{
+			MethodName: "JobStatus",
+			Handler:    _Storage_JobStatus_Handler,
+		},
+		{
+			MethodName: "JobFree",
+			Handler:    _Storage_JobFree_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "JobWatch",
+			Handler:       _Storage_JobWatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "lsm.proto",
+}
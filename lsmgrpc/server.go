@@ -0,0 +1,546 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package lsmgrpc serves the storage API over gRPC, the service is defined in
+// lsm.proto for generating clients in other languages.
+//
+// Calls name the items they act on by ID.  Failed calls return a status with
+// the closest gRPC code and the LsmError as Error detail, see LsmError.
+package lsmgrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative lsm.proto
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Server implements StorageServer using a storage client, which must be safe
+// for concurrent use as a ClientConnection is.
+type Server struct {
+	UnimplementedStorageServer
+	client lsm.StorageClient
+}
+
+// NewServer returns a Server using client, register it with
+// RegisterStorageServer.
+func NewServer(client lsm.StorageClient) *Server {
+	return &Server{client: client}
+}
+
+// PluginInfo returns the plugin information.
+func (s *Server) PluginInfo(ctx context.Context, _ *Empty) (*PluginInfo, error) {
+	info, err := s.client.PluginInfoCtx(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &PluginInfo{Version: info.Version, Description: info.Description, Name: info.Name}, nil
+}
+
+// Systems lists the systems.
+func (s *Server) Systems(ctx context.Context, _ *Empty) (*SystemsReply, error) {
+	systems, err := s.client.SystemsCtx(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &SystemsReply{}
+	for i := range systems {
+		reply.Systems = append(reply.Systems, fromSystem(&systems[i]))
+	}
+	return reply, nil
+}
+
+// Pools lists the pools.
+func (s *Server) Pools(ctx context.Context, req *SearchRequest) (*PoolsReply, error) {
+	pools, err := s.client.PoolsCtx(ctx, search(req)...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &PoolsReply{}
+	for i := range pools {
+		reply.Pools = append(reply.Pools, fromPool(&pools[i]))
+	}
+	return reply, nil
+}
+
+// Volumes lists the volumes.
+func (s *Server) Volumes(ctx context.Context, req *SearchRequest) (*VolumesReply, error) {
+	volumes, err := s.client.VolumesCtx(ctx, search(req)...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &VolumesReply{}
+	for i := range volumes {
+		reply.Volumes = append(reply.Volumes, fromVolume(&volumes[i]))
+	}
+	return reply, nil
+}
+
+// Disks lists the disks.
+func (s *Server) Disks(ctx context.Context, _ *Empty) (*DisksReply, error) {
+	disks, err := s.client.DisksCtx(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &DisksReply{}
+	for i := range disks {
+		reply.Disks = append(reply.Disks, fromDisk(&disks[i]))
+	}
+	return reply, nil
+}
+
+// FileSystems lists the file systems.
+func (s *Server) FileSystems(ctx context.Context, req *SearchRequest) (*FileSystemsReply, error) {
+	fileSystems, err := s.client.FileSystemsCtx(ctx, search(req)...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &FileSystemsReply{}
+	for i := range fileSystems {
+		reply.FileSystems = append(reply.FileSystems, fromFileSystem(&fileSystems[i]))
+	}
+	return reply, nil
+}
+
+// NfsExports lists the NFS exports.
+func (s *Server) NfsExports(ctx context.Context, req *SearchRequest) (*NfsExportsReply, error) {
+	exports, err := s.client.NfsExportsCtx(ctx, search(req)...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &NfsExportsReply{}
+	for i := range exports {
+		reply.Exports = append(reply.Exports, fromNfsExport(&exports[i]))
+	}
+	return reply, nil
+}
+
+// AccessGroups lists the access groups.
+func (s *Server) AccessGroups(ctx context.Context, _ *Empty) (*AccessGroupsReply, error) {
+	groups, err := s.client.AccessGroupsCtx(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &AccessGroupsReply{}
+	for i := range groups {
+		reply.AccessGroups = append(reply.AccessGroups, fromAccessGroup(&groups[i]))
+	}
+	return reply, nil
+}
+
+// TargetPorts lists the target ports.
+func (s *Server) TargetPorts(ctx context.Context, _ *Empty) (*TargetPortsReply, error) {
+	ports, err := s.client.TargetPortsCtx(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &TargetPortsReply{}
+	for i := range ports {
+		reply.TargetPorts = append(reply.TargetPorts, fromTargetPort(&ports[i]))
+	}
+	return reply, nil
+}
+
+// Batteries lists the batteries.
+func (s *Server) Batteries(ctx context.Context, _ *Empty) (*BatteriesReply, error) {
+	batteries, err := s.client.BatteriesCtx(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &BatteriesReply{}
+	for i := range batteries {
+		reply.Batteries = append(reply.Batteries, fromBattery(&batteries[i]))
+	}
+	return reply, nil
+}
+
+// VolumeCreate creates a volume.
+func (s *Server) VolumeCreate(ctx context.Context, req *VolumeCreateRequest) (*VolumeReply, error) {
+	pool, err := s.pool(ctx, req.PoolId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	provisioning := lsm.VolumeProvisionType(req.Provisioning)
+	if provisioning == 0 {
+		provisioning = lsm.VolumeProvisionTypeDefault
+	}
+
+	volume, job, err := s.client.VolumeCreateCtx(ctx, pool, req.Name, req.SizeBytes, provisioning, req.Sync)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &VolumeReply{Volume: fromVolume(volume), JobId: jobID(job)}, nil
+}
+
+// VolumeResize resizes a volume.
+func (s *Server) VolumeResize(ctx context.Context, req *VolumeResizeRequest) (*VolumeReply, error) {
+	volume, err := s.volume(ctx, req.VolumeId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resized, job, err := s.client.VolumeResizeCtx(ctx, volume, req.SizeBytes, req.Sync)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &VolumeReply{Volume: fromVolume(resized), JobId: jobID(job)}, nil
+}
+
+// VolumeDelete deletes a volume.
+func (s *Server) VolumeDelete(ctx context.Context, req *VolumeDeleteRequest) (*JobReply, error) {
+	volume, err := s.volume(ctx, req.VolumeId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	job, err := s.client.VolumeDeleteCtx(ctx, volume, req.Sync)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &JobReply{JobId: jobID(job)}, nil
+}
+
+// VolumeMask grants the access group access to the volume.
+func (s *Server) VolumeMask(ctx context.Context, req *VolumeMaskRequest) (*Empty, error) {
+	volume, ag, err := s.volumeAndGroup(ctx, req.VolumeId, req.AccessGroupId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.client.VolumeMaskCtx(ctx, volume, ag); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+// VolumeUnMask revokes the access of the access group to the volume.
+func (s *Server) VolumeUnMask(ctx context.Context, req *VolumeMaskRequest) (*Empty, error) {
+	volume, ag, err := s.volumeAndGroup(ctx, req.VolumeId, req.AccessGroupId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.client.VolumeUnMaskCtx(ctx, volume, ag); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+// AccessGroupCreate creates an access group with one initiator.
+func (s *Server) AccessGroupCreate(ctx context.Context, req *AccessGroupCreateRequest) (*AccessGroup, error) {
+	system, err := s.system(ctx, req.SystemId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	ag, err := s.client.AccessGroupCreateCtx(ctx, req.Name, req.InitId, lsm.InitiatorType(req.InitType), system)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return fromAccessGroup(ag), nil
+}
+
+// AccessGroupDelete deletes an access group.
+func (s *Server) AccessGroupDelete(ctx context.Context, req *AccessGroupDeleteRequest) (*Empty, error) {
+	ag, err := s.accessGroup(ctx, req.AccessGroupId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.client.AccessGroupDeleteCtx(ctx, ag); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+// FsCreate creates a file system.
+func (s *Server) FsCreate(ctx context.Context, req *FsCreateRequest) (*FileSystemReply, error) {
+	pool, err := s.pool(ctx, req.PoolId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	fs, job, err := s.client.FsCreateCtx(ctx, pool, req.Name, req.SizeBytes, req.Sync)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &FileSystemReply{FileSystem: fromFileSystem(fs), JobId: jobID(job)}, nil
+}
+
+// FsResize resizes a file system.
+func (s *Server) FsResize(ctx context.Context, req *FsResizeRequest) (*FileSystemReply, error) {
+	fs, err := s.fs(ctx, req.FsId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resized, job, err := s.client.FsResizeCtx(ctx, fs, req.SizeBytes, req.Sync)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &FileSystemReply{FileSystem: fromFileSystem(resized), JobId: jobID(job)}, nil
+}
+
+// FsDelete deletes a file system.
+func (s *Server) FsDelete(ctx context.Context, req *FsDeleteRequest) (*JobReply, error) {
+	fs, err := s.fs(ctx, req.FsId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	job, err := s.client.FsDeleteCtx(ctx, fs, req.Sync)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &JobReply{JobId: jobID(job)}, nil
+}
+
+// FsExport exports a file system over NFS.
+func (s *Server) FsExport(ctx context.Context, req *FsExportRequest) (*NfsExport, error) {
+	fs, err := s.fs(ctx, req.FsId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	access := lsm.NfsAccess{
+		Root:    req.Root,
+		Rw:      req.Rw,
+		Ro:      req.Ro,
+		AnonUID: lsm.AnonUIDGIDNotApplicable,
+		AnonGID: lsm.AnonUIDGIDNotApplicable,
+	}
+	if req.AnonUid != nil {
+		access.AnonUID = *req.AnonUid
+	}
+	if req.AnonGid != nil {
+		access.AnonGID = *req.AnonGid
+	}
+
+	export, err := s.client.FsExportCtx(ctx, fs, req.ExportPath, &access, req.AuthType, req.Options)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return fromNfsExport(export), nil
+}
+
+// FsUnExport removes an NFS export.
+func (s *Server) FsUnExport(ctx context.Context, req *FsUnExportRequest) (*Empty, error) {
+	exports, err := s.client.NfsExportsBy(ctx, lsm.ByID(req.ExportId))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if len(exports) == 0 {
+		return nil, toStatus(notFound(errors.NotFoundNfsExport, "export", req.ExportId))
+	}
+	if err := s.client.FsUnExportCtx(ctx, &exports[0]); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+// JobStatus returns the status of a job, a failed job is reported in the
+// reply rather than as error.
+func (s *Server) JobStatus(ctx context.Context, req *JobRequest) (*JobStatusReply, error) {
+	var result json.RawMessage
+	jobStatus, percent, err := s.client.JobStatusCtx(ctx, req.JobId, &result)
+	if code, _ := errors.CodeOf(err); code == errors.NotFoundJob || errors.IsRetryable(err) {
+		return nil, toStatus(err)
+	}
+	return jobReply(req.JobId, jobStatus, percent, result, err), nil
+}
+
+// JobFree frees a job.
+func (s *Server) JobFree(ctx context.Context, req *JobRequest) (*Empty, error) {
+	if err := s.client.JobFreeCtx(ctx, req.JobId); err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, nil
+}
+
+// JobWatch streams the progress of a job until it finishes.
+func (s *Server) JobWatch(req *JobRequest, stream Storage_JobWatchServer) error {
+	ctx := stream.Context()
+
+	var result json.RawMessage
+	job := lsm.NewJob(s.client, req.JobId, &result)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- job.Wait(ctx)
+	}()
+
+	// Wait returns without finishing the job when the stream goes away, so
+	// the progress channel can't be relied on to be closed
+	var err error
+	progress := job.Progress()
+	for progress != nil {
+		select {
+		case <-ctx.Done():
+			return toStatus(ctx.Err())
+		case err = <-done:
+			progress = nil
+		case percent, ok := <-progress:
+			if !ok {
+				err = <-done
+				progress = nil
+				break
+			}
+			if percent == 100 {
+				// Reported with the outcome
+				continue
+			}
+			reply := &JobStatusReply{JobId: req.JobId, Status: JobStatus_JOB_STATUS_INPROGRESS, Percent: uint32(percent)}
+			if sendErr := stream.Send(reply); sendErr != nil {
+				return sendErr
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return toStatus(ctx.Err())
+	}
+	if code, _ := errors.CodeOf(err); code == errors.NotFoundJob || errors.IsRetryable(err) {
+		return toStatus(err)
+	}
+
+	jobStatus := lsm.JobStatusComplete
+	if err != nil {
+		jobStatus = lsm.JobStatusError
+	}
+	return stream.Send(jobReply(req.JobId, jobStatus, 100, result, err))
+}
+
+func search(req *SearchRequest) []string {
+	if req == nil || len(req.Key) == 0 {
+		return nil
+	}
+	return []string{req.Key, req.Value}
+}
+
+func jobID(job *string) string {
+	if job == nil {
+		return ""
+	}
+	return *job
+}
+
+// jobReply builds the reply for a job, the result is told apart by the class
+// the plugin gave it.
+func jobReply(id string, jobStatus lsm.JobStatusType, percent uint8, result json.RawMessage, err error) *JobStatusReply {
+	reply := &JobStatusReply{JobId: id, Status: JobStatus(jobStatus), Percent: uint32(percent)}
+	if err != nil {
+		e := lsmError(err)
+		reply.Status = JobStatus_JOB_STATUS_ERROR
+		reply.Error = &Error{Code: e.Code, Message: e.Message, Data: e.Data}
+		return reply
+	}
+	if len(result) == 0 {
+		return reply
+	}
+
+	var class struct {
+		Class string `json:"class"`
+	}
+	if json.Unmarshal(result, &class) != nil {
+		return reply
+	}
+
+	switch class.Class {
+	case "Volume":
+		var v lsm.Volume
+		if json.Unmarshal(result, &v) == nil {
+			reply.Result = &JobStatusReply_Volume{Volume: fromVolume(&v)}
+		}
+	case "FileSystem":
+		var fs lsm.FileSystem
+		if json.Unmarshal(result, &fs) == nil {
+			reply.Result = &JobStatusReply_FileSystem{FileSystem: fromFileSystem(&fs)}
+		}
+	case "FsSnapshot":
+		var ss lsm.FileSystemSnapShot
+		if json.Unmarshal(result, &ss) == nil {
+			reply.Result = &JobStatusReply_Snapshot{Snapshot: fromSnapShot(&ss)}
+		}
+	}
+	return reply
+}
+
+// LsmError returns the LsmError carried by the status of a failed call, or
+// nil if err has none.
+func LsmError(err error) *errors.LsmError {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, detail := range st.Details() {
+		if e, ok := detail.(*Error); ok {
+			return &errors.LsmError{Code: e.Code, Message: e.Message, Data: e.Data}
+		}
+	}
+	return nil
+}
+
+// lsmError returns err as an LsmError, other errors are library bugs.
+func lsmError(err error) *errors.LsmError {
+	if e, ok := err.(*errors.LsmError); ok {
+		return e
+	}
+	if code, ok := errors.CodeOf(err); ok {
+		return &errors.LsmError{Code: code, Message: err.Error()}
+	}
+	return &errors.LsmError{Code: errors.LibBug, Message: err.Error()}
+}
+
+func toStatus(err error) error {
+	switch err {
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	e := lsmError(err)
+	st, detailErr := status.New(grpcCode(e.Code), e.Message).WithDetails(
+		&Error{Code: e.Code, Message: e.Message, Data: e.Data})
+	if detailErr != nil {
+		return status.Error(grpcCode(e.Code), e.Message)
+	}
+	return st.Err()
+}
+
+// grpcCode maps the error codes to the closest gRPC code.
+func grpcCode(code int32) codes.Code {
+	switch {
+	case code >= errors.NotFoundAccessGroup && code <= errors.NotFoundDisk:
+		return codes.NotFound
+	}
+
+	switch code {
+	case errors.InvalidArgument, errors.TransPortInvalidArg, errors.UnsupportedSearchKey:
+		return codes.InvalidArgument
+	case errors.PermissionDenied, errors.PluginSocketPermission, errors.NotLicensed:
+		return codes.PermissionDenied
+	case errors.PluginAuthFailed:
+		return codes.Unauthenticated
+	case errors.NameConflict, errors.ExistsInitiator:
+		return codes.AlreadyExists
+	case errors.IsMasked, errors.HasChildDependency, errors.NoStateChange, errors.LastInitInAccessGroup,
+		errors.EmptyAccessGroup, errors.PoolNotReady, errors.DiskNotFree,
+		errors.NoSupportOnlineChange, errors.NoSupportOfflineChange:
+		return codes.FailedPrecondition
+	case errors.NotEnoughSpace, errors.NoMemory:
+		return codes.ResourceExhausted
+	case errors.NoSupport:
+		return codes.Unimplemented
+	case errors.TimeOut:
+		return codes.DeadlineExceeded
+	case errors.NetworkConRefused, errors.NetworkHostDown, errors.NetworkError,
+		errors.PluginIpcFail, errors.DameonNotRunning, errors.PluginNotExist,
+		errors.TransPortComunication:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
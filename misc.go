@@ -3,6 +3,7 @@
 package libstoragemgmt
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -54,7 +55,7 @@ func checkDaemonExists() bool {
 	}
 
 	for _, pluginPath := range getPlugins(udsPath) {
-		var trans, err = newTransport(pluginPath, false)
+		var trans, err = newTransport(context.Background(), pluginPath, false)
 		if err == nil {
 			present = true
 			trans.close()
@@ -4,9 +4,12 @@ package libstoragemgmt
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"os"
+	"runtime"
 	"strconv"
+	"strings"
 
 	errors "github.com/libstorage/libstoragemgmt-golang/errors"
 )
@@ -312,7 +315,7 @@ type handler func(p *Plugin, params *requestMsg) (interface{}, error)
 
 // Plugin represents plugin
 type Plugin struct {
-	tp        transPort
+	tp        *transPort
 	cb        *PluginCallBacks
 	callTable map[string]handler
 	desc      string
@@ -353,58 +356,109 @@ func PluginInit(callbacks *PluginCallBacks, cmdLineArgs []string, desc string, v
 			return nil, err
 		}
 
-		tp := transPort{uds: s, debug: false}
-		return &Plugin{tp: tp, cb: callbacks, callTable: buildTable(callbacks), desc: desc, ver: ver}, nil
+		return PluginInitConn(callbacks, s, desc, ver), nil
 	}
 	return nil, &errors.LsmError{
 		Code:    errors.LibBug,
 		Message: fmt.Sprintf("Plugin called with invalid args: %s\n", cmdLineArgs)}
 }
 
-func noSupport(tp *transPort, method string) {
-	tp.sendError(&errors.LsmError{
+// PluginInitConn initializes the plugin with the specified callbacks to serve the
+// client on the other end of conn, eg. one accepted from a listener or one end
+// of a pipe.
+func PluginInitConn(callbacks *PluginCallBacks, conn io.ReadWriteCloser, desc string, ver string) *Plugin {
+	tp := &transPort{conn: conn, debug: false}
+	return &Plugin{tp: tp, cb: callbacks, callTable: buildTable(callbacks), desc: desc, ver: ver}
+}
+
+func noSupport(tp *transPort, id int, method string) {
+	tp.sendError(id, &errors.LsmError{
 		Code: errors.NoSupport,
 		Message: fmt.Sprintf(
 			"method %s not supported", method)})
 }
 
-// Run the plugin, looping processing requests and sending responses.
+// panicError describes a recovered panic in what, with a summary of the stack
+// of the panicking goroutine in Data.
+func panicError(what string, r interface{}) *errors.LsmError {
+	const maxFrames = 16
+	pc := make([]uintptr, maxFrames)
+	// Skip runtime.Callers, panicError and the deferred function
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+
+	var stack []string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+
+	return &errors.LsmError{
+		Code:    errors.PluginBug,
+		Message: fmt.Sprintf("%s panicked: %v", what, r),
+		Data:    strings.Join(stack, "\n")}
+}
+
+// call runs the handler for the request, turning a panic into an error so one
+// bad callback doesn't take the plugin down.
+func (p *Plugin) call(f handler, request *requestMsg) (response interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			response = nil
+			err = panicError(request.Method, r)
+		}
+	}()
+	return f(p, request)
+}
+
+// Run the plugin, looping processing requests and sending responses until the
+// client goes away, then close the connection.  Errors ending the loop, other
+// than the client closing the connection, are written to stderr.
 func (p *Plugin) Run() {
+	defer p.tp.close()
+
 	for {
 		request, err := p.tp.readRequest()
 		if err != nil {
+			if err == io.EOF {
+				return
+			}
 			if lsmError, ok := err.(*errors.LsmError); ok == true {
 
 				if lsmError.Code != errors.TransPortComunication {
-					p.tp.sendError(lsmError)
+					// We couldn't parse the request, so we don't know its id
+					p.tp.sendError(legacyID, lsmError)
 					//fmt.Printf("Returned error %+v\n", lsmError)
 					continue
 				} else {
-					fmt.Printf("Communication error: exiting! %s\n", lsmError)
+					fmt.Fprintf(os.Stderr, "Communication error: exiting! %s\n", lsmError)
 				}
 				return
 			}
-			fmt.Printf("Unexpected error, exiting! %s\n", err)
+			fmt.Fprintf(os.Stderr, "Unexpected error, exiting! %s\n", err)
 			return
 		}
 
 		var response interface{}
 		if f, ok := p.callTable[request.Method]; ok == true && f != nil {
 			//fmt.Printf("Executing %s(%s)\n", request.Method, string(request.Params))
-			response, err = f(p, request)
+			response, err = p.call(f, request)
 			if err != nil {
-				p.tp.sendError(err)
+				p.tp.sendError(request.ID, err)
 			} else {
-				p.tp.sendResponse(response)
+				p.tp.sendResponse(request.ID, response)
 			}
 
 			// Need to shut down the connection.
 			if request.Method == "plugin_unregister" {
-				p.tp.close()
 				return
 			}
 		} else {
-			noSupport(&p.tp, request.Method)
+			noSupport(p.tp, request.ID, request.Method)
 		}
 	}
 }
@@ -13,7 +13,7 @@ import (
 func invalidArgs(msg string, e error) error {
 	return &errors.LsmError{
 		Code:    errors.TransPortInvalidArg,
-		Message: fmt.Sprintf("%s: invalid arguments(s) %w\n", msg, e)}
+		Message: fmt.Sprintf("%s: invalid arguments(s) %v\n", msg, e)}
 }
 
 func handleRegister(p *Plugin, msg *requestMsg) (interface{}, error) {
@@ -99,6 +99,9 @@ func handleCapabilities(p *Plugin, msg *requestMsg) (interface{}, error) {
 	if uE := json.Unmarshal(msg.Params, &args); uE != nil {
 		return nil, invalidArgs(msg.Method, uE)
 	}
+	if p.cb.Mgmt.Capabilities == nil {
+		return DefaultCapabilities(p.cb, nil)(&args.Sys)
+	}
 	return p.cb.Mgmt.Capabilities(&args.Sys)
 }
 
@@ -879,22 +882,26 @@ func handleVolReadCacheSet(p *Plugin, msg *requestMsg) (interface{}, error) {
 }
 
 func nilAssign(present interface{}, cb handler) handler {
-
-	// This seems like an epic fail of golang as I got burned by doing present == nil
-	// ref. https://groups.google.com/forum/#!topic/golang-nuts/wnH302gBa4I/discussion
-	if present == nil || reflect.ValueOf(present).IsNil() {
+	if !isSet(present) {
 		return nil
 	}
 	return cb
 }
 
+// isSet returns true if the callback isn't nil.
+func isSet(cb interface{}) bool {
+	// This seems like an epic fail of golang as I got burned by doing present == nil
+	// ref. https://groups.google.com/forum/#!topic/golang-nuts/wnH302gBa4I/discussion
+	return cb != nil && !reflect.ValueOf(cb).IsNil()
+}
+
 func buildTable(c *PluginCallBacks) map[string]handler {
 	return map[string]handler{
 		"plugin_info":       handlePluginInfo,
 		"plugin_register":   nilAssign(c.Mgmt.PluginRegister, handleRegister),
 		"plugin_unregister": nilAssign(c.Mgmt.PluginUnregister, handleUnRegister),
 		"systems":           nilAssign(c.Mgmt.Systems, handleSystems),
-		"capabilities":      nilAssign(c.Mgmt.Capabilities, handleCapabilities),
+		"capabilities":      handleCapabilities,
 		"time_out_set":      nilAssign(c.Mgmt.TimeOutSet, handleTmoSet),
 		"time_out_get":      nilAssign(c.Mgmt.TimeOutGet, handleTmoGet),
 		"pools":             nilAssign(c.Mgmt.Pools, handlePools),
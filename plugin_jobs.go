@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import (
+	"fmt"
+	"sync"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// JobProgress is used by a running job to report its percent complete.
+type JobProgress func(percent uint8)
+
+// JobWork is a long running operation run as a job.  The returned item is
+// what the client gets on completion, eg. the new *Volume, or nil for
+// operations without a result.
+type JobWork func(progress JobProgress) (interface{}, error)
+
+type managedJob struct {
+	percent uint8
+	done    bool
+	item    interface{}
+	err     error
+}
+
+// JobManager keeps track of the jobs a plugin has running, so callbacks can
+// return the job ID from Start and leave JobStatus and JobFree to it.
+// A JobManager is safe for concurrent use by multiple goroutines.
+type JobManager struct {
+	lock   sync.Mutex
+	lastID uint64
+	jobs   map[string]*managedJob
+}
+
+// NewJobManager returns a JobManager with no jobs.
+func NewJobManager() *JobManager {
+	return &JobManager{jobs: make(map[string]*managedJob)}
+}
+
+// Install sets the JobStatus and JobFree management callbacks to m.
+func (m *JobManager) Install(callbacks *PluginCallBacks) {
+	callbacks.Mgmt.JobStatus = m.Status
+	callbacks.Mgmt.JobFree = m.Free
+}
+
+// Start runs work in a new goroutine and returns the ID of the job tracking
+// it, which callbacks return as is.
+func (m *JobManager) Start(work JobWork) *string {
+	m.lock.Lock()
+	m.lastID++
+	id := fmt.Sprintf("JOB_%d", m.lastID)
+	job := &managedJob{}
+	m.jobs[id] = job
+	m.lock.Unlock()
+
+	progress := func(percent uint8) {
+		// 100 is reserved for when the work has returned
+		if percent > 99 {
+			percent = 99
+		}
+		m.lock.Lock()
+		job.percent = percent
+		m.lock.Unlock()
+	}
+
+	go func() {
+		item, err := runJob(id, work, progress)
+
+		if err != nil {
+			if _, ok := err.(*errors.LsmError); !ok {
+				err = &errors.LsmError{
+					Code:    errors.PluginBug,
+					Message: err.Error()}
+			}
+		}
+
+		m.lock.Lock()
+		job.done = true
+		job.item = item
+		job.err = err
+		job.percent = 100
+		m.lock.Unlock()
+	}()
+
+	return &id
+}
+
+// runJob runs work, a panic fails the job rather than the plugin.
+func runJob(id string, work JobWork, progress JobProgress) (item interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			item = nil
+			err = panicError("job "+id, r)
+		}
+	}()
+	return work(progress)
+}
+
+// Status returns the status of the job, a job which failed returns the error
+// it failed with until it is freed.  It can be used as the JobStatus callback.
+func (m *JobManager) Status(jobID string) (*JobInfo, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return nil, &errors.LsmError{
+			Code:    errors.NotFoundJob,
+			Message: fmt.Sprintf("job %s not found", jobID)}
+	}
+
+	if !job.done {
+		return &JobInfo{Status: JobStatusInprogress, Percent: job.percent}, nil
+	}
+	if job.err != nil {
+		return nil, job.err
+	}
+	return &JobInfo{Status: JobStatusComplete, Percent: 100, Item: job.item}, nil
+}
+
+// Free forgets the job.  A job still running carries on, but its result is
+// discarded.  It can be used as the JobFree callback.
+func (m *JobManager) Free(jobID string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.jobs[jobID]; !ok {
+		return &errors.LsmError{
+			Code:    errors.NotFoundJob,
+			Message: fmt.Sprintf("job %s not found", jobID)}
+	}
+	delete(m.jobs, jobID)
+	return nil
+}
@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package proxy gives remote clients access to the plugins of lsmd over TCP
+// with mutual TLS.  The proxy looks at the plugin_register request each client
+// starts with to pick the plugin socket, from then on it forwards the
+// connection as is, so clients use the normal ClientConnection API.
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+const (
+	headerLen        = 10
+	maxRegisterLen   = 64 * 1024
+	handshakeTimeout = 10 * time.Second
+)
+
+// Server forwards authenticated TLS connections to the local plugin sockets.
+type Server struct {
+	// TLSConfig must require and verify client certificates
+	TLSConfig *tls.Config
+
+	// SocketDir holds the plugin sockets, default lsm.IpcPath()
+	SocketDir string
+
+	// Authorize, if set, decides whether the client may use the plugin, a
+	// non nil error is returned to the client
+	Authorize func(state tls.ConnectionState, plugin string) error
+
+	// Log receives diagnostics, default discards them
+	Log *log.Logger
+}
+
+// ListenAndServe listens on the TCP address addr and serves clients until an
+// error occurs.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	return s.Serve(l)
+}
+
+// Serve serves clients connecting to l, which must not already use TLS, until
+// l is closed.
+func (s *Server) Serve(l net.Listener) error {
+	if s.TLSConfig == nil || s.TLSConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		return &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: "proxy requires a TLS config with ClientAuth set to RequireAndVerifyClientCert"}
+	}
+
+	tl := tls.NewListener(l, s.TLSConfig)
+	for {
+		conn, err := tl.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn.(*tls.Conn))
+	}
+}
+
+func (s *Server) logf(format string, a ...interface{}) {
+	if s.Log != nil {
+		s.Log.Printf(format, a...)
+	}
+}
+
+func (s *Server) handle(conn *tls.Conn) {
+	defer conn.Close()
+
+	// Don't let a client hold a connection without saying what it wants
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := conn.Handshake(); err != nil {
+		s.logf("%s: handshake failed: %s", conn.RemoteAddr(), err)
+		return
+	}
+
+	frame, err := readFrame(conn)
+	if err != nil {
+		s.logf("%s: unable to read request: %s", conn.RemoteAddr(), err)
+		return
+	}
+	conn.SetDeadline(time.Time{})
+
+	plugin, id, err := s.pluginFor(frame)
+	if err == nil && s.Authorize != nil {
+		err = s.Authorize(conn.ConnectionState(), plugin)
+	}
+
+	var pluginConn net.Conn
+	if err == nil {
+		var d net.Dialer
+		pluginConn, err = d.DialContext(context.Background(), "unix", filepath.Join(s.socketDir(), plugin))
+		if err != nil {
+			err = &errors.LsmError{
+				Code:    errors.PluginNotExist,
+				Message: fmt.Sprintf("plug-in %s not reachable: %v", plugin, err)}
+		}
+	}
+
+	if err != nil {
+		s.logf("%s: %s", conn.RemoteAddr(), err)
+		sendError(conn, id, err)
+		return
+	}
+	defer pluginConn.Close()
+
+	if _, err := pluginConn.Write(frame); err != nil {
+		s.logf("%s: unable to forward to %s: %s", conn.RemoteAddr(), plugin, err)
+		return
+	}
+
+	cert := conn.ConnectionState().PeerCertificates[0]
+	s.logf("%s: %s connected to %s", conn.RemoteAddr(), cert.Subject, plugin)
+
+	// Whichever side finishes first ends the session
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(pluginConn, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, pluginConn)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+func (s *Server) socketDir() string {
+	if len(s.SocketDir) > 0 {
+		return s.SocketDir
+	}
+	return lsm.IpcPath()
+}
+
+// pluginFor returns the plugin named by the scheme of the URI in the
+// plugin_register request in frame and the id of the request.
+func (s *Server) pluginFor(frame []byte) (string, int, error) {
+	var request struct {
+		ID     int    `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(frame[headerLen:], &request); err != nil {
+		return "", 0, &errors.LsmError{
+			Code:    errors.TransPortInvalidArg,
+			Message: fmt.Sprintf("unparsable request: %v", err)}
+	}
+
+	if request.Method != "plugin_register" {
+		return "", request.ID, &errors.LsmError{
+			Code:    errors.TransPortInvalidArg,
+			Message: fmt.Sprintf("expected plugin_register, got %s", request.Method)}
+	}
+
+	u, err := url.Parse(request.Params.URI)
+	if err != nil {
+		return "", request.ID, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("invalid uri: %v", err)}
+	}
+
+	// Only sockets lsmd created, the name comes from the client
+	for _, path := range lsm.PluginSockets(s.socketDir()) {
+		if filepath.Base(path) == u.Scheme {
+			return u.Scheme, request.ID, nil
+		}
+	}
+	return "", request.ID, &errors.LsmError{
+		Code:    errors.PluginNotExist,
+		Message: fmt.Sprintf("plug-in %s not found!", u.Scheme)}
+}
+
+// readFrame reads one length prefixed message, returning it header and all.
+func readFrame(r io.Reader) ([]byte, error) {
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	msgLen, err := strconv.ParseUint(string(header), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	if msgLen > maxRegisterLen {
+		return nil, fmt.Errorf("request of %d bytes is too large", msgLen)
+	}
+
+	frame := make([]byte, headerLen+int(msgLen))
+	copy(frame, header)
+	if _, err := io.ReadFull(r, frame[headerLen:]); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+func sendError(w io.Writer, id int, err error) {
+	lsmError, ok := err.(*errors.LsmError)
+	if !ok {
+		lsmError = &errors.LsmError{Code: errors.PermissionDenied, Message: err.Error()}
+	}
+
+	msg, _ := json.Marshal(map[string]interface{}{"id": id, "error": lsmError})
+	fmt.Fprintf(w, "%010d%s", len(msg), msg)
+}
+
+// Client connects to the plugin named by the scheme of uri through the proxy
+// at addr.  config must contain the client certificate.
+func Client(addr string, config *tls.Config, uri string, password string, timeout uint32) (*lsm.ClientConnection, error) {
+	return lsm.ClientTLS(addr, config, uri, password, timeout)
+}
+
+// ClientCtx is Client with a context used for cancellation and deadlines while
+// connecting to and registering with the plugin.
+func ClientCtx(ctx context.Context, addr string, config *tls.Config,
+	uri string, password string, timeout uint32) (*lsm.ClientConnection, error) {
+	return lsm.ClientTLSCtx(ctx, addr, config, uri, password, timeout)
+}
+
+// ServerConfig returns a TLS config for a Server using the certificate and key
+// in PEM files, accepting clients with certificates signed by the CAs in caFile.
+func ServerConfig(certFile string, keyFile string, caFile string) (*tls.Config, error) {
+	cert, pool, err := loadFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientConfig returns a TLS config for Client using the certificate and key
+// in PEM files, trusting proxies with certificates signed by the CAs in caFile.
+func ClientConfig(certFile string, keyFile string, caFile string) (*tls.Config, error) {
+	cert, pool, err := loadFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func loadFiles(certFile string, keyFile string, caFile string) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return cert, nil, err
+	}
+
+	ca, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return cert, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return cert, nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	return cert, pool, nil
+}
@@ -0,0 +1,601 @@
+// SPDX-License-Identifier: 0BSD
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+	"github.com/libstorage/libstoragemgmt-golang/inventory"
+)
+
+// Options change how the plan is computed.
+type Options struct {
+	// Prune deletes the volumes and access groups named with the prefix
+	// and removes the NFS exports whose last path element has it, which
+	// the state doesn't list.  Nothing is pruned when empty.
+	Prune string
+}
+
+// Action is a step of the plan.
+type Action struct {
+	// Op is what is done, eg. "create", "mask" or "unexport"
+	Op     string
+	Kind   inventory.Kind
+	Name   string
+	Detail string
+
+	do func(ctx context.Context, client lsm.StorageClient) error
+}
+
+// removing are the ops taking something away.
+var removing = map[string]bool{
+	"delete": true, "remove initiator": true, "unmask": true, "unexport": true,
+}
+
+func (a *Action) String() string {
+	sign := "+"
+	if removing[a.Op] {
+		sign = "-"
+	} else if a.Op == "resize" {
+		sign = "~"
+	}
+	s := fmt.Sprintf("%s %s %s %s", sign, a.Op, a.Kind, a.Name)
+	if len(a.Detail) > 0 {
+		s += " (" + a.Detail + ")"
+	}
+	return s
+}
+
+// Plan is the actions bringing the array to the state, in order.
+type Plan struct {
+	Actions []*Action
+}
+
+// String lists the actions one per line, it is the output of a dry run.
+func (p *Plan) String() string {
+	if len(p.Actions) == 0 {
+		return "No changes\n"
+	}
+	var b strings.Builder
+	for _, a := range p.Actions {
+		fmt.Fprintln(&b, a)
+	}
+	return b.String()
+}
+
+// Apply takes the actions in order, stopping at the first failing.  The
+// actions before it stay applied, computing the plan again carries on from
+// there.
+func (p *Plan) Apply(ctx context.Context, client lsm.StorageClient) error {
+	for _, a := range p.Actions {
+		if err := a.do(ctx, client); err != nil {
+			code, ok := errors.CodeOf(err)
+			if !ok {
+				code = errors.LibBug
+			}
+			return &errors.LsmError{Code: code, Message: fmt.Sprintf("%s: %s", a, errors.MessageOf(err))}
+		}
+	}
+	return nil
+}
+
+// The volumes and access groups created by the plan are only known once
+// applied, the actions refer to them through these.
+type volumeRef struct {
+	vol *lsm.Volume
+}
+
+type groupRef struct {
+	ag *lsm.AccessGroup
+}
+
+// planner holds what is on the array while computing the plan.
+type planner struct {
+	state *State
+	opts  Options
+	plan  *Plan
+
+	systems     []lsm.System
+	pools       []lsm.Pool
+	fileSystems []lsm.FileSystem
+	volumes     map[string]*volumeRef
+	groups      map[string]*groupRef
+	exports     []lsm.NfsExport
+
+	// masked holds the IDs of the access groups by volume ID
+	masked map[string]map[string]bool
+}
+
+// Compute plans the changes bringing the array to the state.  The plan only
+// touches what the state lists, and with Options.Prune what is named with
+// the prefix.
+func Compute(ctx context.Context, client lsm.StorageClient, state *State, opts Options) (*Plan, error) {
+	if err := state.validate(); err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		state:   state,
+		opts:    opts,
+		plan:    &Plan{},
+		volumes: make(map[string]*volumeRef),
+		groups:  make(map[string]*groupRef),
+		masked:  make(map[string]map[string]bool),
+	}
+	if err := p.load(ctx, client); err != nil {
+		return nil, err
+	}
+
+	steps := []func() error{
+		p.unexport, p.unmask, p.pruneVolumes, p.pruneGroups,
+		p.accessGroups, p.createVolumes, p.mask, p.export,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return p.plan, nil
+}
+
+// load lists what is on the array.
+func (p *planner) load(ctx context.Context, client lsm.StorageClient) error {
+	var err error
+	if p.systems, err = client.SystemsCtx(ctx); err != nil {
+		return err
+	}
+	if p.pools, err = client.PoolsCtx(ctx); err != nil {
+		return err
+	}
+
+	volumes, err := client.VolumesCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range volumes {
+		if err := unique(p.volumes[volumes[i].Name] != nil, "volume", volumes[i].Name); err != nil {
+			return err
+		}
+		p.volumes[volumes[i].Name] = &volumeRef{&volumes[i]}
+	}
+
+	groups, err := client.AccessGroupsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range groups {
+		if err := unique(p.groups[groups[i].Name] != nil, "access group", groups[i].Name); err != nil {
+			return err
+		}
+		p.groups[groups[i].Name] = &groupRef{&groups[i]}
+
+		masked, err := client.VolsMaskedToAgCtx(ctx, &groups[i])
+		if err != nil {
+			return err
+		}
+		for _, v := range masked {
+			if p.masked[v.ID] == nil {
+				p.masked[v.ID] = make(map[string]bool)
+			}
+			p.masked[v.ID][groups[i].ID] = true
+		}
+	}
+
+	if len(p.state.NfsExports) > 0 || len(p.opts.Prune) > 0 {
+		if p.fileSystems, err = client.FileSystemsCtx(ctx); err != nil {
+			return err
+		}
+		if p.exports, err = client.NfsExportsCtx(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unique returns an error for a name the array has more than once, which
+// can't be told apart.
+func unique(seen bool, what string, name string) error {
+	if seen {
+		return &errors.LsmError{
+			Code:    errors.NameConflict,
+			Message: fmt.Sprintf("more than one %s named %s on the array", what, name)}
+	}
+	return nil
+}
+
+func (p *planner) add(op string, kind inventory.Kind, name string, detail string,
+	do func(ctx context.Context, client lsm.StorageClient) error) {
+	p.plan.Actions = append(p.plan.Actions, &Action{Op: op, Kind: kind, Name: name, Detail: detail, do: do})
+}
+
+// pruned tells if the item named name isn't in the state but has the prefix.
+func (p *planner) pruned(name string, listed bool) bool {
+	return !listed && len(p.opts.Prune) > 0 && strings.HasPrefix(name, p.opts.Prune)
+}
+
+func (p *planner) volumeState(name string) *VolumeState {
+	for i := range p.state.Volumes {
+		if p.state.Volumes[i].Name == name {
+			return &p.state.Volumes[i]
+		}
+	}
+	return nil
+}
+
+func (p *planner) groupState(name string) *AccessGroupState {
+	for i := range p.state.AccessGroups {
+		if p.state.AccessGroups[i].Name == name {
+			return &p.state.AccessGroups[i]
+		}
+	}
+	return nil
+}
+
+func (p *planner) exportState(exportPath string) *NfsExportState {
+	for i := range p.state.NfsExports {
+		if p.state.NfsExports[i].Path == exportPath {
+			return &p.state.NfsExports[i]
+		}
+	}
+	return nil
+}
+
+// sortedNames returns the names of the volumes or access groups on the
+// array in order.
+func sortedNames(m interface{}) []string {
+	var names []string
+	switch items := m.(type) {
+	case map[string]*volumeRef:
+		for name := range items {
+			names = append(names, name)
+		}
+	case map[string]*groupRef:
+		for name := range items {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unexport removes the exports pruned or differing from the state, the
+// latter are exported again by export.
+func (p *planner) unexport() error {
+	for i := range p.exports {
+		e := &p.exports[i]
+		want := p.exportState(e.ExportPath)
+		if want == nil && !p.pruned(path.Base(e.ExportPath), false) {
+			continue
+		}
+		if want != nil {
+			fs, err := p.fileSystem(want.Fs)
+			if err != nil {
+				return err
+			}
+			if fs.ID == e.FsID && sameExport(want, e) {
+				continue
+			}
+		}
+		p.add("unexport", inventory.KindNfsExport, e.ExportPath, "", func(ctx context.Context, c lsm.StorageClient) error {
+			return c.FsUnExportCtx(ctx, e)
+		})
+	}
+	return nil
+}
+
+// sameExport tells if the export is as wanted.
+func sameExport(want *NfsExportState, e *lsm.NfsExport) bool {
+	access := want.access()
+	return sameSet(access.Root, e.Root) && sameSet(access.Rw, e.Rw) && sameSet(access.Ro, e.Ro) &&
+		access.AnonUID == e.AnonUID && access.AnonGID == e.AnonGID &&
+		(len(want.Auth) == 0 || want.Auth == e.Auth) &&
+		(len(want.Options) == 0 || want.Options == e.Options)
+}
+
+func (e *NfsExportState) access() *lsm.NfsAccess {
+	access := &lsm.NfsAccess{
+		Root: e.Root, Rw: e.Rw, Ro: e.Ro,
+		AnonUID: lsm.AnonUIDGIDNotApplicable, AnonGID: lsm.AnonUIDGIDNotApplicable}
+	if e.AnonUID != nil {
+		access.AnonUID = *e.AnonUID
+	}
+	if e.AnonGID != nil {
+		access.AnonGID = *e.AnonGID
+	}
+	return access
+}
+
+func sameSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// unmask removes the masking of the volumes to the access groups of the
+// state not listed for them, and all the masking of what is pruned.
+func (p *planner) unmask() error {
+	for _, name := range sortedNames(p.volumes) {
+		vol := p.volumes[name].vol
+		want := p.volumeState(name)
+		prunedVol := p.pruned(name, want != nil)
+
+		for _, agName := range sortedNames(p.groups) {
+			ag := p.groups[agName].ag
+			if !p.masked[vol.ID][ag.ID] {
+				continue
+			}
+			agState := p.groupState(agName)
+			prunedAg := p.pruned(agName, agState != nil)
+			managed := want != nil && agState != nil && !contains(want.AccessGroups, agName)
+			if !prunedVol && !prunedAg && !managed {
+				continue
+			}
+			p.add("unmask", inventory.KindVolume, name, "from "+agName,
+				func(ctx context.Context, c lsm.StorageClient) error {
+					return c.VolumeUnMaskCtx(ctx, vol, ag)
+				})
+		}
+	}
+	return nil
+}
+
+func (p *planner) pruneVolumes() error {
+	for _, name := range sortedNames(p.volumes) {
+		if !p.pruned(name, p.volumeState(name) != nil) {
+			continue
+		}
+		vol := p.volumes[name].vol
+		p.add("delete", inventory.KindVolume, name, "", func(ctx context.Context, c lsm.StorageClient) error {
+			_, err := c.VolumeDeleteCtx(ctx, vol, true)
+			return err
+		})
+	}
+	return nil
+}
+
+func (p *planner) pruneGroups() error {
+	for _, name := range sortedNames(p.groups) {
+		if !p.pruned(name, p.groupState(name) != nil) {
+			continue
+		}
+		ag := p.groups[name].ag
+		p.add("delete", inventory.KindAccessGroup, name, "", func(ctx context.Context, c lsm.StorageClient) error {
+			return c.AccessGroupDeleteCtx(ctx, ag)
+		})
+	}
+	return nil
+}
+
+// accessGroups creates the access groups of the state and brings their
+// initiators in line, adding before removing so a group is never left empty.
+func (p *planner) accessGroups() error {
+	for _, want := range p.state.AccessGroups {
+		want := want
+		ref := p.groups[want.Name]
+		initiators := want.Initiators
+
+		if ref == nil {
+			system, err := p.system()
+			if err != nil {
+				return err
+			}
+			ref = &groupRef{}
+			p.groups[want.Name] = ref
+			p.add("create", inventory.KindAccessGroup, want.Name, initiators[0],
+				func(ctx context.Context, c lsm.StorageClient) error {
+					ag, err := c.AccessGroupCreateCtx(ctx, want.Name, want.Initiators[0], want.InitType, system)
+					ref.ag = ag
+					return err
+				})
+			initiators = initiators[1:]
+		}
+
+		var current []string
+		if ref.ag != nil {
+			current = ref.ag.InitIDs
+		}
+		for _, init := range initiators {
+			if contains(current, init) {
+				continue
+			}
+			init := init
+			p.add("add initiator", inventory.KindAccessGroup, want.Name, init,
+				func(ctx context.Context, c lsm.StorageClient) error {
+					ag, err := c.AccessGroupInitAddCtx(ctx, ref.ag, init, want.InitType)
+					if err == nil {
+						ref.ag = ag
+					}
+					return err
+				})
+		}
+		for _, init := range current {
+			if contains(want.Initiators, init) {
+				continue
+			}
+			init := init
+			p.add("remove initiator", inventory.KindAccessGroup, want.Name, init,
+				func(ctx context.Context, c lsm.StorageClient) error {
+					ag, err := c.AccessGroupInitDeleteCtx(ctx, ref.ag, init, ref.ag.InitiatorType)
+					if err == nil {
+						ref.ag = ag
+					}
+					return err
+				})
+		}
+	}
+	return nil
+}
+
+// createVolumes creates the volumes of the state and grows those too small.
+func (p *planner) createVolumes() error {
+	for _, want := range p.state.Volumes {
+		want := want
+		ref := p.volumes[want.Name]
+
+		if ref != nil {
+			vol := ref.vol
+			if size := vol.SizeBytes(); size < want.Size {
+				newSize, err := want.Size.RoundUp(vol.BlockSize)
+				if err != nil {
+					return err
+				}
+				p.add("resize", inventory.KindVolume, want.Name, fmt.Sprintf("%s to %s", size, newSize),
+					func(ctx context.Context, c lsm.StorageClient) error {
+						_, job, err := c.VolumeResizeSize(ctx, vol, newSize)
+						if err != nil {
+							return err
+						}
+						return job.Wait(ctx)
+					})
+			}
+			continue
+		}
+
+		pool, err := p.pool(want.Pool)
+		if err != nil {
+			return err
+		}
+		provisioning := lsm.VolumeProvisionTypeDefault
+		if want.Provisioning != nil {
+			provisioning = *want.Provisioning
+		}
+		ref = &volumeRef{}
+		p.volumes[want.Name] = ref
+		p.add("create", inventory.KindVolume, want.Name, fmt.Sprintf("%s in pool %s", want.Size, pool.ID),
+			func(ctx context.Context, c lsm.StorageClient) error {
+				vol, job, err := c.VolumeCreateSize(ctx, pool, want.Name, want.Size, provisioning)
+				if err != nil {
+					return err
+				}
+				if err := job.Wait(ctx); err != nil {
+					return err
+				}
+				ref.vol = vol
+				return nil
+			})
+	}
+	return nil
+}
+
+// mask masks the volumes of the state to their access groups.
+func (p *planner) mask() error {
+	for _, want := range p.state.Volumes {
+		vol := p.volumes[want.Name]
+		for _, agName := range want.AccessGroups {
+			ag := p.groups[agName]
+			if vol.vol != nil && ag.ag != nil && p.masked[vol.vol.ID][ag.ag.ID] {
+				continue
+			}
+			p.add("mask", inventory.KindVolume, want.Name, "to "+agName,
+				func(ctx context.Context, c lsm.StorageClient) error {
+					return c.VolumeMaskCtx(ctx, vol.vol, ag.ag)
+				})
+		}
+	}
+	return nil
+}
+
+// export exports what the state lists and isn't exported as wanted.
+func (p *planner) export() error {
+	for _, want := range p.state.NfsExports {
+		want := want
+		fs, err := p.fileSystem(want.Fs)
+		if err != nil {
+			return err
+		}
+
+		exported := false
+		for i := range p.exports {
+			e := &p.exports[i]
+			exported = exported || (e.ExportPath == want.Path && e.FsID == fs.ID && sameExport(&want, e))
+		}
+		if exported {
+			continue
+		}
+
+		p.add("export", inventory.KindNfsExport, want.Path, "of "+fs.Name,
+			func(ctx context.Context, c lsm.StorageClient) error {
+				var auth, options *string
+				if len(want.Auth) > 0 {
+					auth = &want.Auth
+				}
+				if len(want.Options) > 0 {
+					options = &want.Options
+				}
+				_, err := c.FsExportCtx(ctx, fs, &want.Path, want.access(), auth, options)
+				return err
+			})
+	}
+	return nil
+}
+
+// system returns the system access groups are created on.
+func (p *planner) system() (*lsm.System, error) {
+	for i := range p.systems {
+		if p.systems[i].ID == p.state.System || (len(p.state.System) == 0 && len(p.systems) == 1) {
+			return &p.systems[i], nil
+		}
+	}
+	if len(p.state.System) == 0 {
+		return nil, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("the array has %d systems, the state needs to name one", len(p.systems))}
+	}
+	return nil, &errors.LsmError{
+		Code:    errors.NotFoundSystem,
+		Message: fmt.Sprintf("system %s not found", p.state.System)}
+}
+
+// pool returns the pool with the ID or name.
+func (p *planner) pool(idOrName string) (*lsm.Pool, error) {
+	for _, byName := range []bool{false, true} {
+		for i := range p.pools {
+			if (!byName && p.pools[i].ID == idOrName) || (byName && p.pools[i].Name == idOrName) {
+				return &p.pools[i], nil
+			}
+		}
+	}
+	return nil, &errors.LsmError{
+		Code:    errors.NotFoundPool,
+		Message: fmt.Sprintf("pool %s not found", idOrName)}
+}
+
+// fileSystem returns the file system with the ID or name.
+func (p *planner) fileSystem(idOrName string) (*lsm.FileSystem, error) {
+	for _, byName := range []bool{false, true} {
+		for i := range p.fileSystems {
+			if (!byName && p.fileSystems[i].ID == idOrName) || (byName && p.fileSystems[i].Name == idOrName) {
+				return &p.fileSystems[i], nil
+			}
+		}
+	}
+	return nil, &errors.LsmError{
+		Code:    errors.NotFoundFs,
+		Message: fmt.Sprintf("file system %s not found", idOrName)}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
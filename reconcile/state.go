@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package reconcile brings an array to a desired state described in YAML or
+// JSON.  The state lists volumes, access groups with their initiators,
+// masking and NFS exports, Compute plans the changes against the array and
+// Apply makes them, eg.
+//
+//	state, err := reconcile.Parse(data)
+//	plan, err := reconcile.Compute(ctx, client, state, reconcile.Options{Prune: "app1_"})
+//	fmt.Print(plan)
+//	err = plan.Apply(ctx, client)
+//
+// Volumes and access groups are matched by name and NFS exports by path, so a
+// plan computed once the state is applied is empty.
+package reconcile
+
+import (
+	"fmt"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// State is the desired state of the array.
+type State struct {
+	// System is the ID of the system to create access groups on, it may
+	// be left out if there is only one
+	System       string             `json:"system,omitempty" yaml:"system,omitempty"`
+	Volumes      []VolumeState      `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	AccessGroups []AccessGroupState `json:"access_groups,omitempty" yaml:"access_groups,omitempty"`
+	NfsExports   []NfsExportState   `json:"nfs_exports,omitempty" yaml:"nfs_exports,omitempty"`
+}
+
+// VolumeState is a volume, it is grown to the size if smaller but never
+// shrunk or moved to another pool.
+type VolumeState struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Pool is the ID or name of the pool to create the volume in
+	Pool string `json:"pool" yaml:"pool"`
+
+	// Size may have a unit, eg. "10GiB"
+	Size lsm.Size `json:"size" yaml:"size"`
+
+	// Provisioning defaults to lsm.VolumeProvisionTypeDefault
+	Provisioning *lsm.VolumeProvisionType `json:"provisioning,omitempty" yaml:"provisioning,omitempty"`
+
+	// AccessGroups are the names of the access groups of the state the
+	// volume is masked to, it is unmasked from the others of the state
+	AccessGroups []string `json:"access_groups,omitempty" yaml:"access_groups,omitempty"`
+}
+
+// AccessGroupState is an access group and its initiators, initiators not
+// listed are removed.
+type AccessGroupState struct {
+	Name       string            `json:"name" yaml:"name"`
+	InitType   lsm.InitiatorType `json:"init_type" yaml:"init_type"`
+	Initiators []string          `json:"initiators" yaml:"initiators"`
+}
+
+// NfsExportState is an NFS export of a file system, an export differing from
+// it is exported again.
+type NfsExportState struct {
+	// Fs is the ID or name of the file system
+	Fs   string   `json:"fs" yaml:"fs"`
+	Path string   `json:"path" yaml:"path"`
+	Root []string `json:"root,omitempty" yaml:"root,omitempty"`
+	Rw   []string `json:"rw,omitempty" yaml:"rw,omitempty"`
+	Ro   []string `json:"ro,omitempty" yaml:"ro,omitempty"`
+
+	// AnonUID and AnonGID default to lsm.AnonUIDGIDNotApplicable
+	AnonUID *int64 `json:"anonuid,omitempty" yaml:"anonuid,omitempty"`
+	AnonGID *int64 `json:"anongid,omitempty" yaml:"anongid,omitempty"`
+
+	// Auth and Options are left to the plugin when empty
+	Auth    string `json:"auth,omitempty" yaml:"auth,omitempty"`
+	Options string `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// Parse reads the state from YAML or JSON, JSON being YAML too.  The
+// enumerated types may be given by name, eg. "init_type: IscsiIqn".
+func Parse(data []byte) (*State, error) {
+	var state State
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		if _, ok := errors.CodeOf(err); ok {
+			return nil, err
+		}
+		return nil, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("invalid state: %s", err)}
+	}
+	return &state, state.validate()
+}
+
+// validate checks the state is consistent by itself, what it refers to on
+// the array is checked by Compute.
+func (s *State) validate() error {
+	invalid := func(format string, args ...interface{}) error {
+		return &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("invalid state: "+format, args...)}
+	}
+
+	groups := make(map[string]bool)
+	for _, ag := range s.AccessGroups {
+		if len(ag.Name) == 0 {
+			return invalid("access group without a name")
+		}
+		if groups[ag.Name] {
+			return invalid("access group %s listed twice", ag.Name)
+		}
+		groups[ag.Name] = true
+		if len(ag.Initiators) == 0 {
+			return invalid("access group %s has no initiators", ag.Name)
+		}
+		if ag.InitType != lsm.InitiatorTypeWwpn && ag.InitType != lsm.InitiatorTypeIscsiIqn {
+			return invalid("access group %s has init_type %s, not Wwpn or IscsiIqn", ag.Name, ag.InitType)
+		}
+	}
+
+	volumes := make(map[string]bool)
+	for _, v := range s.Volumes {
+		if len(v.Name) == 0 {
+			return invalid("volume without a name")
+		}
+		if volumes[v.Name] {
+			return invalid("volume %s listed twice", v.Name)
+		}
+		volumes[v.Name] = true
+		if len(v.Pool) == 0 || v.Size == 0 {
+			return invalid("volume %s needs a pool and a size", v.Name)
+		}
+		for _, ag := range v.AccessGroups {
+			if !groups[ag] {
+				return invalid("volume %s is masked to %s, which isn't a listed access group", v.Name, ag)
+			}
+		}
+	}
+
+	exports := make(map[string]bool)
+	for _, e := range s.NfsExports {
+		if len(e.Fs) == 0 || len(e.Path) == 0 {
+			return invalid("NFS export needs a file system and a path")
+		}
+		if exports[e.Path] {
+			return invalid("NFS export %s listed twice", e.Path)
+		}
+		exports[e.Path] = true
+	}
+	return nil
+}
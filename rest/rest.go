@@ -0,0 +1,680 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package rest exposes a storage client as a REST/JSON API, so tooling not
+// written in Go can manage storage over HTTP.
+//
+// Collections are listed with GET, eg. /volumes, a single item with GET
+// /volumes/{id}.  Collections which support searching take the search key and
+// value as query, eg. /volumes?pool_id=POOL_ID_01.  Operations which may run
+// as a job answer 202 Accepted with the job in the Location header, the job is
+// polled with GET /jobs/{id} and freed with DELETE /jobs/{id}.  Adding
+// ?wait=true to the request waits for the job instead.  Errors are returned
+// as the JSON of an errors.LsmError.
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// VolumeCreateRequest is the body of POST /volumes
+type VolumeCreateRequest struct {
+	PoolID       string                  `json:"pool_id"`
+	Name         string                  `json:"name"`
+	SizeBytes    uint64                  `json:"size_bytes"`
+	Provisioning lsm.VolumeProvisionType `json:"provisioning"`
+}
+
+// ResizeRequest is the body of POST /volumes/{id}/resize and
+// /fs/{id}/resize
+type ResizeRequest struct {
+	SizeBytes uint64 `json:"size_bytes"`
+}
+
+// MaskRequest is the body of POST /volumes/{id}/access_groups
+type MaskRequest struct {
+	AccessGroupID string `json:"access_group_id"`
+}
+
+// FsCreateRequest is the body of POST /fs
+type FsCreateRequest struct {
+	PoolID    string `json:"pool_id"`
+	Name      string `json:"name"`
+	SizeBytes uint64 `json:"size_bytes"`
+}
+
+// ExportRequest is the body of POST /exports, AnonUID and AnonGID default to
+// lsm.AnonUIDGIDNotApplicable
+type ExportRequest struct {
+	FsID       string   `json:"fs_id"`
+	ExportPath *string  `json:"export_path"`
+	Root       []string `json:"root"`
+	Rw         []string `json:"rw"`
+	Ro         []string `json:"ro"`
+	AnonUID    *int64   `json:"anon_uid"`
+	AnonGID    *int64   `json:"anon_gid"`
+	AuthType   *string  `json:"auth_type"`
+	Options    *string  `json:"options"`
+}
+
+// AccessGroupCreateRequest is the body of POST /access_groups
+type AccessGroupCreateRequest struct {
+	Name     string            `json:"name"`
+	InitID   string            `json:"init_id"`
+	InitType lsm.InitiatorType `json:"init_type"`
+	SystemID string            `json:"system_id"`
+}
+
+// JobResponse is returned for started jobs and by GET /jobs/{id}, Result
+// holds the resulting item of a completed job if it has one and Error the
+// reason a job failed.
+type JobResponse struct {
+	ID      string           `json:"id"`
+	Status  string           `json:"status"`
+	Percent uint8            `json:"percent"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *errors.LsmError `json:"error,omitempty"`
+}
+
+var jobStatusNames = map[lsm.JobStatusType]string{
+	lsm.JobStatusInprogress: "inprogress",
+	lsm.JobStatusComplete:   "complete",
+	lsm.JobStatusError:      "error",
+}
+
+// Server serves the REST API using a storage client, which must be safe for
+// concurrent use as a ClientConnection is.
+type Server struct {
+	client lsm.StorageClient
+}
+
+// NewServer returns a Server using client.
+func NewServer(client lsm.StorageClient) *Server {
+	return &Server{client: client}
+}
+
+// ServeHTTP routes the request to the handler of the resource.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var path = strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var ctx = r.Context()
+
+	switch route(r.Method, path) {
+	case "GET systems":
+		s.list(w, r, func(_ []string) (interface{}, error) { return s.client.SystemsCtx(ctx) })
+	case "GET systems/*":
+		s.item(w, func() (interface{}, error) { return s.system(ctx, path[1]) })
+	case "GET pools":
+		s.list(w, r, func(search []string) (interface{}, error) { return s.client.PoolsCtx(ctx, search...) })
+	case "GET pools/*":
+		s.item(w, func() (interface{}, error) { return s.pool(ctx, path[1]) })
+	case "GET volumes":
+		s.list(w, r, func(search []string) (interface{}, error) { return s.client.VolumesCtx(ctx, search...) })
+	case "POST volumes":
+		s.volumeCreate(w, r)
+	case "GET volumes/*":
+		s.item(w, func() (interface{}, error) { return s.volume(ctx, path[1]) })
+	case "DELETE volumes/*":
+		s.volumeDelete(w, r, path[1])
+	case "POST volumes/*/resize":
+		s.volumeResize(w, r, path[1])
+	case "GET volumes/*/access_groups":
+		s.agsGrantedToVol(w, r, path[1])
+	case "POST volumes/*/access_groups":
+		s.volumeMask(w, r, path[1])
+	case "DELETE volumes/*/access_groups/*":
+		s.volumeUnMask(w, r, path[1], path[3])
+	case "GET disks":
+		s.list(w, r, func(_ []string) (interface{}, error) { return s.client.DisksCtx(ctx) })
+	case "GET fs":
+		s.list(w, r, func(search []string) (interface{}, error) { return s.client.FileSystemsCtx(ctx, search...) })
+	case "POST fs":
+		s.fsCreate(w, r)
+	case "GET fs/*":
+		s.item(w, func() (interface{}, error) { return s.fs(ctx, path[1]) })
+	case "DELETE fs/*":
+		s.fsDelete(w, r, path[1])
+	case "POST fs/*/resize":
+		s.fsResize(w, r, path[1])
+	case "GET exports":
+		s.list(w, r, func(search []string) (interface{}, error) { return s.client.NfsExportsCtx(ctx, search...) })
+	case "POST exports":
+		s.fsExport(w, r)
+	case "GET exports/*":
+		s.item(w, func() (interface{}, error) { return s.export(ctx, path[1]) })
+	case "DELETE exports/*":
+		s.fsUnExport(w, r, path[1])
+	case "GET access_groups":
+		s.list(w, r, func(_ []string) (interface{}, error) { return s.client.AccessGroupsCtx(ctx) })
+	case "POST access_groups":
+		s.accessGroupCreate(w, r)
+	case "GET access_groups/*":
+		s.item(w, func() (interface{}, error) { return s.accessGroup(ctx, path[1]) })
+	case "DELETE access_groups/*":
+		s.accessGroupDelete(w, r, path[1])
+	case "GET access_groups/*/volumes":
+		s.volsMaskedToAg(w, r, path[1])
+	case "GET target_ports":
+		s.list(w, r, func(_ []string) (interface{}, error) { return s.client.TargetPortsCtx(ctx) })
+	case "GET batteries":
+		s.list(w, r, func(_ []string) (interface{}, error) { return s.client.BatteriesCtx(ctx) })
+	case "GET jobs/*":
+		s.jobStatus(w, r, path[1])
+	case "DELETE jobs/*":
+		writeNoContent(w, s.client.JobFreeCtx(ctx, path[1]))
+	default:
+		writeError(w, http.StatusNotFound, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("no such resource %s %s", r.Method, r.URL.Path)})
+	}
+}
+
+// route turns the method and path into a pattern with "*" for the IDs, eg.
+// "DELETE volumes/*", the resource names being at the even positions.
+func route(method string, path []string) string {
+	var pattern = make([]string, len(path))
+	for i, p := range path {
+		if i%2 == 1 {
+			pattern[i] = "*"
+		} else {
+			pattern[i] = p
+		}
+	}
+	return method + " " + strings.Join(pattern, "/")
+}
+
+// item writes the item returned by get.
+func (s *Server) item(w http.ResponseWriter, get func() (interface{}, error)) {
+	var item, err = get()
+	writeResult(w, item, err)
+}
+
+// list writes the result of get, which is passed the search of the query if
+// there is one.
+func (s *Server) list(w http.ResponseWriter, r *http.Request, get func(search []string) (interface{}, error)) {
+	var query = r.URL.Query()
+	var search []string
+
+	if len(query) > 1 {
+		writeLsmError(w, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: "only one search key is supported"})
+		return
+	}
+	for key, values := range query {
+		search = []string{key, values[0]}
+	}
+
+	var items, err = get(search)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) system(ctx context.Context, id string) (*lsm.System, error) {
+	var systems, err = s.client.SystemsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range systems {
+		if systems[i].ID == id {
+			return &systems[i], nil
+		}
+	}
+	return nil, notFound(errors.NotFoundSystem, "system", id)
+}
+
+func (s *Server) pool(ctx context.Context, id string) (*lsm.Pool, error) {
+	var pools, err = s.client.PoolsBy(ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		return nil, notFound(errors.NotFoundPool, "pool", id)
+	}
+	return &pools[0], nil
+}
+
+func (s *Server) volume(ctx context.Context, id string) (*lsm.Volume, error) {
+	var volumes, err = s.client.VolumesBy(ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(volumes) == 0 {
+		return nil, notFound(errors.NotFoundVolume, "volume", id)
+	}
+	return &volumes[0], nil
+}
+
+func (s *Server) fs(ctx context.Context, id string) (*lsm.FileSystem, error) {
+	var fileSystems, err = s.client.FileSystemsBy(ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(fileSystems) == 0 {
+		return nil, notFound(errors.NotFoundFs, "file system", id)
+	}
+	return &fileSystems[0], nil
+}
+
+func (s *Server) export(ctx context.Context, id string) (*lsm.NfsExport, error) {
+	var exports, err = s.client.NfsExportsBy(ctx, lsm.ByID(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(exports) == 0 {
+		return nil, notFound(errors.NotFoundNfsExport, "export", id)
+	}
+	return &exports[0], nil
+}
+
+func (s *Server) accessGroup(ctx context.Context, id string) (*lsm.AccessGroup, error) {
+	var groups, err = s.client.AccessGroupsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i].ID == id {
+			return &groups[i], nil
+		}
+	}
+	return nil, notFound(errors.NotFoundAccessGroup, "access group", id)
+}
+
+func (s *Server) volumeCreate(w http.ResponseWriter, r *http.Request) {
+	var req VolumeCreateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var pool, err = s.pool(r.Context(), req.PoolID)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var provisioning = req.Provisioning
+	if provisioning == 0 {
+		provisioning = lsm.VolumeProvisionTypeDefault
+	}
+
+	var volume, job, createErr = s.client.VolumeCreateJob(
+		r.Context(), pool, req.Name, req.SizeBytes, provisioning)
+	writeCreated(w, r, volume, job, createErr)
+}
+
+func (s *Server) volumeDelete(w http.ResponseWriter, r *http.Request, id string) {
+	var volume, err = s.volume(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var job, deleteErr = s.client.VolumeDeleteJob(r.Context(), volume)
+	writeDeleted(w, r, job, deleteErr)
+}
+
+func (s *Server) volumeResize(w http.ResponseWriter, r *http.Request, id string) {
+	var req ResizeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var volume, err = s.volume(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var resized, job, resizeErr = s.client.VolumeResizeJob(r.Context(), volume, req.SizeBytes)
+	writeUpdated(w, r, resized, job, resizeErr)
+}
+
+func (s *Server) agsGrantedToVol(w http.ResponseWriter, r *http.Request, id string) {
+	var volume, err = s.volume(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var groups, agErr = s.client.AgsGrantedToVolCtx(r.Context(), volume)
+	writeResult(w, groups, agErr)
+}
+
+func (s *Server) volumeMask(w http.ResponseWriter, r *http.Request, id string) {
+	var req MaskRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var volume, ag, err = s.volumeAndGroup(r.Context(), id, req.AccessGroupID)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+	writeNoContent(w, s.client.VolumeMaskCtx(r.Context(), volume, ag))
+}
+
+func (s *Server) volumeUnMask(w http.ResponseWriter, r *http.Request, id string, agID string) {
+	var volume, ag, err = s.volumeAndGroup(r.Context(), id, agID)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+	writeNoContent(w, s.client.VolumeUnMaskCtx(r.Context(), volume, ag))
+}
+
+func (s *Server) volumeAndGroup(ctx context.Context, id string, agID string) (*lsm.Volume, *lsm.AccessGroup, error) {
+	var volume, err = s.volume(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ag, agErr = s.accessGroup(ctx, agID)
+	if agErr != nil {
+		return nil, nil, agErr
+	}
+	return volume, ag, nil
+}
+
+func (s *Server) fsCreate(w http.ResponseWriter, r *http.Request) {
+	var req FsCreateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var pool, err = s.pool(r.Context(), req.PoolID)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var fs, job, createErr = s.client.FsCreateJob(r.Context(), pool, req.Name, req.SizeBytes)
+	writeCreated(w, r, fs, job, createErr)
+}
+
+func (s *Server) fsDelete(w http.ResponseWriter, r *http.Request, id string) {
+	var fs, err = s.fs(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var job, deleteErr = s.client.FsDeleteJob(r.Context(), fs)
+	writeDeleted(w, r, job, deleteErr)
+}
+
+func (s *Server) fsResize(w http.ResponseWriter, r *http.Request, id string) {
+	var req ResizeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var fs, err = s.fs(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var resized, job, resizeErr = s.client.FsResizeJob(r.Context(), fs, req.SizeBytes)
+	writeUpdated(w, r, resized, job, resizeErr)
+}
+
+func (s *Server) fsExport(w http.ResponseWriter, r *http.Request) {
+	var req ExportRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var fs, err = s.fs(r.Context(), req.FsID)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var access = lsm.NfsAccess{
+		Root:    req.Root,
+		Rw:      req.Rw,
+		Ro:      req.Ro,
+		AnonUID: lsm.AnonUIDGIDNotApplicable,
+		AnonGID: lsm.AnonUIDGIDNotApplicable,
+	}
+	if req.AnonUID != nil {
+		access.AnonUID = *req.AnonUID
+	}
+	if req.AnonGID != nil {
+		access.AnonGID = *req.AnonGID
+	}
+
+	var export, exportErr = s.client.FsExportCtx(
+		r.Context(), fs, req.ExportPath, &access, req.AuthType, req.Options)
+	writeCreated(w, r, export, nil, exportErr)
+}
+
+func (s *Server) fsUnExport(w http.ResponseWriter, r *http.Request, id string) {
+	var export, err = s.export(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+	writeNoContent(w, s.client.FsUnExportCtx(r.Context(), export))
+}
+
+func (s *Server) accessGroupCreate(w http.ResponseWriter, r *http.Request) {
+	var req AccessGroupCreateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var system, err = s.system(r.Context(), req.SystemID)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var ag, createErr = s.client.AccessGroupCreateCtx(
+		r.Context(), req.Name, req.InitID, req.InitType, system)
+	writeCreated(w, r, ag, nil, createErr)
+}
+
+func (s *Server) accessGroupDelete(w http.ResponseWriter, r *http.Request, id string) {
+	var ag, err = s.accessGroup(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+	writeNoContent(w, s.client.AccessGroupDeleteCtx(r.Context(), ag))
+}
+
+func (s *Server) volsMaskedToAg(w http.ResponseWriter, r *http.Request, id string) {
+	var ag, err = s.accessGroup(r.Context(), id)
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+
+	var volumes, volErr = s.client.VolsMaskedToAgCtx(r.Context(), ag)
+	writeResult(w, volumes, volErr)
+}
+
+func (s *Server) jobStatus(w http.ResponseWriter, r *http.Request, id string) {
+	var result json.RawMessage
+	var status, percent, err = s.client.JobStatusCtx(r.Context(), id, &result)
+
+	// A failed job is reported in the job, failing to get its status isn't
+	if code, _ := errors.CodeOf(err); code == errors.NotFoundJob || errors.IsRetryable(err) {
+		writeLsmError(w, err)
+		return
+	}
+
+	var job = JobResponse{ID: id, Status: jobStatusNames[status], Percent: percent}
+	if err != nil {
+		job.Error = lsmError(err)
+	} else if len(result) > 0 {
+		job.Result = result
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func wait(r *http.Request) bool {
+	return r.URL.Query().Get("wait") == "true"
+}
+
+func decode(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	var decoder = json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("invalid request body: %s", err)})
+		return false
+	}
+	return true
+}
+
+func notFound(code int32, what string, id string) error {
+	return &errors.LsmError{
+		Code:    code,
+		Message: fmt.Sprintf("%s %s not found", what, id)}
+}
+
+// running waits for the job if the request asks to, it returns the job when
+// it is left running.
+func running(r *http.Request, job *lsm.Job, err error) (*lsm.Job, error) {
+	if err != nil || job == nil {
+		return nil, err
+	}
+	if wait(r) {
+		return nil, job.Wait(r.Context())
+	}
+	if len(job.ID) == 0 {
+		return nil, nil
+	}
+	return job, nil
+}
+
+// writeCreated writes the created item, or the job creating it.
+func writeCreated(w http.ResponseWriter, r *http.Request, item interface{}, job *lsm.Job, err error) {
+	job, err = running(r, job, err)
+	switch {
+	case err != nil:
+		writeLsmError(w, err)
+	case job != nil:
+		writeJob(w, job.ID)
+	default:
+		writeJSON(w, http.StatusCreated, item)
+	}
+}
+
+// writeUpdated writes the changed item, or the job changing it.
+func writeUpdated(w http.ResponseWriter, r *http.Request, item interface{}, job *lsm.Job, err error) {
+	job, err = running(r, job, err)
+	switch {
+	case err != nil:
+		writeLsmError(w, err)
+	case job != nil:
+		writeJob(w, job.ID)
+	default:
+		writeJSON(w, http.StatusOK, item)
+	}
+}
+
+// writeDeleted writes nothing when the delete is done, or the job doing it.
+func writeDeleted(w http.ResponseWriter, r *http.Request, job *lsm.Job, err error) {
+	job, err = running(r, job, err)
+	switch {
+	case err != nil:
+		writeLsmError(w, err)
+	case job != nil:
+		writeJob(w, job.ID)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeJob(w http.ResponseWriter, id string) {
+	w.Header().Set("Location", "/jobs/"+id)
+	writeJSON(w, http.StatusAccepted, JobResponse{
+		ID:     id,
+		Status: jobStatusNames[lsm.JobStatusInprogress]})
+}
+
+func writeResult(w http.ResponseWriter, item interface{}, err error) {
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func writeNoContent(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeLsmError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeLsmError(w http.ResponseWriter, err error) {
+	var e = lsmError(err)
+	writeError(w, httpStatus(e.Code), e)
+}
+
+func writeError(w http.ResponseWriter, status int, e *errors.LsmError) {
+	writeJSON(w, status, e)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// lsmError returns err as an LsmError, other errors are library bugs.
+func lsmError(err error) *errors.LsmError {
+	if e, ok := err.(*errors.LsmError); ok {
+		return e
+	}
+	if code, ok := errors.CodeOf(err); ok {
+		return &errors.LsmError{Code: code, Message: err.Error()}
+	}
+	return &errors.LsmError{Code: errors.LibBug, Message: err.Error()}
+}
+
+// httpStatus maps the error codes to the closest HTTP status.
+func httpStatus(code int32) int {
+	switch {
+	case code >= errors.NotFoundAccessGroup && code <= errors.NotFoundDisk:
+		return http.StatusNotFound
+	}
+
+	switch code {
+	case errors.InvalidArgument, errors.TransPortInvalidArg, errors.UnsupportedSearchKey,
+		errors.EmptyAccessGroup, errors.DiskNotFree:
+		return http.StatusBadRequest
+	case errors.PermissionDenied, errors.PluginAuthFailed, errors.PluginSocketPermission,
+		errors.NotLicensed:
+		return http.StatusForbidden
+	case errors.NameConflict, errors.ExistsInitiator, errors.IsMasked, errors.HasChildDependency,
+		errors.NoStateChange, errors.LastInitInAccessGroup, errors.PoolNotReady,
+		errors.NoSupportOnlineChange, errors.NoSupportOfflineChange:
+		return http.StatusConflict
+	case errors.NotEnoughSpace:
+		return http.StatusInsufficientStorage
+	case errors.NoSupport:
+		return http.StatusNotImplemented
+	case errors.TimeOut:
+		return http.StatusGatewayTimeout
+	case errors.NetworkConRefused, errors.NetworkHostDown, errors.NetworkError,
+		errors.PluginIpcFail, errors.DameonNotRunning, errors.PluginNotExist,
+		errors.TransPortComunication:
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
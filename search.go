@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Search narrows a listing down to the items with a property of the given
+// value, eg.
+//
+//	volumes, err := c.VolumesBy(ctx, lsm.ByPoolID(pool.ID))
+//
+// The listings take only the keys they can be searched by, others fail with
+// UnsupportedSearchKey before reaching the plugin.
+type Search struct {
+	Key   string
+	Value string
+}
+
+// ByID searches by the ID of the item.
+func ByID(id string) Search {
+	return Search{"id", id}
+}
+
+// BySystemID searches by the ID of the system of a pool, volume or file
+// system.
+func BySystemID(id string) Search {
+	return Search{"system_id", id}
+}
+
+// ByPoolID searches by the ID of the pool of a volume or file system.
+func ByPoolID(id string) Search {
+	return Search{"pool_id", id}
+}
+
+// ByFsID searches by the ID of the file system of an NFS export.
+func ByFsID(id string) Search {
+	return Search{"fs_id", id}
+}
+
+// PoolsBy returns the pools matching the search, by ID or system ID.
+func (c *ClientConnection) PoolsBy(ctx context.Context, search Search) ([]Pool, error) {
+	return c.PoolsCtx(ctx, search.Key, search.Value)
+}
+
+// VolumesBy returns the volumes matching the search, by ID, system ID or
+// pool ID.
+func (c *ClientConnection) VolumesBy(ctx context.Context, search Search) ([]Volume, error) {
+	return c.VolumesCtx(ctx, search.Key, search.Value)
+}
+
+// FileSystemsBy returns the file systems matching the search, by ID, system
+// ID or pool ID.
+func (c *ClientConnection) FileSystemsBy(ctx context.Context, search Search) ([]FileSystem, error) {
+	return c.FileSystemsCtx(ctx, search.Key, search.Value)
+}
+
+// NfsExportsBy returns the NFS exports matching the search, by ID or file
+// system ID.
+func (c *ClientConnection) NfsExportsBy(ctx context.Context, search Search) ([]NfsExport, error) {
+	return c.NfsExportsCtx(ctx, search.Key, search.Value)
+}
+
+// searchKeys are the keys each listing can be searched by.
+var searchKeys = map[string][]string{
+	"volumes": {"id", "system_id", "pool_id"},
+	"pools":   {"id", "system_id"},
+	"fs":      {"id", "system_id", "pool_id"},
+	"exports": {"id", "fs_id"},
+}
+
+// searchArgs validates the search of the listing method and returns the
+// arguments for it.
+func searchArgs(method string, what string, search []string) (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+	if !handleSearch(args, search) {
+		return nil, &errors.LsmError{
+			Code: errors.InvalidArgument,
+			Message: fmt.Sprintf(
+				"%s supports 0 or 2 search parameters (key, value), provide %d", what, len(search)),
+			Data: ""}
+	}
+
+	if len(search) > 0 && !contains(searchKeys[method], search[0]) {
+		return nil, &errors.LsmError{
+			Code: errors.UnsupportedSearchKey,
+			Message: fmt.Sprintf("%s can't be searched by %s, only by %s",
+				what, search[0], strings.Join(searchKeys[method], ", "))}
+	}
+	return args, nil
+}
+
+// list calls the listing method with the search, result is a pointer to the
+// slice of items.  Plugins which can't search are asked for everything, the
+// items are filtered here in any case.
+func (c *ClientConnection) list(ctx context.Context, method string, what string, search []string,
+	result interface{}) error {
+
+	args, err := searchArgs(method, what, search)
+	if err != nil {
+		return err
+	}
+
+	err = c.invoke(ctx, method, args, result)
+	if len(search) == 0 {
+		return err
+	}
+	if code, _ := errors.CodeOf(err); code == errors.NoSupport || code == errors.UnsupportedSearchKey {
+		args, _ = searchArgs(method, what, nil)
+		err = c.invoke(ctx, method, args, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	filterSearch(result, search[0], search[1])
+	return nil
+}
+
+// filterSearch removes the items whose property, by JSON name, doesn't have
+// the value from the slice items points to.
+func filterSearch(items interface{}, key string, value string) {
+	slice := reflect.ValueOf(items).Elem()
+	elem := slice.Type().Elem()
+
+	field := -1
+	for i := 0; i < elem.NumField(); i++ {
+		if strings.Split(elem.Field(i).Tag.Get("json"), ",")[0] == key {
+			field = i
+		}
+	}
+	if field < 0 {
+		return
+	}
+
+	kept := 0
+	for i := 0; i < slice.Len(); i++ {
+		if slice.Index(i).Field(field).String() == value {
+			slice.Index(kept).Set(slice.Index(i))
+			kept++
+		}
+	}
+	slice.Set(slice.Slice(0, kept))
+}
@@ -0,0 +1,488 @@
+// SPDX-License-Identifier: 0BSD
+
+package simulator
+
+import (
+	"fmt"
+	"time"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+const authStandard = "standard"
+
+// Caller must hold the lock
+func (s *Simulator) findFs(fs *lsm.FileSystem) (*simFs, error) {
+	if fs == nil {
+		return nil, invalidArg("file system is required")
+	}
+	for _, f := range s.fs {
+		if f.fs.ID == fs.ID {
+			return f, nil
+		}
+	}
+	return nil, notFound(errors.NotFoundFs, "file system", fs.ID)
+}
+
+// Caller must hold the lock
+func (s *Simulator) findSnapShot(f *simFs, ss *lsm.FileSystemSnapShot) (*lsm.FileSystemSnapShot, error) {
+	if ss == nil {
+		return nil, invalidArg("snapshot is required")
+	}
+	for _, item := range f.snapShots {
+		if item.ID == ss.ID {
+			return item, nil
+		}
+	}
+	return nil, notFound(errors.NotFoundFsSs, "snapshot", ss.ID)
+}
+
+// Caller must hold the lock
+func (s *Simulator) addFs(p *simPool, name string, size uint64, parent string) (*simFs, error) {
+	if len(name) == 0 {
+		return nil, invalidArg("file system name is required")
+	}
+	if size == 0 {
+		return nil, invalidArg("file system size must be greater than 0")
+	}
+	if p.pool.ElementType&lsm.PoolElementTypeFs == 0 {
+		return nil, invalidArg("pool %s can't be used for file systems", p.pool.ID)
+	}
+	for _, f := range s.fs {
+		if f.fs.Name == name {
+			return nil, nameConflict("file system", name)
+		}
+	}
+
+	size = roundUp(size)
+	if err := s.reserve(p, size); err != nil {
+		return nil, err
+	}
+
+	f := &simFs{
+		fs: lsm.FileSystem{
+			Class:      "FileSystem",
+			ID:         s.newID("FS_ID"),
+			Name:       name,
+			TotalSpace: size,
+			FreeSpace:  size,
+			SystemID:   SystemID,
+			PoolID:     p.pool.ID,
+		},
+		parent: parent,
+	}
+	s.fs = append(s.fs, f)
+	return f, nil
+}
+
+// Caller must hold the lock
+func (s *Simulator) fsHasChildren(f *simFs) bool {
+	if len(f.snapShots) > 0 {
+		return true
+	}
+	for _, item := range s.fs {
+		if item.parent == f.fs.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Simulator) fsList(search ...string) ([]lsm.FileSystem, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	fs := make([]lsm.FileSystem, 0)
+	for _, f := range s.fs {
+		match, err := searchMatch(search, map[string]string{
+			"id": f.fs.ID, "system_id": f.fs.SystemID, "pool_id": f.fs.PoolID})
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			fs = append(fs, f.fs)
+		}
+	}
+	return fs, nil
+}
+
+func (s *Simulator) fsCreate(pool *lsm.Pool, name string, size uint64) (*lsm.FileSystem, *string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	p, err := s.findPool(pool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := s.addFs(p, name, size, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fs := f.fs
+	return nil, s.newJob(&fs), nil
+}
+
+func (s *Simulator) fsDelete(fs *lsm.FileSystem) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshots go with the file system, clones have a life of their own.
+	for _, item := range s.fs {
+		if item.parent == f.fs.ID {
+			return nil, &errors.LsmError{
+				Code:    errors.HasChildDependency,
+				Message: fmt.Sprintf("file system %s has clone %s", f.fs.ID, item.fs.ID)}
+		}
+	}
+
+	for i, item := range s.fs {
+		if item == f {
+			s.fs = append(s.fs[:i], s.fs[i+1:]...)
+			break
+		}
+	}
+
+	exports := s.exports[:0]
+	for _, e := range s.exports {
+		if e.FsID != f.fs.ID {
+			exports = append(exports, e)
+		}
+	}
+	s.exports = exports
+
+	return s.newJob(nil), nil
+}
+
+func (s *Simulator) fsResize(fs *lsm.FileSystem, newSizeBytes uint64) (*lsm.FileSystem, *string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newSize := roundUp(newSizeBytes)
+	current := f.fs.TotalSpace
+	if newSize == 0 {
+		return nil, nil, invalidArg("file system size must be greater than 0")
+	}
+	if newSize == current {
+		return nil, nil, noStateChange("file system is already the requested size")
+	}
+
+	used := f.fs.TotalSpace - f.fs.FreeSpace
+	if newSize < used {
+		return nil, nil, invalidArg("file system has %d bytes in use", used)
+	}
+
+	if newSize > current {
+		p, err := s.findPool(&lsm.Pool{ID: f.fs.PoolID})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.reserve(p, newSize-current); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	f.fs.TotalSpace = newSize
+	f.fs.FreeSpace = newSize - used
+	resized := f.fs
+	return nil, s.newJob(&resized), nil
+}
+
+func (s *Simulator) fsClone(srcFs *lsm.FileSystem, destName string,
+	optionalSnapShot *lsm.FileSystemSnapShot) (*lsm.FileSystem, *string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	src, err := s.findFs(srcFs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if optionalSnapShot != nil {
+		if _, err := s.findSnapShot(src, optionalSnapShot); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	p, err := s.findPool(&lsm.Pool{ID: src.fs.PoolID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := s.addFs(p, destName, src.fs.TotalSpace, src.fs.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fs := f.fs
+	return nil, s.newJob(&fs), nil
+}
+
+func (s *Simulator) fsFileClone(fs *lsm.FileSystem, srcFileName string, dstFileName string,
+	optionalSnapShot *lsm.FileSystemSnapShot) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	if optionalSnapShot != nil {
+		if _, err := s.findSnapShot(f, optionalSnapShot); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(srcFileName) == 0 || len(dstFileName) == 0 {
+		return nil, invalidArg("source and destination file names are required")
+	}
+
+	return s.newJob(nil), nil
+}
+
+func (s *Simulator) fsSnapShotCreate(fs *lsm.FileSystem, name string) (*lsm.FileSystemSnapShot, *string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(name) == 0 {
+		return nil, nil, invalidArg("snapshot name is required")
+	}
+	for _, ss := range f.snapShots {
+		if ss.Name == name {
+			return nil, nil, nameConflict("snapshot", name)
+		}
+	}
+
+	ss := &lsm.FileSystemSnapShot{
+		Class: "FsSnapshot",
+		ID:    s.newID("FS_SS_ID"),
+		Name:  name,
+		Ts:    uint64(time.Now().Unix()),
+	}
+	f.snapShots = append(f.snapShots, ss)
+
+	result := *ss
+	return nil, s.newJob(&result), nil
+}
+
+func (s *Simulator) fsSnapShotDelete(fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := s.findSnapShot(f, snapShot)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range f.snapShots {
+		if item == ss {
+			f.snapShots = append(f.snapShots[:i], f.snapShots[i+1:]...)
+			break
+		}
+	}
+	return s.newJob(nil), nil
+}
+
+func (s *Simulator) fsSnapShots(fs *lsm.FileSystem) ([]lsm.FileSystemSnapShot, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	snapShots := make([]lsm.FileSystemSnapShot, 0, len(f.snapShots))
+	for _, ss := range f.snapShots {
+		snapShots = append(snapShots, *ss)
+	}
+	return snapShots, nil
+}
+
+func (s *Simulator) fsSnapShotRestore(fs *lsm.FileSystem, snapShot *lsm.FileSystemSnapShot,
+	allFiles bool, files []string, restoreFiles []string) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.findSnapShot(f, snapShot); err != nil {
+		return nil, err
+	}
+
+	if !allFiles {
+		if len(files) == 0 {
+			return nil, invalidArg("'files' is empty and 'all_files' is false")
+		}
+		if len(restoreFiles) > 0 && len(files) != len(restoreFiles) {
+			return nil, invalidArg("'files' and 'restore_files' have different lengths")
+		}
+	}
+
+	return s.newJob(nil), nil
+}
+
+func (s *Simulator) fsHasChildDep(fs *lsm.FileSystem, files []string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return false, err
+	}
+	return s.fsHasChildren(f), nil
+}
+
+func (s *Simulator) fsChildDepRm(fs *lsm.FileSystem, files []string) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.fsHasChildren(f) {
+		return nil, noStateChange("file system has no child dependencies")
+	}
+
+	// Like the C simulator, the snapshots are removed rather than the
+	// dependency on them.
+	f.snapShots = nil
+	for _, item := range s.fs {
+		if item.parent == f.fs.ID {
+			item.parent = ""
+		}
+	}
+	return s.newJob(nil), nil
+}
+
+func exportCopy(e *lsm.NfsExport) lsm.NfsExport {
+	c := *e
+	c.Root = append([]string{}, e.Root...)
+	c.Rw = append([]string{}, e.Rw...)
+	c.Ro = append([]string{}, e.Ro...)
+	return c
+}
+
+func (s *Simulator) exportList(search ...string) ([]lsm.NfsExport, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	exports := make([]lsm.NfsExport, 0)
+	for _, e := range s.exports {
+		match, err := searchMatch(search, map[string]string{"id": e.ID, "fs_id": e.FsID})
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			exports = append(exports, exportCopy(e))
+		}
+	}
+	return exports, nil
+}
+
+func (s *Simulator) exportAuthTypes() ([]string, error) {
+	return []string{authStandard}, nil
+}
+
+func (s *Simulator) fsExport(fs *lsm.FileSystem, exportPath *string,
+	access *lsm.NfsAccess, authType *string, options *string) (*lsm.NfsExport, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := s.findFs(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	if access == nil || (len(access.Ro) == 0 && len(access.Rw) == 0) {
+		return nil, invalidArg("at least 1 host should exist in access.ro or access.rw")
+	}
+	for _, host := range access.Rw {
+		if contains(access.Ro, host) {
+			return nil, invalidArg("host '%s' in both access.ro and access.rw", host)
+		}
+	}
+
+	auth := authStandard
+	if authType != nil {
+		auth = *authType
+	}
+	if auth != authStandard {
+		return nil, invalidArg("unsupported auth type %s", auth)
+	}
+
+	path := fmt.Sprintf("/nfs_exp_%s", f.fs.ID)
+	if exportPath != nil && len(*exportPath) > 0 {
+		path = *exportPath
+	}
+	for _, e := range s.exports {
+		if e.ExportPath == path {
+			return nil, nameConflict("export", path)
+		}
+	}
+
+	e := &lsm.NfsExport{
+		Class:      "NfsExport",
+		ID:         s.newID("EXP_ID"),
+		FsID:       f.fs.ID,
+		ExportPath: path,
+		Auth:       auth,
+		Root:       append([]string{}, access.Root...),
+		Rw:         append([]string{}, access.Rw...),
+		Ro:         append([]string{}, access.Ro...),
+		AnonUID:    access.AnonUID,
+		AnonGID:    access.AnonGID,
+	}
+	if options != nil {
+		e.Options = *options
+	}
+	s.exports = append(s.exports, e)
+
+	result := exportCopy(e)
+	return &result, nil
+}
+
+func (s *Simulator) fsUnExport(export *lsm.NfsExport) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if export == nil {
+		return invalidArg("export is required")
+	}
+
+	for i, e := range s.exports {
+		if e.ID == export.ID {
+			s.exports = append(s.exports[:i], s.exports[i+1:]...)
+			return nil
+		}
+	}
+	return notFound(errors.NotFoundNfsExport, "export", export.ID)
+}
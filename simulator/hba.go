@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: 0BSD
+
+package simulator
+
+import (
+	"fmt"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+var (
+	raidTypes  = []lsm.RaidType{lsm.Raid0, lsm.Raid1, lsm.Raid5, lsm.Raid6, lsm.Raid10, lsm.Raid50, lsm.Raid60}
+	stripSizes = []uint32{8 * 1024, 16 * 1024, 32 * 1024, 64 * 1024, 128 * 1024, 256 * 1024, 512 * 1024, 1024 * 1024}
+)
+
+func (s *Simulator) volRaidInfo(vol *lsm.Volume) (*lsm.VolumeRaidInfo, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.findPool(&lsm.Pool{ID: v.vol.PoolID})
+	if err != nil {
+		return nil, err
+	}
+
+	data := uint32(dataDisks(p.raid, len(p.memberIDs)))
+	return &lsm.VolumeRaidInfo{
+		Type:      p.raid,
+		StripSize: p.stripSize,
+		DiskCount: uint32(len(p.memberIDs)),
+		MinIOSize: p.stripSize,
+		OptIOSize: p.stripSize * data,
+	}, nil
+}
+
+func (s *Simulator) poolMemberInfo(pool *lsm.Pool) (*lsm.PoolMemberInfo, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	p, err := s.findPool(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lsm.PoolMemberInfo{
+		Raid:   p.raid,
+		Member: p.member,
+		ID:     append([]string{}, p.memberIDs...),
+	}, nil
+}
+
+func (s *Simulator) volRaidCreateCapGet(system *lsm.System) (*lsm.SupportedRaidCapability, error) {
+	if err := s.checkSystem(system); err != nil {
+		return nil, err
+	}
+
+	return &lsm.SupportedRaidCapability{
+		Types:       append([]lsm.RaidType{}, raidTypes...),
+		StripeSizes: append([]uint32{}, stripSizes...),
+	}, nil
+}
+
+func (s *Simulator) volRaidCreate(name string, raidType lsm.RaidType,
+	disks []lsm.Disk, stripSize uint32) (*lsm.Volume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(name) == 0 {
+		return nil, invalidArg("volume name is required")
+	}
+
+	supported := false
+	for _, r := range raidTypes {
+		if r == raidType {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, &errors.LsmError{
+			Code:    errors.NoSupport,
+			Message: fmt.Sprintf("RAID type %d is not supported", raidType)}
+	}
+
+	if dataDisks(raidType, len(disks)) == 0 {
+		return nil, invalidArg("%d disks can't be used for RAID type %d", len(disks), raidType)
+	}
+
+	if stripSize == 0 {
+		stripSize = defaultStrip
+	}
+	supported = false
+	for _, size := range stripSizes {
+		if size == stripSize {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, &errors.LsmError{
+			Code:    errors.NoSupport,
+			Message: fmt.Sprintf("strip size %d is not supported", stripSize)}
+	}
+
+	var ids []string
+	for _, disk := range disks {
+		var found *lsm.Disk
+		for _, d := range s.disks {
+			if d.ID == disk.ID {
+				found = d
+				break
+			}
+		}
+		if found == nil {
+			return nil, notFound(errors.NotFoundDisk, "disk", disk.ID)
+		}
+		if found.Status&lsm.DiskStatusFree == 0 || contains(ids, found.ID) {
+			return nil, &errors.LsmError{
+				Code:    errors.DiskNotFree,
+				Message: fmt.Sprintf("disk %s is not free", found.ID)}
+		}
+		ids = append(ids, found.ID)
+	}
+
+	for _, v := range s.volumes {
+		if v.vol.Name == name {
+			return nil, nameConflict("volume", name)
+		}
+	}
+
+	p := s.addPool(s.newID("POOL_ID"), name, raidType, ids, stripSize, true)
+	v, err := s.addVolume(p, name, p.pool.TotalSpace, "")
+	if err != nil {
+		s.removePool(p.pool.ID)
+		return nil, err
+	}
+
+	vol := v.vol
+	return &vol, nil
+}
+
+func (s *Simulator) sysReadCachePctSet(system *lsm.System, readPercent uint32) error {
+	if err := s.checkSystem(system); err != nil {
+		return err
+	}
+	if readPercent > 100 {
+		return invalidArg("read cache percentage %d is greater than 100", readPercent)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.system.ReadCachePct = int8(readPercent)
+	return nil
+}
+
+func (s *Simulator) volCacheInfo(volume *lsm.Volume) (*lsm.VolumeCacheInfo, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(volume)
+	if err != nil {
+		return nil, err
+	}
+
+	info := v.cache
+	return &info, nil
+}
+
+func (s *Simulator) volPhyDiskCacheSet(volume *lsm.Volume, pdc lsm.PhysicalDiskCache) error {
+	if pdc != lsm.PhysicalDiskCacheEnabled && pdc != lsm.PhysicalDiskCacheDisabled &&
+		pdc != lsm.PhysicalDiskCacheUseDiskSetting {
+		return invalidArg("invalid physical disk cache setting %d", pdc)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(volume)
+	if err != nil {
+		return err
+	}
+	v.cache.PhysicalDiskStatus = pdc
+	return nil
+}
+
+func (s *Simulator) volWriteCacheSet(volume *lsm.Volume, wcp lsm.WriteCachePolicy) error {
+	var status lsm.WriteCacheStatus
+	switch wcp {
+	case lsm.WriteCachePolicyWriteBack, lsm.WriteCachePolicyAuto:
+		// The batteries are always healthy, so auto means write back
+		status = lsm.WriteCacheStatusWriteBack
+	case lsm.WriteCachePolicyWriteThrough:
+		status = lsm.WriteCacheStatusWriteThrough
+	default:
+		return invalidArg("invalid write cache policy %d", wcp)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(volume)
+	if err != nil {
+		return err
+	}
+	v.cache.WriteSetting = wcp
+	v.cache.WriteStatus = status
+	return nil
+}
+
+func (s *Simulator) volReadCacheSet(volume *lsm.Volume, rcp lsm.ReadCachePolicy) error {
+	var status lsm.ReadCacheStatus
+	switch rcp {
+	case lsm.ReadCachePolicyEnabled:
+		status = lsm.ReadCacheStatusEnabled
+	case lsm.ReadCachePolicyDisabled:
+		status = lsm.ReadCacheStatusDisabled
+	default:
+		return invalidArg("invalid read cache policy %d", rcp)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(volume)
+	if err != nil {
+		return err
+	}
+	v.cache.ReadSetting = rcp
+	v.cache.ReadStatus = status
+	return nil
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: 0BSD
+
+package simulator
+
+import (
+	"time"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+)
+
+const jobSteps = 10
+
+// newJob returns a job which completes with item after JobDuration.  The
+// simulated array changes state as soon as an operation is requested, the job
+// only hides the result from the client until then.
+func (s *Simulator) newJob(item interface{}) *string {
+	duration := s.JobDuration
+	return s.jobs.Start(func(progress lsm.JobProgress) (interface{}, error) {
+		for i := 1; i < jobSteps && duration > 0; i++ {
+			time.Sleep(duration / jobSteps)
+			progress(uint8(i * 100 / jobSteps))
+		}
+		time.Sleep(duration / jobSteps)
+		return item, nil
+	})
+}
@@ -0,0 +1,798 @@
+// SPDX-License-Identifier: 0BSD
+
+package simulator
+
+import (
+	"fmt"
+	"strings"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// dataDisks returns how many disks worth of capacity a RAID set of n disks
+// provides, or 0 if n disks can't make up that RAID type.
+func dataDisks(raid lsm.RaidType, n int) int {
+	switch raid {
+	case lsm.Raid0:
+		if n >= 1 {
+			return n
+		}
+	case lsm.Raid1:
+		if n == 2 {
+			return 1
+		}
+	case lsm.Raid5:
+		if n >= 3 {
+			return n - 1
+		}
+	case lsm.Raid6:
+		if n >= 4 {
+			return n - 2
+		}
+	case lsm.Raid10:
+		if n >= 4 && n%2 == 0 {
+			return n / 2
+		}
+	case lsm.Raid50:
+		if n >= 6 && n%2 == 0 {
+			return n - 2
+		}
+	case lsm.Raid60:
+		if n >= 8 && n%2 == 0 {
+			return n - 4
+		}
+	}
+	return 0
+}
+
+// Caller must hold the lock
+func (s *Simulator) addPool(id string, name string, raid lsm.RaidType,
+	diskIDs []string, stripSize uint32, raidCreated bool) *simPool {
+
+	for _, d := range s.disks {
+		if contains(diskIDs, d.ID) {
+			d.Status &^= lsm.DiskStatusFree
+		}
+	}
+
+	elements := lsm.PoolElementTypeVolume | lsm.PoolElementTypeVolumeFull
+	if !raidCreated {
+		elements |= lsm.PoolElementTypeFs | lsm.PoolElementTypeVolumeThin | lsm.PoolElementTypeDelta
+	}
+
+	p := &simPool{
+		pool: lsm.Pool{
+			Class:       "Pool",
+			ID:          id,
+			Name:        name,
+			ElementType: elements,
+			TotalSpace:  uint64(dataDisks(raid, len(diskIDs))) * diskSize,
+			Status:      lsm.PoolStatusOk,
+			SystemID:    SystemID,
+		},
+		raid:        raid,
+		member:      lsm.MemberTypeDisk,
+		memberIDs:   diskIDs,
+		stripSize:   stripSize,
+		raidCreated: raidCreated,
+	}
+	s.pools = append(s.pools, p)
+	return p
+}
+
+// Caller must hold the lock
+func (s *Simulator) removePool(id string) {
+	for i, p := range s.pools {
+		if p.pool.ID == id {
+			for _, d := range s.disks {
+				if contains(p.memberIDs, d.ID) {
+					d.Status |= lsm.DiskStatusFree
+				}
+			}
+			s.pools = append(s.pools[:i], s.pools[i+1:]...)
+			return
+		}
+	}
+}
+
+// Caller must hold the lock
+func (s *Simulator) findPool(pool *lsm.Pool) (*simPool, error) {
+	if pool == nil {
+		return nil, invalidArg("pool is required")
+	}
+	for _, p := range s.pools {
+		if p.pool.ID == pool.ID {
+			return p, nil
+		}
+	}
+	return nil, notFound(errors.NotFoundPool, "pool", pool.ID)
+}
+
+// Caller must hold the lock
+func (s *Simulator) freeSpace(p *simPool) uint64 {
+	var used uint64
+	for _, v := range s.volumes {
+		if v.vol.PoolID == p.pool.ID {
+			used += volumeSize(&v.vol)
+		}
+	}
+	for _, f := range s.fs {
+		if f.fs.PoolID == p.pool.ID {
+			used += f.fs.TotalSpace
+		}
+	}
+	if used > p.pool.TotalSpace {
+		return 0
+	}
+	return p.pool.TotalSpace - used
+}
+
+// Caller must hold the lock
+func (s *Simulator) reserve(p *simPool, size uint64) error {
+	if size > s.freeSpace(p) {
+		return &errors.LsmError{
+			Code:    errors.NotEnoughSpace,
+			Message: fmt.Sprintf("pool %s has insufficient free space for %d bytes", p.pool.ID, size)}
+	}
+	return nil
+}
+
+func (s *Simulator) poolList(search ...string) ([]lsm.Pool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pools := make([]lsm.Pool, 0)
+	for _, p := range s.pools {
+		match, err := searchMatch(search, map[string]string{
+			"id": p.pool.ID, "system_id": p.pool.SystemID})
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			pool := p.pool
+			pool.FreeSpace = s.freeSpace(p)
+			pools = append(pools, pool)
+		}
+	}
+	return pools, nil
+}
+
+func (s *Simulator) diskList() ([]lsm.Disk, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	disks := make([]lsm.Disk, 0, len(s.disks))
+	for _, d := range s.disks {
+		disks = append(disks, *d)
+	}
+	return disks, nil
+}
+
+func volumeSize(v *lsm.Volume) uint64 {
+	return v.BlockSize * v.NumOfBlocks
+}
+
+// Caller must hold the lock
+func (s *Simulator) findVolume(vol *lsm.Volume) (*simVolume, error) {
+	if vol == nil {
+		return nil, invalidArg("volume is required")
+	}
+	for _, v := range s.volumes {
+		if v.vol.ID == vol.ID {
+			return v, nil
+		}
+	}
+	return nil, notFound(errors.NotFoundVolume, "volume", vol.ID)
+}
+
+// Caller must hold the lock
+func (s *Simulator) addVolume(p *simPool, name string, size uint64, parent string) (*simVolume, error) {
+	if len(name) == 0 {
+		return nil, invalidArg("volume name is required")
+	}
+	if size == 0 {
+		return nil, invalidArg("volume size must be greater than 0")
+	}
+	if p.pool.ElementType&lsm.PoolElementTypeVolume == 0 {
+		return nil, invalidArg("pool %s can't be used for volumes", p.pool.ID)
+	}
+	for _, v := range s.volumes {
+		if v.vol.Name == name {
+			return nil, nameConflict("volume", name)
+		}
+	}
+
+	size = roundUp(size)
+	if err := s.reserve(p, size); err != nil {
+		return nil, err
+	}
+
+	id := s.newID("VOL_ID")
+	v := &simVolume{
+		vol: lsm.Volume{
+			Class:       "Volume",
+			ID:          id,
+			Name:        name,
+			Enabled:     true,
+			BlockSize:   blockSize,
+			NumOfBlocks: size / blockSize,
+			Vpd83:       fmt.Sprintf("600508b1001c%020x", s.lastID),
+			SystemID:    SystemID,
+			PoolID:      p.pool.ID,
+		},
+		parent: parent,
+		cache: lsm.VolumeCacheInfo{
+			WriteSetting:       lsm.WriteCachePolicyAuto,
+			WriteStatus:        lsm.WriteCacheStatusWriteBack,
+			ReadSetting:        lsm.ReadCachePolicyEnabled,
+			ReadStatus:         lsm.ReadCacheStatusEnabled,
+			PhysicalDiskStatus: lsm.PhysicalDiskCacheUseDiskSetting,
+		},
+	}
+	s.volumes = append(s.volumes, v)
+	return v, nil
+}
+
+func (s *Simulator) volumeList(search ...string) ([]lsm.Volume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	volumes := make([]lsm.Volume, 0)
+	for _, v := range s.volumes {
+		match, err := searchMatch(search, map[string]string{
+			"id": v.vol.ID, "system_id": v.vol.SystemID, "pool_id": v.vol.PoolID})
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			volumes = append(volumes, v.vol)
+		}
+	}
+	return volumes, nil
+}
+
+func (s *Simulator) volumeCreate(pool *lsm.Pool, volumeName string, size uint64,
+	provisioning lsm.VolumeProvisionType) (*lsm.Volume, *string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch provisioning {
+	case lsm.VolumeProvisionTypeThin, lsm.VolumeProvisionTypeFull, lsm.VolumeProvisionTypeDefault:
+	default:
+		return nil, nil, invalidArg("invalid provisioning type %d", provisioning)
+	}
+
+	p, err := s.findPool(pool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err := s.addVolume(p, volumeName, size, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vol := v.vol
+	return nil, s.newJob(&vol), nil
+}
+
+// Caller must hold the lock
+func (s *Simulator) isMasked(volID string) bool {
+	for _, vols := range s.masks {
+		if vols[volID] {
+			return true
+		}
+	}
+	return false
+}
+
+// Caller must hold the lock
+func (s *Simulator) volHasChildren(volID string) bool {
+	for _, v := range s.volumes {
+		if v.parent == volID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Simulator) volumeDelete(vol *lsm.Volume) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.isMasked(v.vol.ID) {
+		return nil, &errors.LsmError{
+			Code:    errors.IsMasked,
+			Message: fmt.Sprintf("volume %s is masked to one or more access groups", v.vol.ID)}
+	}
+
+	if s.volHasChildren(v.vol.ID) {
+		return nil, &errors.LsmError{
+			Code:    errors.HasChildDependency,
+			Message: fmt.Sprintf("volume %s has child dependencies", v.vol.ID)}
+	}
+
+	for i, item := range s.volumes {
+		if item == v {
+			s.volumes = append(s.volumes[:i], s.volumes[i+1:]...)
+			break
+		}
+	}
+
+	for _, p := range s.pools {
+		if p.pool.ID == v.vol.PoolID && p.raidCreated {
+			s.removePool(p.pool.ID)
+			break
+		}
+	}
+
+	return s.newJob(nil), nil
+}
+
+func (s *Simulator) volumeReplicate(optionalPool *lsm.Pool, repType lsm.VolumeReplicateType,
+	sourceVolume *lsm.Volume, name string) (*lsm.Volume, *string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	src, err := s.findVolume(sourceVolume)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var parent string
+	switch repType {
+	case lsm.VolumeReplicateTypeCopy:
+	case lsm.VolumeReplicateTypeClone, lsm.VolumeReplicateTypeMirrorSync, lsm.VolumeReplicateTypeMirrorAsync:
+		parent = src.vol.ID
+	default:
+		return nil, nil, invalidArg("invalid replication type %d", repType)
+	}
+
+	var p *simPool
+	if optionalPool != nil {
+		p, err = s.findPool(optionalPool)
+	} else {
+		p, err = s.findPool(&lsm.Pool{ID: src.vol.PoolID})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err := s.addVolume(p, name, volumeSize(&src.vol), parent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vol := v.vol
+	return nil, s.newJob(&vol), nil
+}
+
+func (s *Simulator) volumeReplicateRange(repType lsm.VolumeReplicateType, srcVol *lsm.Volume,
+	dstVol *lsm.Volume, ranges []lsm.BlockRange) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if repType != lsm.VolumeReplicateTypeCopy && repType != lsm.VolumeReplicateTypeClone {
+		return nil, invalidArg("invalid replication type %d", repType)
+	}
+
+	src, err := s.findVolume(srcVol)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := s.findVolume(dstVol)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ranges) == 0 {
+		return nil, invalidArg("no block ranges specified")
+	}
+
+	for _, r := range ranges {
+		if r.SrcBlkAddr+r.BlkCount > src.vol.NumOfBlocks ||
+			r.DstBlkAddr+r.BlkCount > dst.vol.NumOfBlocks {
+			return nil, invalidArg("block range %+v is outside of the volume", r)
+		}
+	}
+
+	return s.newJob(nil), nil
+}
+
+func (s *Simulator) volumeRepRangeBlkSize(system *lsm.System) (uint32, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.checkSystem(system); err != nil {
+		return 0, err
+	}
+	return blockSize, nil
+}
+
+func (s *Simulator) volumeResize(vol *lsm.Volume, newSizeBytes uint64) (*lsm.Volume, *string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newSize := roundUp(newSizeBytes)
+	current := volumeSize(&v.vol)
+	if newSize == 0 {
+		return nil, nil, invalidArg("volume size must be greater than 0")
+	}
+	if newSize == current {
+		return nil, nil, noStateChange("volume is already the requested size")
+	}
+
+	if newSize > current {
+		p, err := s.findPool(&lsm.Pool{ID: v.vol.PoolID})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.reserve(p, newSize-current); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	v.vol.NumOfBlocks = newSize / blockSize
+	resized := v.vol
+	return nil, s.newJob(&resized), nil
+}
+
+func (s *Simulator) volumeAdminState(vol *lsm.Volume, enabled bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return err
+	}
+	if bool(v.vol.Enabled) == enabled {
+		return noStateChange("volume is already in the requested state")
+	}
+	v.vol.Enabled = lsm.LsmBool(enabled)
+	return nil
+}
+
+func (s *Simulator) volumeEnable(vol *lsm.Volume) error {
+	return s.volumeAdminState(vol, true)
+}
+
+func (s *Simulator) volumeDisable(vol *lsm.Volume) error {
+	return s.volumeAdminState(vol, false)
+}
+
+func (s *Simulator) volHasChildDep(vol *lsm.Volume) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return false, err
+	}
+	return s.volHasChildren(v.vol.ID), nil
+}
+
+func (s *Simulator) volChildDepRm(vol *lsm.Volume) (*string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Children become independent copies
+	for _, child := range s.volumes {
+		if child.parent == v.vol.ID {
+			child.parent = ""
+		}
+	}
+	return s.newJob(nil), nil
+}
+
+func (s *Simulator) volIdentLed(volume *lsm.Volume) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, err := s.findVolume(volume)
+	return err
+}
+
+// Caller must hold the lock
+func (s *Simulator) findAg(ag *lsm.AccessGroup) (*lsm.AccessGroup, error) {
+	if ag == nil {
+		return nil, invalidArg("access group is required")
+	}
+	for _, a := range s.ags {
+		if a.ID == ag.ID {
+			return a, nil
+		}
+	}
+	return nil, notFound(errors.NotFoundAccessGroup, "access group", ag.ID)
+}
+
+func agCopy(ag *lsm.AccessGroup) lsm.AccessGroup {
+	c := *ag
+	c.InitIDs = append([]string(nil), ag.InitIDs...)
+	return c
+}
+
+func checkInitType(initType lsm.InitiatorType) error {
+	if initType != lsm.InitiatorTypeWwpn && initType != lsm.InitiatorTypeIscsiIqn {
+		return invalidArg("unsupported initiator type %d", initType)
+	}
+	return nil
+}
+
+// Caller must hold the lock
+func (s *Simulator) initOwner(initID string) *lsm.AccessGroup {
+	for _, a := range s.ags {
+		if contains(a.InitIDs, initID) {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) accessGroupList() ([]lsm.AccessGroup, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ags := make([]lsm.AccessGroup, 0, len(s.ags))
+	for _, a := range s.ags {
+		ags = append(ags, agCopy(a))
+	}
+	return ags, nil
+}
+
+func (s *Simulator) accessGroupCreate(name string, initID string,
+	initType lsm.InitiatorType, system *lsm.System) (*lsm.AccessGroup, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.checkSystem(system); err != nil {
+		return nil, err
+	}
+	if err := checkInitType(initType); err != nil {
+		return nil, err
+	}
+	if len(name) == 0 {
+		return nil, invalidArg("access group name is required")
+	}
+
+	for _, a := range s.ags {
+		if a.Name == name {
+			return nil, nameConflict("access group", name)
+		}
+	}
+
+	if owner := s.initOwner(initID); owner != nil {
+		return nil, &errors.LsmError{
+			Code:    errors.ExistsInitiator,
+			Message: fmt.Sprintf("initiator %s already in access group %s", initID, owner.ID)}
+	}
+
+	ag := &lsm.AccessGroup{
+		Class:         "AccessGroup",
+		ID:            s.newID("AG_ID"),
+		Name:          name,
+		InitIDs:       []string{initID},
+		InitiatorType: initType,
+		SystemID:      SystemID,
+	}
+	s.ags = append(s.ags, ag)
+
+	result := agCopy(ag)
+	return &result, nil
+}
+
+func (s *Simulator) accessGroupDelete(ag *lsm.AccessGroup) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	a, err := s.findAg(ag)
+	if err != nil {
+		return err
+	}
+
+	if len(s.masks[a.ID]) > 0 {
+		return &errors.LsmError{
+			Code:    errors.IsMasked,
+			Message: fmt.Sprintf("access group %s has volumes masked to it", a.ID)}
+	}
+
+	for i, item := range s.ags {
+		if item == a {
+			s.ags = append(s.ags[:i], s.ags[i+1:]...)
+			break
+		}
+	}
+	delete(s.masks, a.ID)
+	return nil
+}
+
+func (s *Simulator) accessGroupInitAdd(ag *lsm.AccessGroup,
+	initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	a, err := s.findAg(ag)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkInitType(initType); err != nil {
+		return nil, err
+	}
+
+	if owner := s.initOwner(initID); owner != nil {
+		if owner != a {
+			return nil, &errors.LsmError{
+				Code:    errors.ExistsInitiator,
+				Message: fmt.Sprintf("initiator %s already in access group %s", initID, owner.ID)}
+		}
+	} else {
+		a.InitIDs = append(a.InitIDs, initID)
+		if a.InitiatorType != initType {
+			a.InitiatorType = lsm.InitiatorTypeMixed
+		}
+	}
+
+	result := agCopy(a)
+	return &result, nil
+}
+
+func (s *Simulator) accessGroupInitDelete(ag *lsm.AccessGroup,
+	initID string, initType lsm.InitiatorType) (*lsm.AccessGroup, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	a, err := s.findAg(ag)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range a.InitIDs {
+		if id == initID {
+			if len(a.InitIDs) == 1 {
+				return nil, &errors.LsmError{
+					Code:    errors.LastInitInAccessGroup,
+					Message: fmt.Sprintf("refusing to remove the last initiator of access group %s", a.ID)}
+			}
+			a.InitIDs = append(a.InitIDs[:i:i], a.InitIDs[i+1:]...)
+			break
+		}
+	}
+
+	// Work out the type from what is left
+	a.InitiatorType = initTypeOf(a.InitIDs[0])
+	for _, id := range a.InitIDs[1:] {
+		if initTypeOf(id) != a.InitiatorType {
+			a.InitiatorType = lsm.InitiatorTypeMixed
+			break
+		}
+	}
+
+	result := agCopy(a)
+	return &result, nil
+}
+
+// initTypeOf tells a WWPN from an iSCSI name, the client has already validated
+// it is one or the other.
+func initTypeOf(initID string) lsm.InitiatorType {
+	for _, prefix := range []string{"iqn", "eui", "naa"} {
+		if strings.HasPrefix(initID, prefix) {
+			return lsm.InitiatorTypeIscsiIqn
+		}
+	}
+	return lsm.InitiatorTypeWwpn
+}
+
+func (s *Simulator) volumeMask(vol *lsm.Volume, ag *lsm.AccessGroup) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return err
+	}
+	a, err := s.findAg(ag)
+	if err != nil {
+		return err
+	}
+
+	if s.masks[a.ID][v.vol.ID] {
+		return noStateChange("volume is already masked to access group")
+	}
+	if s.masks[a.ID] == nil {
+		s.masks[a.ID] = make(map[string]bool)
+	}
+	s.masks[a.ID][v.vol.ID] = true
+	return nil
+}
+
+func (s *Simulator) volumeUnMask(vol *lsm.Volume, ag *lsm.AccessGroup) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return err
+	}
+	a, err := s.findAg(ag)
+	if err != nil {
+		return err
+	}
+
+	if !s.masks[a.ID][v.vol.ID] {
+		return noStateChange("volume is not masked to access group")
+	}
+	delete(s.masks[a.ID], v.vol.ID)
+	return nil
+}
+
+func (s *Simulator) volsMaskedToAg(ag *lsm.AccessGroup) ([]lsm.Volume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	a, err := s.findAg(ag)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]lsm.Volume, 0)
+	for _, v := range s.volumes {
+		if s.masks[a.ID][v.vol.ID] {
+			volumes = append(volumes, v.vol)
+		}
+	}
+	return volumes, nil
+}
+
+func (s *Simulator) agsGrantedToVol(vol *lsm.Volume) ([]lsm.AccessGroup, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.findVolume(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	ags := make([]lsm.AccessGroup, 0)
+	for _, a := range s.ags {
+		if s.masks[a.ID][v.vol.ID] {
+			ags = append(ags, agCopy(a))
+		}
+	}
+	return ags, nil
+}
+
+func (s *Simulator) iscsiChapAuthSet(initID string, inUser *string, inPassword *string,
+	outUser *string, outPassword *string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(initID) == 0 {
+		return invalidArg("initiator ID is required")
+	}
+	s.chap[initID] = chapAuth{inUser, inPassword, outUser, outPassword}
+	return nil
+}
+
+func contains(s []string, v string) bool {
+	for _, a := range s {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,390 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package simulator is an in-memory storage array served through the plugin
+// callbacks, for exercising clients and tooling without real hardware or lsmd.
+package simulator
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+const (
+	// Description is reported by plugin_info
+	Description = "Go storage simulator"
+
+	// Version is reported by plugin_info
+	Version = "0.1.0"
+
+	// SystemID is the ID of the one and only simulated system
+	SystemID = "sim-01"
+
+	blockSize      = 512
+	diskSize       = 2 * 1024 * 1024 * 1024 * 1024
+	defaultStrip   = 128 * 1024
+	defaultTimeout = 30000
+	simTimeVarName = "LSM_SIM_TIME"
+)
+
+type simPool struct {
+	pool      lsm.Pool
+	raid      lsm.RaidType
+	member    lsm.MemberType
+	memberIDs []string
+	stripSize uint32
+
+	// Created by VolRaidCreate, it goes away along with its volume
+	raidCreated bool
+}
+
+type simVolume struct {
+	vol    lsm.Volume
+	parent string
+	cache  lsm.VolumeCacheInfo
+}
+
+type simFs struct {
+	fs        lsm.FileSystem
+	parent    string
+	snapShots []*lsm.FileSystemSnapShot
+}
+
+type chapAuth struct {
+	inUser      *string
+	inPassword  *string
+	outUser     *string
+	outPassword *string
+}
+
+// Simulator is the state of one simulated storage array.  It may be served to
+// any number of clients at the same time, all of which see the same array.
+type Simulator struct {
+	// JobDuration is how long asynchronous operations report themselves as in
+	// progress.  It defaults to the number of seconds in LSM_SIM_TIME, or 0.
+	JobDuration time.Duration
+
+	lock      sync.Mutex
+	lastID    int
+	system    lsm.System
+	pools     []*simPool
+	disks     []*lsm.Disk
+	volumes   []*simVolume
+	fs        []*simFs
+	exports   []*lsm.NfsExport
+	ags       []*lsm.AccessGroup
+	masks     map[string]map[string]bool
+	ports     []lsm.TargetPort
+	batteries []lsm.Battery
+	chap      map[string]chapAuth
+	jobs      *lsm.JobManager
+}
+
+// New returns a simulator populated with one system, its disks, pools, target
+// ports and batteries.  There are no volumes, file systems or access groups.
+func New() *Simulator {
+	s := &Simulator{
+		masks: make(map[string]map[string]bool),
+		chap:  make(map[string]chapAuth),
+		jobs:  lsm.NewJobManager(),
+	}
+
+	if simTime, err := strconv.ParseFloat(os.Getenv(simTimeVarName), 64); err == nil && simTime > 0 {
+		s.JobDuration = time.Duration(simTime * float64(time.Second))
+	}
+
+	s.system = lsm.System{
+		Class:        "System",
+		ID:           SystemID,
+		Name:         "LSM simulated storage plug-in",
+		Status:       lsm.SystemStatusOk,
+		FwVersion:    Version,
+		ReadCachePct: 10,
+		SystemMode:   lsm.SystemModeHardwareRaid,
+	}
+
+	type diskKind struct {
+		diskType lsm.DiskType
+		linkType lsm.DiskLinkType
+		rpm      int
+	}
+	kinds := []diskKind{
+		{lsm.DiskTypeSata, lsm.DiskLinkTypeAta, 7200},
+		{lsm.DiskTypeSas, lsm.DiskLinkTypeSas, 15000},
+		{lsm.DiskTypeSsd, lsm.DiskLinkTypeSas, 0},
+		{lsm.DiskTypeNlSas, lsm.DiskLinkTypeSas, 7200},
+	}
+	for i := 0; i < 20; i++ {
+		k := kinds[(i/5)%len(kinds)]
+		s.disks = append(s.disks, &lsm.Disk{
+			Class:       "Disk",
+			ID:          fmt.Sprintf("DISK_ID_%03d", i),
+			Name:        fmt.Sprintf("Sim Disk %03d", i),
+			DiskType:    k.diskType,
+			BlockSize:   blockSize,
+			NumOfBlocks: diskSize / blockSize,
+			Status:      lsm.DiskStatusOk | lsm.DiskStatusFree,
+			SystemID:    SystemID,
+			Location:    fmt.Sprintf("Port: %d Box: 1 Bay: %d", i/4, i%4),
+			Rpm:         k.rpm,
+			LinkType:    k.linkType,
+			Vpd83:       fmt.Sprintf("5000c500%024x", i+1),
+		})
+	}
+
+	seed := []struct {
+		raid  lsm.RaidType
+		disks []int
+	}{
+		{lsm.Raid1, []int{0, 1}},
+		{lsm.Raid5, []int{5, 6, 7, 8}},
+		{lsm.Raid6, []int{10, 11, 12, 13}},
+		{lsm.Raid10, []int{15, 16, 17, 18}},
+	}
+	for i, p := range seed {
+		var ids []string
+		for _, d := range p.disks {
+			ids = append(ids, s.disks[d].ID)
+		}
+		s.addPool(fmt.Sprintf("POOL_ID_%02d", i+1), fmt.Sprintf("Pool %d", i+1),
+			p.raid, ids, defaultStrip, false)
+	}
+
+	s.ports = []lsm.TargetPort{
+		{Class: "TargetPort", ID: "TGT_PORT_ID_01", PortType: lsm.PortTypeFc,
+			ServiceAddress: "50:0a:09:86:99:4b:8d:c5", NetworkAddress: "50:0a:09:86:99:4b:8d:c5",
+			PhysicalAddress: "50:0a:09:86:99:4b:8d:c5", PhysicalName: "FC_a_0b", SystemID: SystemID},
+		{Class: "TargetPort", ID: "TGT_PORT_ID_02", PortType: lsm.PortTypeFCoE,
+			ServiceAddress: "50:0a:09:86:99:4b:8d:c6", NetworkAddress: "50:0a:09:86:99:4b:8d:c6",
+			PhysicalAddress: "00:1b:21:3f:a1:b4", PhysicalName: "FCoE_b_0c", SystemID: SystemID},
+		{Class: "TargetPort", ID: "TGT_PORT_ID_03", PortType: lsm.PortTypeIscsi,
+			ServiceAddress: "iqn.1986-05.com.example:sim-tgt-03", NetworkAddress: "sim-iscsi-tgt-3.example.com:3260",
+			PhysicalAddress: "a4:4e:31:47:f4:e0", PhysicalName: "iSCSI_c_0d", SystemID: SystemID},
+		{Class: "TargetPort", ID: "TGT_PORT_ID_04", PortType: lsm.PortTypeIscsi,
+			ServiceAddress: "iqn.1986-05.com.example:sim-tgt-03", NetworkAddress: "10.0.0.1:3260",
+			PhysicalAddress: "a4:4e:31:47:f4:e1", PhysicalName: "iSCSI_c_0e", SystemID: SystemID},
+	}
+
+	s.batteries = []lsm.Battery{
+		{Class: "Battery", ID: "BAT_ID_01", Name: "Li-ion Battery 01", BatteryType: lsm.BatteryTypeChemical,
+			Status: lsm.BatteryStatusOk, SystemID: SystemID},
+		{Class: "Battery", ID: "BAT_ID_02", Name: "Capacitor 01", BatteryType: lsm.BatteryTypeCapacitor,
+			Status: lsm.BatteryStatusOk, SystemID: SystemID},
+	}
+
+	return s
+}
+
+// Callbacks returns the plugin callbacks for one client connection.  Per
+// connection state, eg. the timeout, is kept apart, everything else is shared.
+func (s *Simulator) Callbacks() *lsm.PluginCallBacks {
+	var tmo uint32 = defaultTimeout
+	var tmoLock sync.Mutex
+
+	callbacks := &lsm.PluginCallBacks{
+		Mgmt: lsm.ManagementOps{
+			TimeOutSet: func(timeout uint32) error {
+				tmoLock.Lock()
+				defer tmoLock.Unlock()
+				tmo = timeout
+				return nil
+			},
+			TimeOutGet: func() uint32 {
+				tmoLock.Lock()
+				defer tmoLock.Unlock()
+				return tmo
+			},
+			JobStatus: s.jobs.Status,
+			JobFree:   s.jobs.Free,
+			Systems:   s.systems,
+			Pools:     s.poolList,
+			PluginRegister: func(p *lsm.PluginRegister) error {
+				tmoLock.Lock()
+				defer tmoLock.Unlock()
+				if p.Timeout > 0 {
+					tmo = p.Timeout
+				}
+				return nil
+			},
+			PluginUnregister: func() error { return nil },
+		},
+		San: lsm.SanOps{
+			Volumes:               s.volumeList,
+			VolumeCreate:          s.volumeCreate,
+			VolumeDelete:          s.volumeDelete,
+			Disks:                 s.diskList,
+			VolumeReplicate:       s.volumeReplicate,
+			VolumeReplicateRange:  s.volumeReplicateRange,
+			VolumeRepRangeBlkSize: s.volumeRepRangeBlkSize,
+			VolumeResize:          s.volumeResize,
+			VolumeEnable:          s.volumeEnable,
+			VolumeDisable:         s.volumeDisable,
+			VolumeMask:            s.volumeMask,
+			VolumeUnMask:          s.volumeUnMask,
+			VolsMaskedToAg:        s.volsMaskedToAg,
+			VolHasChildDep:        s.volHasChildDep,
+			VolChildDepRm:         s.volChildDepRm,
+			AccessGroups:          s.accessGroupList,
+			AccessGroupCreate:     s.accessGroupCreate,
+			AccessGroupDelete:     s.accessGroupDelete,
+			AccessGroupInitAdd:    s.accessGroupInitAdd,
+			AccessGroupInitDelete: s.accessGroupInitDelete,
+			AgsGrantedToVol:       s.agsGrantedToVol,
+			IscsiChapAuthSet:      s.iscsiChapAuthSet,
+			TargetPorts:           s.targetPorts,
+			VolIdentLedOn:         s.volIdentLed,
+			VolIdentLedOff:        s.volIdentLed,
+		},
+		File: lsm.FsOps{
+			FileSystems:       s.fsList,
+			FsCreate:          s.fsCreate,
+			FsDelete:          s.fsDelete,
+			FsResize:          s.fsResize,
+			FsClone:           s.fsClone,
+			FsFileClone:       s.fsFileClone,
+			FsSnapShotCreate:  s.fsSnapShotCreate,
+			FsSnapShotDelete:  s.fsSnapShotDelete,
+			FsSnapShots:       s.fsSnapShots,
+			FsSnapShotRestore: s.fsSnapShotRestore,
+			FsHasChildDep:     s.fsHasChildDep,
+			FsChildDepRm:      s.fsChildDepRm,
+		},
+		Nfs: lsm.NfsOps{
+			Exports:         s.exportList,
+			ExportAuthTypes: s.exportAuthTypes,
+			FsExport:        s.fsExport,
+			FsUnExport:      s.fsUnExport,
+		},
+		Hba: lsm.HbaRaidOps{
+			VolRaidInfo:         s.volRaidInfo,
+			PoolMemberInfo:      s.poolMemberInfo,
+			VolRaidCreateCapGet: s.volRaidCreateCapGet,
+			VolRaidCreate:       s.volRaidCreate,
+			Batteries:           s.batteryList,
+		},
+		Cache: lsm.CacheOps{
+			SysReadCachePctSet: s.sysReadCachePctSet,
+			VolCacheInfo:       s.volCacheInfo,
+			VolPhyDiskCacheSet: s.volPhyDiskCacheSet,
+			VolWriteCacheSet:   s.volWriteCacheSet,
+			VolReadCacheSet:    s.volReadCacheSet,
+		},
+	}
+	callbacks.Mgmt.Capabilities = lsm.DefaultCapabilities(callbacks, s.capabilities)
+	return callbacks
+}
+
+// Serve accepts connections on l and serves each one with its own plugin
+// instance until l is closed.
+func (s *Simulator) Serve(l net.Listener) error {
+	return lsm.PluginServe(l, s.Callbacks, Description, Version)
+}
+
+func (s *Simulator) newID(prefix string) string {
+	s.lastID++
+	return fmt.Sprintf("%s_%04d", prefix, s.lastID)
+}
+
+func invalidArg(format string, a ...interface{}) error {
+	return &errors.LsmError{
+		Code:    errors.InvalidArgument,
+		Message: fmt.Sprintf(format, a...)}
+}
+
+func notFound(code int32, what string, id string) error {
+	return &errors.LsmError{
+		Code:    code,
+		Message: fmt.Sprintf("%s with ID %s not found", what, id)}
+}
+
+func nameConflict(what string, name string) error {
+	return &errors.LsmError{
+		Code:    errors.NameConflict,
+		Message: fmt.Sprintf("%s with name %s already exists", what, name)}
+}
+
+func noStateChange(msg string) error {
+	return &errors.LsmError{
+		Code:    errors.NoStateChange,
+		Message: msg}
+}
+
+// searchMatch reports whether the object with the given key values matches the
+// search, which must be empty or a key/value pair using one of the keys.
+func searchMatch(search []string, values map[string]string) (bool, error) {
+	if len(search) == 0 {
+		return true, nil
+	}
+
+	if len(search) != 2 {
+		return false, invalidArg("search requires a key and value, got %v", search)
+	}
+
+	value, ok := values[search[0]]
+	if !ok {
+		return false, &errors.LsmError{
+			Code:    errors.UnsupportedSearchKey,
+			Message: fmt.Sprintf("unsupported search key %s", search[0])}
+	}
+	return value == search[1], nil
+}
+
+func roundUp(size uint64) uint64 {
+	return (size + blockSize - 1) / blockSize * blockSize
+}
+
+func (s *Simulator) checkSystem(system *lsm.System) error {
+	if system == nil {
+		return invalidArg("system is required")
+	}
+	if system.ID != s.system.ID {
+		return notFound(errors.NotFoundSystem, "system", system.ID)
+	}
+	return nil
+}
+
+func (s *Simulator) systems() ([]lsm.System, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return []lsm.System{s.system}, nil
+}
+
+// capabilities refines the capabilities derived from the callbacks with what
+// the system, its disks and volumes report.
+func (s *Simulator) capabilities(system *lsm.System, caps *lsm.CapabilitiesBuilder) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.checkSystem(system); err != nil {
+		return err
+	}
+
+	caps.Set(lsm.CapVolumeThin, lsm.CapSysReadCachePctGet, lsm.CapSysFwVersionGet,
+		lsm.CapSysModeGet, lsm.CapDiskLocation, lsm.CapDiskRpm, lsm.CapDiskLinkType,
+		lsm.CapDiskVpd83Get)
+	// Dependencies are removed for the whole file system only, so there is
+	// no CapFsChildDepRmSpecificFiles
+	caps.Set(lsm.CapVolumeCReplicateClone, lsm.CapVolumeCReplicateCopy,
+		lsm.CapVolumeCReplicateMirrorAsync, lsm.CapVolumeCReplicateMirrorSync,
+		lsm.CapVolumeCopyRangeClone, lsm.CapVolumeCopyRangeCopy,
+		lsm.CapFsSnapshotRestoreSpecificFiles, lsm.CapFsExportCustomPath)
+	return nil
+}
+
+func (s *Simulator) targetPorts() ([]lsm.TargetPort, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]lsm.TargetPort(nil), s.ports...), nil
+}
+
+func (s *Simulator) batteryList() ([]lsm.Battery, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]lsm.Battery(nil), s.batteries...), nil
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Size is a number of bytes which parses from and prints to text with
+// units, eg. "10GiB", "500G" or "1.5TB".
+type Size uint64
+
+// Binary and decimal units of Size.
+const (
+	Byte Size = 1
+	KiB  Size = 1 << 10
+	MiB  Size = 1 << 20
+	GiB  Size = 1 << 30
+	TiB  Size = 1 << 40
+	PiB  Size = 1 << 50
+	KB   Size = 1e3
+	MB   Size = 1e6
+	GB   Size = 1e9
+	TB   Size = 1e12
+	PB   Size = 1e15
+)
+
+// The single letter units are binary, as they are for lsmcli.
+var sizeUnits = map[string]Size{
+	"":    Byte,
+	"B":   Byte,
+	"K":   KiB,
+	"KIB": KiB,
+	"M":   MiB,
+	"MIB": MiB,
+	"G":   GiB,
+	"GIB": GiB,
+	"T":   TiB,
+	"TIB": TiB,
+	"P":   PiB,
+	"PIB": PiB,
+	"KB":  KB,
+	"MB":  MB,
+	"GB":  GB,
+	"TB":  TB,
+	"PB":  PB,
+}
+
+// sizePrint are the units String picks from, largest first.
+var sizePrint = []struct {
+	unit Size
+	name string
+}{
+	{PiB, "PiB"}, {TiB, "TiB"}, {GiB, "GiB"}, {MiB, "MiB"}, {KiB, "KiB"},
+}
+
+// ParseSize parses a number of bytes with an optional unit, the units are
+// case insensitive and the number may have a fraction, eg. "1.5TB", as long as
+// it comes to a whole number of bytes.
+func ParseSize(s string) (Size, error) {
+	s = strings.TrimSpace(s)
+	number, unit := s, ""
+	if i := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' }); i >= 0 {
+		number, unit = s[:i], strings.ToUpper(strings.TrimSpace(s[i:]))
+	}
+
+	multiplier, ok := sizeUnits[unit]
+	if !ok {
+		return 0, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("unknown unit %q in size %q", unit, s)}
+	}
+	if n, err := strconv.ParseUint(number, 10, 64); err == nil {
+		if n > ^uint64(0)/uint64(multiplier) {
+			return 0, &errors.LsmError{
+				Code:    errors.InvalidArgument,
+				Message: fmt.Sprintf("size %q is too large", s)}
+		}
+		return Size(n) * multiplier, nil
+	}
+	// Exactly, so a fraction of a byte is caught rather than rounded away
+	bytes, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("invalid size %q", s)}
+	}
+	bytes.Mul(bytes, new(big.Rat).SetInt64(int64(multiplier)))
+	if !bytes.IsInt() {
+		return 0, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("size %q is not a whole number of bytes", s)}
+	}
+	if !bytes.Num().IsUint64() {
+		return 0, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("size %q is too large", s)}
+	}
+	return Size(bytes.Num().Uint64()), nil
+}
+
+// String prints the size in the largest binary unit it is a whole number of,
+// so it parses back to the same size, eg. "1536MiB".
+func (s Size) String() string {
+	for _, u := range sizePrint {
+		if s >= u.unit && s%u.unit == 0 {
+			return fmt.Sprintf("%d%s", s/u.unit, u.name)
+		}
+	}
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// Set parses the size, a *Size is a flag.Value.
+func (s *Size) Set(value string) error {
+	size, err := ParseSize(value)
+	if err != nil {
+		return err
+	}
+	*s = size
+	return nil
+}
+
+// MarshalText prints the size as String does.
+func (s Size) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the size as ParseSize does.
+func (s *Size) UnmarshalText(text []byte) error {
+	return s.Set(string(text))
+}
+
+// MarshalJSON encodes the size as a number of bytes.
+func (s Size) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uint64(s))
+}
+
+// UnmarshalJSON decodes a number of bytes or a string as ParseSize takes.
+func (s *Size) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return s.Set(text)
+	}
+	var n *uint64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("invalid size %s", data)}
+	}
+	if n != nil {
+		*s = Size(*n)
+	}
+	return nil
+}
+
+// RoundUp returns the size rounded up to a whole number of blocks, an
+// InvalidArgument error if that is too large for a Size.
+func (s Size) RoundUp(blockSize uint64) (Size, error) {
+	if blockSize == 0 {
+		return s, nil
+	}
+	short := s % Size(blockSize)
+	if short == 0 {
+		return s, nil
+	}
+	if s > ^Size(0)-(Size(blockSize)-short) {
+		return 0, &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("size %s rounded up to blocks of %d bytes is too large", s, blockSize)}
+	}
+	return s + Size(blockSize) - short, nil
+}
+
+// SizeBytes returns the size of the volume.
+func (v *Volume) SizeBytes() Size {
+	return Size(v.BlockSize * v.NumOfBlocks)
+}
+
+// UsedBytes returns the space of the pool in use.
+func (p *Pool) UsedBytes() Size {
+	if p.FreeSpace > p.TotalSpace {
+		return 0
+	}
+	return Size(p.TotalSpace - p.FreeSpace)
+}
+
+// defaultBlockSize is the block size volumes are assumed to have in pools
+// without any.
+const defaultBlockSize = 512
+
+// blockSize returns the block size of the volumes in the pool.
+func (c *ClientConnection) blockSize(ctx context.Context, pool *Pool) (uint64, error) {
+	volumes, err := c.VolumesBy(ctx, ByPoolID(pool.ID))
+	if code, _ := errors.CodeOf(err); code == errors.NoSupport {
+		return defaultBlockSize, nil
+	} else if err != nil {
+		return 0, err
+	}
+	for _, v := range volumes {
+		if v.BlockSize > 0 {
+			return v.BlockSize, nil
+		}
+	}
+	return defaultBlockSize, nil
+}
+
+// VolumeCreateSize creates a volume as VolumeCreateJob does, with the size
+// rounded up to whole blocks of the volumes already in the pool, or of 512
+// bytes when there are none.  Plugins may round it up further.
+func (c *ClientConnection) VolumeCreateSize(ctx context.Context, pool *Pool, volumeName string, size Size,
+	provisioning VolumeProvisionType) (*Volume, *Job, error) {
+	blockSize, err := c.blockSize(ctx, pool)
+	if err != nil {
+		return nil, nil, err
+	}
+	rounded, err := size.RoundUp(blockSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.VolumeCreateJob(ctx, pool, volumeName, uint64(rounded), provisioning)
+}
+
+// VolumeResizeSize resizes a volume as VolumeResizeJob does, with the size
+// rounded up to whole blocks of the volume.
+func (c *ClientConnection) VolumeResizeSize(ctx context.Context, vol *Volume, newSize Size) (*Volume, *Job, error) {
+	rounded, err := newSize.RoundUp(vol.BlockSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.VolumeResizeJob(ctx, vol, uint64(rounded))
+}
+
+// FsCreateSize creates a file system as FsCreateJob does.
+func (c *ClientConnection) FsCreateSize(ctx context.Context, pool *Pool, name string, size Size) (*FileSystem, *Job, error) {
+	return c.FsCreateJob(ctx, pool, name, uint64(size))
+}
+
+// FsResizeSize resizes a file system as FsResizeJob does.
+func (c *ClientConnection) FsResizeSize(ctx context.Context, fs *FileSystem, newSize Size) (*FileSystem, *Job, error) {
+	return c.FsResizeJob(ctx, fs, uint64(newSize))
+}
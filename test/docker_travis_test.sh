@@ -29,20 +29,26 @@ cd /tmp/go/src/github.com/libstorage/libstoragemgmt-golang || exit 1
 # Speed up tests
 export LSM_SIM_TIME=0
 
-# Start the service
-/usr/bin/lsmd || exit 1
-
-# Let the service get ready
-sleep 5 || exit 1
-
-# Make sure things are sane
-lsmcli list --type pools -u simc:// || exit 1
-lsmcli list --type plugins -u simc:// || exit 1
+# lsmd is deliberately not started, without it the tests serve the Go
+# simulator in process, so they run against the same fixtures as they do
+# locally.  The C library is still needed for localdisk.
 
 export GOPATH=/tmp/go
+export GO111MODULE=off
 
 # Get the required lib for unit test
 go get github.com/stretchr/testify/assert || exit 1
 
+# lsmgrpc needs grpc and protobuf, pinned to the versions its generated code
+# is for, then what those versions import
+go get -d google.golang.org/grpc google.golang.org/protobuf/proto || exit 1
+git -C $GOPATH/src/google.golang.org/grpc checkout -q v1.75.0 || exit 1
+git -C $GOPATH/src/google.golang.org/protobuf checkout -q v1.36.9 || exit 1
+go get -d google.golang.org/grpc/... google.golang.org/protobuf/... || exit 1
+
+# reconcile reads its state with yaml.v3
+go get -d gopkg.in/yaml.v3 || exit 1
+git -C $GOPATH/src/gopkg.in/yaml.v3 checkout -q v3.0.1 || exit 1
+
 cd test || exit 1
 ./cov.sh || exit 1
@@ -3,20 +3,53 @@
 package libstoragemgmt
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/user"
+	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	lsm "github.com/libstorage/libstoragemgmt-golang"
+	"github.com/libstorage/libstoragemgmt-golang/cli"
+	"github.com/libstorage/libstoragemgmt-golang/daemon"
 	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+	"github.com/libstorage/libstoragemgmt-golang/fake"
+	"github.com/libstorage/libstoragemgmt-golang/inventory"
 	disks "github.com/libstorage/libstoragemgmt-golang/localdisk"
+	"github.com/libstorage/libstoragemgmt-golang/lsmgrpc"
+	"github.com/libstorage/libstoragemgmt-golang/proxy"
+	"github.com/libstorage/libstoragemgmt-golang/reconcile"
+	"github.com/libstorage/libstoragemgmt-golang/rest"
+	"github.com/libstorage/libstoragemgmt-golang/simulator"
+	"github.com/libstorage/libstoragemgmt-golang/workflow"
 )
 
 var URI = getEnv("LSM_GO_URI", "sim://")
@@ -26,12 +59,10 @@ var URI = getEnv("LSM_GO_URI", "sim://")
 const PASSWORD = ""
 const TMO uint32 = 30000
 
-// Running these tests requires lsmd up and running with the simulator
-// plugin available.  In addition a number of tests require things to
-// exists which don't exist by default and need to be created.  At the
-// moment this needs to be done via lsmcli.  As functionality evolves
-// these requirements will be reduced as the unit tests will create
-// things as needed.
+// These tests run against lsmd with the simulator plugin available.  If
+// lsmd isn't reachable the Go simulator is served in process on the
+// sockets lsmd would use for the "sim" and "simc" plugins instead.  Things
+// the tests need which don't exist by default are created by setup().
 
 func rs(pre string, n int) string {
 	var l = []byte("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
@@ -55,6 +86,85 @@ func TestConnect(t *testing.T) {
 	assert.Equal(t, nil, c.Close())
 }
 
+func TestClientExec(t *testing.T) {
+	// The test binary doubles as the plugin, see TestMain
+	os.Setenv(pluginVarName, "1")
+	var c, libError = lsm.ClientExec(os.Args[0], "simgo://", PASSWORD, TMO)
+	os.Unsetenv(pluginVarName)
+	assert.Nil(t, libError)
+
+	var systems, sysError = c.Systems()
+	assert.Nil(t, sysError)
+	assert.Equal(t, 1, len(systems))
+	assert.Equal(t, simulator.SystemID, systems[0].ID)
+
+	var info, infoError = c.PluginInfo()
+	assert.Nil(t, infoError)
+	assert.Equal(t, simulator.Description, info.Description)
+	assert.Equal(t, "simgo", info.Name)
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestClientExecMissing(t *testing.T) {
+	var _, libError = lsm.ClientExec(rs("/tmp/", 8), "simgo://", PASSWORD, TMO)
+	assert.NotNil(t, libError)
+	assert.Equal(t, errors.PluginNotExist, libError.(*errors.LsmError).Code)
+}
+
+func TestDaemon(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "lsm_go_daemon")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// The test binary doubles as the plugin, see TestMain
+	var pluginDir = filepath.Join(dir, "plugins")
+	var socketDir = filepath.Join(dir, "ipc")
+	assert.Nil(t, os.Mkdir(pluginDir, 0755))
+	assert.Nil(t, os.Symlink(os.Args[0], filepath.Join(pluginDir, "simgo_lsmplugin")))
+
+	var me, meErr = user.Current()
+	assert.Nil(t, meErr)
+
+	var d, dErr = daemon.New(daemon.Config{
+		PluginDir: pluginDir, SocketDir: socketDir, ConfDir: dir, User: me.Username})
+	assert.Nil(t, dErr)
+	assert.Equal(t, []string{"simgo"}, d.Plugins())
+
+	var done = make(chan error)
+	go func() { done <- d.Run() }()
+
+	const KEY = "LSM_UDS_PATH"
+	var current = os.Getenv(KEY)
+	os.Setenv(KEY, socketDir)
+	os.Setenv(pluginVarName, "1")
+	defer os.Setenv(KEY, current)
+	defer os.Unsetenv(pluginVarName)
+
+	var c *lsm.ClientConnection
+	for i := 0; i < 100; i++ {
+		if c, err = lsm.Client("simgo://", PASSWORD, TMO); err == nil {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Nil(t, err)
+
+	if c != nil {
+		var systems, sysError = c.Systems()
+		assert.Nil(t, sysError)
+		assert.Equal(t, 1, len(systems))
+		assert.Equal(t, nil, c.Close())
+	}
+
+	var plugins, pErr = lsm.AvailablePlugins()
+	assert.Nil(t, pErr)
+	assert.Equal(t, 1, len(plugins))
+
+	assert.Nil(t, d.Close())
+	assert.Nil(t, <-done)
+}
+
 func TestConnectInvalidUri(t *testing.T) {
 	var _, libError = lsm.Client("://", PASSWORD, TMO)
 	assert.NotNil(t, libError)
@@ -129,6 +239,60 @@ func TestJobs(t *testing.T) {
 	assert.Equal(t, nil, c.Close())
 }
 
+func TestJobManager(t *testing.T) {
+	var m = lsm.NewJobManager()
+	var release = make(chan struct{})
+	var reported = make(chan struct{})
+
+	var id = m.Start(func(progress lsm.JobProgress) (interface{}, error) {
+		progress(42)
+		close(reported)
+		<-release
+		return "done", nil
+	})
+	assert.NotNil(t, id)
+
+	<-reported
+	var info, err = m.Status(*id)
+	assert.Nil(t, err)
+	assert.Equal(t, lsm.JobStatusInprogress, info.Status)
+	assert.Equal(t, uint8(42), info.Percent)
+
+	close(release)
+	for info.Status == lsm.JobStatusInprogress {
+		time.Sleep(time.Millisecond)
+		info, err = m.Status(*id)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, lsm.JobStatusComplete, info.Status)
+	assert.Equal(t, uint8(100), info.Percent)
+	assert.Equal(t, "done", info.Item)
+
+	assert.Nil(t, m.Free(*id))
+	var _, notFound = m.Status(*id)
+	assert.Equal(t, errors.NotFoundJob, notFound.(*errors.LsmError).Code)
+	assert.NotNil(t, m.Free(*id))
+
+	// Errors which aren't LsmErrors are reported as plugin bugs
+	var failed = m.Start(func(progress lsm.JobProgress) (interface{}, error) {
+		return nil, fmt.Errorf("boom")
+	})
+	var failure error
+	for failure == nil {
+		time.Sleep(time.Millisecond)
+		_, failure = m.Status(*failed)
+	}
+	assert.Equal(t, errors.PluginBug, failure.(*errors.LsmError).Code)
+	assert.Equal(t, "boom", failure.(*errors.LsmError).Message)
+
+	// A failed job is kept until freed, as clients free it
+	_, failure = m.Status(*failed)
+	assert.Equal(t, "boom", failure.(*errors.LsmError).Message)
+	assert.Nil(t, m.Free(*failed))
+	_, notFound = m.Status(*failed)
+	assert.Equal(t, errors.NotFoundJob, notFound.(*errors.LsmError).Code)
+}
+
 func TestAvailablePluginsBadUds(t *testing.T) {
 	const KEY = "LSM_UDS_PATH"
 	var current = os.Getenv(KEY)
@@ -183,6 +347,609 @@ func TestGoodSeach(t *testing.T) {
 	assert.Equal(t, nil, c.Close())
 }
 
+func TestTypedSearch(t *testing.T) {
+	var c, _ = lsm.Client(URI, PASSWORD, TMO)
+
+	var pools, poolErr = c.PoolsBy(context.Background(), lsm.BySystemID("sim-01"))
+	assert.Nil(t, poolErr)
+	assert.Equal(t, 4, len(pools))
+
+	var volume = createVolume(t, c, rs("lsm_go_vol_", 8))
+	var volumes, volErr = c.VolumesBy(context.Background(), lsm.ByPoolID(volume.PoolID))
+	assert.Nil(t, volErr)
+	for _, v := range volumes {
+		assert.Equal(t, volume.PoolID, v.PoolID)
+	}
+	volumes, volErr = c.VolumesBy(context.Background(), lsm.ByID(volume.ID))
+	assert.Nil(t, volErr)
+	assert.Equal(t, 1, len(volumes))
+
+	// Keys are checked before calling the plugin
+	var _, keyErr = c.NfsExportsBy(context.Background(), lsm.ByPoolID(volume.PoolID))
+	assert.Equal(t, errors.UnsupportedSearchKey, keyErr.(*errors.LsmError).Code)
+	var badKey, badKeyErr = c.PoolsBy(context.Background(), lsm.ByFsID("fs"))
+	assert.Equal(t, errors.UnsupportedSearchKey, badKeyErr.(*errors.LsmError).Code)
+	assert.NotNil(t, badKey)
+	assert.Equal(t, 0, len(badKey))
+
+	c.VolumeDelete(volume, true)
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestSearchFallback(t *testing.T) {
+	var sim = simulator.New()
+	var callbacks = sim.Callbacks()
+	var poolList = callbacks.Mgmt.Pools
+	var volumeList = callbacks.San.Volumes
+	callbacks.Mgmt.Pools = func(search ...string) ([]lsm.Pool, error) {
+		if len(search) > 0 {
+			return nil, &errors.LsmError{Code: errors.NoSupport, Message: "no search"}
+		}
+		return poolList()
+	}
+	callbacks.San.Volumes = func(search ...string) ([]lsm.Volume, error) {
+		return volumeList()
+	}
+
+	var c, err = lsm.ClientPipe(callbacks, simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var all, allErr = c.Pools()
+	assert.Nil(t, allErr)
+	assert.Equal(t, 4, len(all))
+
+	var pools, poolErr = c.PoolsBy(context.Background(), lsm.ByID(all[1].ID))
+	assert.Nil(t, poolErr)
+	assert.Equal(t, 1, len(pools))
+	assert.Equal(t, all[1].ID, pools[0].ID)
+
+	var volume = createVolume(t, c, rs("lsm_go_vol_", 8))
+	createVolume(t, c, rs("lsm_go_vol_", 8))
+	var volumes, volErr = c.VolumesBy(context.Background(), lsm.ByID(volume.ID))
+	assert.Nil(t, volErr)
+	assert.Equal(t, 1, len(volumes))
+	assert.Equal(t, volume.ID, volumes[0].ID)
+
+	volumes, volErr = c.VolumesBy(context.Background(), lsm.ByID("missing"))
+	assert.Nil(t, volErr)
+	assert.Equal(t, 0, len(volumes))
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestInventory(t *testing.T) {
+	var sim = simulator.New()
+	var c, err = lsm.ClientPipe(sim.Callbacks(), simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var systems, _ = c.Systems()
+	var volume = createVolume(t, c, rs("lsm_go_vol_", 8))
+	var ag, agErr = c.AccessGroupCreate(rs("lsm_ag_", 4),
+		"iqn.1994-05.com.domain:01.89bd01", lsm.InitiatorTypeIscsiIqn, &systems[0])
+	assert.Nil(t, agErr)
+	assert.Nil(t, c.VolumeMask(volume, ag))
+
+	var pools, _ = c.Pools()
+	var newFs, _, fsErr = c.FsCreate(&pools[2], rs("lsm_go_fs_", 4), 1024*1024*100, true)
+	assert.Nil(t, fsErr)
+	var fileSystems = []lsm.FileSystem{*newFs}
+	var exportPath = "/mnt/inventory"
+	var access = lsm.NfsAccess{Rw: []string{"192.168.1.1"},
+		AnonUID: lsm.AnonUIDGIDNotApplicable, AnonGID: lsm.AnonUIDGIDNotApplicable}
+	var export, exportErr = c.FsExport(&fileSystems[0], &exportPath, &access, nil, nil)
+	assert.Nil(t, exportErr)
+
+	var inv, invErr = inventory.Collect(context.Background(), c)
+	assert.Nil(t, invErr)
+	assert.Equal(t, 1, len(inv.Systems))
+	assert.Equal(t, 4, len(inv.PoolsOf(systems[0].ID)))
+	assert.Equal(t, volume.Name, inv.Volumes[volume.ID].Name)
+	assert.Contains(t, inv.VolumesOf(volume.PoolID), inv.Volumes[volume.ID])
+	assert.Contains(t, inv.FileSystemsOf(fileSystems[0].PoolID), inv.FileSystems[fileSystems[0].ID])
+	assert.Equal(t, []*lsm.NfsExport{inv.NfsExports[export.ID]}, inv.ExportsOf(fileSystems[0].ID))
+	assert.Equal(t, []string{ag.ID}, inv.Masking[volume.ID])
+	assert.Equal(t, []*lsm.AccessGroup{inv.AccessGroups[ag.ID]}, inv.AccessGroupsOf(volume.ID))
+	assert.Equal(t, []*lsm.Volume{inv.Volumes[volume.ID]}, inv.MaskedTo(ag.ID))
+	assert.Equal(t, []*lsm.AccessGroup{inv.AccessGroups[ag.ID]},
+		inv.InitiatorGroups("iqn.1994-05.com.domain:01.89bd01"))
+	assert.True(t, len(inv.Disks) > 0)
+	assert.True(t, len(inv.PoolMembers) > 0)
+
+	// JSON round trips
+	var invJSON, jsonErr = json.Marshal(inv)
+	assert.Nil(t, jsonErr)
+	var decoded inventory.Inventory
+	assert.Nil(t, json.Unmarshal(invJSON, &decoded))
+	assert.Equal(t, inv.Volumes[volume.ID].ID, decoded.Volumes[volume.ID].ID)
+	assert.Equal(t, inv.Masking, decoded.Masking)
+
+	var dot bytes.Buffer
+	assert.Nil(t, inv.WriteDOT(&dot))
+	assert.True(t, strings.HasPrefix(dot.String(), "digraph inventory {"))
+	assert.True(t, strings.Contains(dot.String(),
+		fmt.Sprintf("\"volume:%s\" -> \"access_group:%s\" [label=\"masked\"];", volume.ID, ag.ID)))
+	assert.True(t, strings.Contains(dot.String(),
+		fmt.Sprintf("\"fs:%s\" -> \"export:%s\";", fileSystems[0].ID, export.ID)))
+
+	// Listings the plugin doesn't support are left empty
+	var f = fake.New()
+	f.SystemsFunc = func(ctx context.Context) ([]lsm.System, error) { return systems, nil }
+	f.BatteriesFunc = func(ctx context.Context) ([]lsm.Battery, error) {
+		return nil, &errors.LsmError{Code: errors.NoSupport, Message: "no batteries"}
+	}
+	inv, invErr = inventory.Collect(context.Background(), f)
+	assert.Nil(t, invErr)
+	assert.Equal(t, 1, len(inv.Systems))
+	assert.Equal(t, 0, len(inv.Batteries))
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestInventoryWatcher(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	// Cancelling may cut a call off, which ends the connection of the watcher
+	var wc, wcErr = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, wcErr)
+	defer wc.Close()
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	var w = inventory.NewWatcher(wc, 10*time.Millisecond)
+	var baseline, pollErr = w.Poll(ctx)
+	assert.Nil(t, pollErr)
+	assert.Equal(t, 0, len(baseline))
+	var done = make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	var volume = createVolume(t, c, rs("lsm_go_vol_", 8))
+	for e := range w.Events() {
+		if e.Kind == inventory.KindVolume && e.ID == volume.ID {
+			assert.Equal(t, inventory.Added, e.Change)
+			assert.Equal(t, volume.Name, e.New.(*lsm.Volume).Name)
+			break
+		}
+	}
+
+	var job, _ = c.VolumeDelete(volume, true)
+	assert.Nil(t, job)
+	for e := range w.Events() {
+		if e.Kind == inventory.KindVolume && e.ID == volume.ID {
+			assert.Equal(t, inventory.Removed, e.Change)
+			assert.Nil(t, e.New)
+			break
+		}
+	}
+
+	cancel()
+	for range w.Events() {
+	}
+	assert.Equal(t, context.Canceled, <-done)
+
+	// Status bit transitions
+	var before = w.Last()
+	var after inventory.Inventory
+	var snapshot, _ = json.Marshal(before)
+	assert.Nil(t, json.Unmarshal(snapshot, &after))
+	var pool = *after.Pools[volume.PoolID]
+	pool.Status = lsm.PoolStatusOk | lsm.PoolStatusDegraded
+	after.Pools[pool.ID] = &pool
+	var disks, _ = c.Disks()
+	var disk = *after.Disks[disks[0].ID]
+	disk.Status = lsm.DiskStatusError
+	after.Disks[disk.ID] = &disk
+
+	var events = inventory.Diff(before, &after)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, inventory.KindPool, events[0].Kind)
+	assert.Equal(t, inventory.StatusChanged, events[0].Change)
+	assert.Equal(t, uint64(lsm.PoolStatusDegraded), events[0].Raised)
+	assert.Equal(t, uint64(0), events[0].Cleared)
+	assert.Equal(t, fmt.Sprintf("pool %s status changed, raised Degraded", pool.ID), events[0].String())
+	assert.Equal(t, inventory.KindDisk, events[1].Kind)
+	assert.Equal(t, uint64(lsm.DiskStatusError), events[1].Raised)
+	assert.Equal(t, uint64(lsm.DiskStatusOk), events[1].Cleared)
+	assert.Equal(t, 0, len(inventory.Diff(before, before)))
+
+	// Retryable failures skip a tick, others end the watcher
+	var polls = 0
+	var fc = &fake.Client{SystemsFunc: func(ctx context.Context) ([]lsm.System, error) {
+		polls++
+		switch polls {
+		case 1:
+			return nil, &errors.LsmError{Code: errors.TimeOut}
+		case 2:
+			return nil, nil
+		case 3:
+			return []lsm.System{{ID: "sys"}}, nil
+		}
+		return nil, &errors.LsmError{Code: errors.PluginBug}
+	}}
+	var fw = inventory.NewWatcher(fc, time.Millisecond)
+	var fdone = make(chan error)
+	go func() { fdone <- fw.Run(context.Background()) }()
+	var added = <-fw.Events()
+	assert.Equal(t, inventory.KindSystem, added.Kind)
+	assert.Equal(t, inventory.Added, added.Change)
+	assert.Equal(t, "sys", added.ID)
+	for range fw.Events() {
+	}
+	assert.Equal(t, errors.PluginBug, (<-fdone).(*errors.LsmError).Code)
+
+	// A connection which failed stays failed
+	var closed, closedErr = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, closedErr)
+	assert.Nil(t, closed.Close())
+	var cw = inventory.NewWatcher(closed, time.Millisecond)
+	var cdone = make(chan error)
+	go func() { cdone <- cw.Run(context.Background()) }()
+	select {
+	case runErr := <-cdone:
+		var code, _ = errors.CodeOf(runErr)
+		assert.Equal(t, errors.TransPortComunication, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run kept polling a closed client")
+	}
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestReconcile(t *testing.T) {
+	var sim = simulator.New()
+	var c, err = lsm.ClientPipe(sim.Callbacks(), simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var pools, _ = c.Pools()
+	var fs, _, fsErr = c.FsCreate(&pools[2], rs("lsm_go_fs_", 4), 1024*1024*100, true)
+	assert.Nil(t, fsErr)
+	var old = createVolume(t, c, "rc_old")
+	var other = createVolume(t, c, "other_vol")
+
+	var stateYAML = fmt.Sprintf(`
+volumes:
+  - name: rc_data
+    pool: %s
+    size: 1MiB
+    provisioning: thin
+    access_groups: [rc_hosts]
+  - name: rc_logs
+    pool: %s
+    size: 2097152
+access_groups:
+  - name: rc_hosts
+    init_type: IscsiIqn
+    initiators:
+      - iqn.1994-05.com.domain:01.89bd01
+      - iqn.1994-05.com.domain:01.89bd02
+nfs_exports:
+  - fs: %s
+    path: /exports/rc_share
+    rw: [192.168.1.1]
+`, pools[3].ID, pools[3].Name, fs.Name)
+
+	var state, parseErr = reconcile.Parse([]byte(stateYAML))
+	assert.Nil(t, parseErr)
+	assert.Equal(t, lsm.VolumeProvisionTypeThin, *state.Volumes[0].Provisioning)
+	assert.Equal(t, lsm.InitiatorTypeIscsiIqn, state.AccessGroups[0].InitType)
+
+	var ctx = context.Background()
+	var opts = reconcile.Options{Prune: "rc_"}
+	var plan, planErr = reconcile.Compute(ctx, c, state, opts)
+	assert.Nil(t, planErr)
+	assert.Equal(t, strings.Join([]string{
+		"- delete volume rc_old",
+		"+ create access_group rc_hosts (iqn.1994-05.com.domain:01.89bd01)",
+		"+ add initiator access_group rc_hosts (iqn.1994-05.com.domain:01.89bd02)",
+		fmt.Sprintf("+ create volume rc_data (1MiB in pool %s)", pools[3].ID),
+		fmt.Sprintf("+ create volume rc_logs (2MiB in pool %s)", pools[3].ID),
+		"+ mask volume rc_data (to rc_hosts)",
+		fmt.Sprintf("+ export export /exports/rc_share (of %s)", fs.Name),
+	}, "\n")+"\n", plan.String())
+
+	// Nothing is changed before applying
+	var volumes, _ = c.Volumes()
+	assert.Equal(t, 2, len(volumes))
+
+	assert.Nil(t, plan.Apply(ctx, c))
+	plan, planErr = reconcile.Compute(ctx, c, state, opts)
+	assert.Nil(t, planErr)
+	assert.Equal(t, "No changes\n", plan.String())
+
+	var byName = func() map[string]lsm.Volume {
+		var volumes, _ = c.Volumes()
+		var m = map[string]lsm.Volume{}
+		for _, v := range volumes {
+			m[v.Name] = v
+		}
+		return m
+	}
+	var found = byName()
+	assert.Equal(t, 3, len(found))
+	assert.Equal(t, other.ID, found["other_vol"].ID)
+	_, hasOld := found[old.Name]
+	assert.False(t, hasOld)
+	var groups, _ = c.AgsGrantedToVol(&lsm.Volume{ID: found["rc_data"].ID})
+	assert.Equal(t, 1, len(groups))
+	assert.Equal(t, 2, len(groups[0].InitIDs))
+
+	// Changes to the state, in JSON
+	state.Volumes[0].AccessGroups = nil
+	state.Volumes[1].Size *= 2
+	state.AccessGroups[0].Initiators = state.AccessGroups[0].Initiators[:1]
+	state.NfsExports[0].Ro = []string{"192.168.1.2"}
+	var stateJSON, _ = json.Marshal(state)
+	state, parseErr = reconcile.Parse(stateJSON)
+	assert.Nil(t, parseErr)
+
+	plan, planErr = reconcile.Compute(ctx, c, state, opts)
+	assert.Nil(t, planErr)
+	assert.Equal(t, strings.Join([]string{
+		"- unexport export /exports/rc_share",
+		"- unmask volume rc_data (from rc_hosts)",
+		"- remove initiator access_group rc_hosts (iqn.1994-05.com.domain:01.89bd02)",
+		"~ resize volume rc_logs (2MiB to 4MiB)",
+		fmt.Sprintf("+ export export /exports/rc_share (of %s)", fs.Name),
+	}, "\n")+"\n", plan.String())
+	assert.Nil(t, plan.Apply(ctx, c))
+	plan, _ = reconcile.Compute(ctx, c, state, opts)
+	assert.Equal(t, 0, len(plan.Actions), plan.String())
+
+	// Pruning everything
+	plan, planErr = reconcile.Compute(ctx, c, &reconcile.State{}, opts)
+	assert.Nil(t, planErr)
+	assert.Nil(t, plan.Apply(ctx, c))
+	found = byName()
+	assert.Equal(t, 1, len(found))
+	var exports, _ = c.NfsExportsBy(context.Background(), lsm.ByFsID(fs.ID))
+	assert.Equal(t, 0, len(exports))
+
+	// Invalid states
+	for _, invalid := range []string{
+		"volumes: [{name: v, pool: p, size: 1, access_groups: [missing]}]",
+		"access_groups: [{name: ag, init_type: Bogus, initiators: [iqn.1994-05.com.domain:01.89bd01]}]",
+		"access_groups: [{name: ag, init_type: Wwpn, initiators: []}]",
+		"volumes: [{name: v, pool: p, size: 1}, {name: v, pool: p, size: 1}]",
+		"volumes: {",
+	} {
+		var _, invalidErr = reconcile.Parse([]byte(invalid))
+		var code, _ = errors.CodeOf(invalidErr)
+		assert.Equal(t, errors.InvalidArgument, code, invalid)
+	}
+
+	var _, notFound = reconcile.Compute(ctx, c, &reconcile.State{
+		Volumes: []reconcile.VolumeState{{Name: "rc_new", Pool: "missing", Size: 1024}}}, opts)
+	var code, _ = errors.CodeOf(notFound)
+	assert.Equal(t, errors.NotFoundPool, code)
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestWorkflow(t *testing.T) {
+	var sim = simulator.New()
+	var c, err = lsm.ClientPipe(sim.Callbacks(), simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var ctx = context.Background()
+	var systems, _ = c.Systems()
+	var pools, _ = c.Pools()
+	var iqn = "iqn.1994-05.com.domain:01.89bd01"
+
+	// Everything stays once committed
+	var w = workflow.New(c)
+	var vol, volErr = w.VolumeCreate(ctx, &pools[3], rs("lsm_go_vol_", 8), 1024*1024, lsm.VolumeProvisionTypeDefault)
+	assert.Nil(t, volErr)
+	var ag, agErr = w.AccessGroupCreate(ctx, rs("lsm_ag_", 4), iqn, lsm.InitiatorTypeIscsiIqn, &systems[0])
+	assert.Nil(t, agErr)
+	assert.Nil(t, w.VolumeMask(ctx, vol, ag))
+	w.Commit()
+	assert.Nil(t, w.Rollback(ctx))
+
+	var masked, _ = c.VolsMaskedToAg(ag)
+	assert.Equal(t, 1, len(masked))
+
+	// A failing step undoes the others in reverse order
+	var undone []string
+	w = workflow.New(c)
+	var newVol, _ = w.VolumeCreate(ctx, &pools[3], rs("lsm_go_vol_", 8), 1024*1024, lsm.VolumeProvisionTypeDefault)
+	var newAg, _ = w.AccessGroupInitAdd(ctx, ag, "iqn.1994-05.com.domain:01.89bd02", lsm.InitiatorTypeIscsiIqn)
+	assert.Equal(t, 2, len(newAg.InitIDs))
+	assert.Nil(t, w.Step(ctx, "record", func(ctx context.Context, c lsm.StorageClient) error {
+		return nil
+	}, func(ctx context.Context, c lsm.StorageClient) error {
+		undone = append(undone, "record")
+		return nil
+	}))
+	var maskErr = w.VolumeMask(ctx, newVol, &lsm.AccessGroup{ID: "missing", Name: "missing"})
+	var code, _ = errors.CodeOf(maskErr)
+	assert.Equal(t, errors.NotFoundAccessGroup, code)
+	assert.True(t, strings.HasPrefix(maskErr.Error(), fmt.Sprintf("step volume mask %s to missing failed: ", newVol.Name)))
+	assert.True(t, strings.HasSuffix(maskErr.Error(), ", rolled back"))
+	assert.True(t, stderrors.Is(maskErr, errors.ErrNotFoundAccessGroup))
+	var stepErr *workflow.StepError
+	assert.True(t, stderrors.As(maskErr, &stepErr))
+	assert.Nil(t, stepErr.Rollback)
+	assert.Equal(t, []string{"record"}, undone)
+
+	var volumes, _ = c.VolumesBy(context.Background(), lsm.ByID(newVol.ID))
+	assert.Equal(t, 0, len(volumes))
+	var groups, _ = c.AccessGroups()
+	assert.Equal(t, []string{iqn}, groups[0].InitIDs)
+
+	// No more steps once rolled back
+	var _, afterErr = w.VolumeCreate(ctx, &pools[3], rs("lsm_go_vol_", 8), 1024*1024, lsm.VolumeProvisionTypeDefault)
+	code, _ = errors.CodeOf(afterErr)
+	assert.Equal(t, errors.InvalidArgument, code)
+
+	// Failing compensating actions are reported, the others still run
+	undone = nil
+	w = workflow.New(c)
+	for _, name := range []string{"first", "second"} {
+		var name = name
+		assert.Nil(t, w.Step(ctx, name, func(ctx context.Context, c lsm.StorageClient) error {
+			return nil
+		}, func(ctx context.Context, c lsm.StorageClient) error {
+			undone = append(undone, name)
+			return &errors.LsmError{Code: errors.TimeOut, Message: name + " timed out"}
+		}))
+	}
+	var rollbackErr = w.Rollback(ctx)
+	code, _ = errors.CodeOf(rollbackErr)
+	assert.Equal(t, errors.TimeOut, code)
+	assert.Equal(t, []string{"second", "first"}, undone)
+	assert.True(t, strings.HasSuffix(errors.MessageOf(rollbackErr), "undoing first failed: first timed out"))
+
+	// A create whose job outlives the context is still undone, the rollback
+	// has a context of its own
+	var short, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	var id = "job"
+	var fc = &fake.Client{
+		VolumeCreateFunc: func(ctx context.Context, pool *lsm.Pool, volumeName string, size uint64,
+			provisioning lsm.VolumeProvisionType, sync bool) (*lsm.Volume, *string, error) {
+			return nil, &id, nil
+		},
+		JobStatusFunc: func(ctx context.Context, jobID string, result interface{}) (lsm.JobStatusType, uint8, error) {
+			if ctx == short {
+				return lsm.JobStatusInprogress, 50, nil
+			}
+			*result.(*lsm.Volume) = lsm.Volume{ID: "slow"}
+			return lsm.JobStatusComplete, 100, nil
+		},
+	}
+	w = workflow.New(fc)
+	var _, slowErr = w.VolumeCreate(short, &pools[3], "slow", 1024*1024, lsm.VolumeProvisionTypeDefault)
+	assert.True(t, stderrors.Is(slowErr, context.DeadlineExceeded))
+	assert.True(t, strings.HasSuffix(slowErr.Error(), ", rolled back"))
+	var deletes = fc.CallsTo("VolumeDelete")
+	assert.Equal(t, 1, len(deletes))
+	assert.Equal(t, "slow", deletes[0].Args[0].(*lsm.Volume).ID)
+
+	assert.Nil(t, c.VolumeUnMask(vol, ag))
+	assert.Nil(t, c.AccessGroupDelete(ag))
+	var job, _ = c.VolumeDelete(vol, true)
+	assert.Nil(t, job)
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestSize(t *testing.T) {
+	var parsed = []struct {
+		text string
+		size lsm.Size
+	}{
+		{"0", 0},
+		{"512", 512},
+		{"10GiB", 10 * lsm.GiB},
+		{"500G", 500 * lsm.GiB},
+		{"1.5TB", 1500 * lsm.GB},
+		{"1.5 tib", 1536 * lsm.GiB},
+		{" 64k ", 64 * lsm.KiB},
+		{"2PB", 2 * lsm.PB},
+		{"18446744073709551615", lsm.Size(^uint64(0))},
+		{"1.1MB", 1100 * lsm.KB},
+		{"0.5K", 512},
+		{"2.0", 2},
+	}
+	for _, p := range parsed {
+		var size, err = lsm.ParseSize(p.text)
+		assert.Nil(t, err, p.text)
+		assert.Equal(t, p.size, size, p.text)
+	}
+
+	for _, invalid := range []string{"", "GiB", "10XB", "1.2.3M", "-1", "-1.5G", "17000PiB",
+		"16384.0PiB", "18446744073709551616.5", "1.5", "0.3K", "1.0000001MB", "."} {
+		var _, err = lsm.ParseSize(invalid)
+		var code, _ = errors.CodeOf(err)
+		assert.Equal(t, errors.InvalidArgument, code, invalid)
+	}
+
+	// Printed in the largest unit it is a whole number of
+	var printed = map[lsm.Size]string{
+		0:                 "0",
+		1000:              "1000",
+		1024:              "1KiB",
+		1536 * lsm.MiB:    "1536MiB",
+		10 * lsm.GiB:      "10GiB",
+		3 * lsm.PiB:       "3PiB",
+		lsm.TB:            "976562500KiB",
+		lsm.GiB + 512:     "1073742336",
+		lsm.GiB + lsm.KiB: "1048577KiB",
+	}
+	for size, text := range printed {
+		assert.Equal(t, text, size.String())
+		var parsedBack, _ = lsm.ParseSize(text)
+		assert.Equal(t, size, parsedBack)
+	}
+
+	var holder struct {
+		Size lsm.Size `json:"size"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"size": "2GiB"}`), &holder))
+	assert.Equal(t, 2*lsm.GiB, holder.Size)
+	assert.Nil(t, json.Unmarshal([]byte(`{"size": 4096}`), &holder))
+	assert.Equal(t, 4*lsm.KiB, holder.Size)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"size": true}`), &holder))
+	var encoded, _ = json.Marshal(holder)
+	assert.Equal(t, `{"size":4096}`, string(encoded))
+	var text, _ = holder.Size.MarshalText()
+	assert.Equal(t, "4KiB", string(text))
+
+	var rounding = []struct {
+		size      lsm.Size
+		blockSize uint64
+		rounded   lsm.Size
+	}{
+		{1000, 512, 1024},
+		{1024, 512, 1024},
+		{1, 4096, 4096},
+		{7, 0, 7},
+		{^lsm.Size(0) - 511, 512, ^lsm.Size(0) - 511},
+	}
+	for _, r := range rounding {
+		var rounded, roundErr = r.size.RoundUp(r.blockSize)
+		assert.Nil(t, roundErr)
+		assert.Equal(t, r.rounded, rounded)
+	}
+	var _, overflow = (^lsm.Size(0) - 1000).RoundUp(4096)
+	assert.Equal(t, errors.InvalidArgument, overflow.(*errors.LsmError).Code)
+
+	var pool = lsm.Pool{TotalSpace: 10 * 1024, FreeSpace: 4 * 1024}
+	assert.Equal(t, 6*lsm.KiB, pool.UsedBytes())
+
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+	var pools, _ = c.Pools()
+	var vol, job, createErr = c.VolumeCreateSize(context.Background(), &pools[3], rs("lsm_go_vol_", 8),
+		1000, lsm.VolumeProvisionTypeDefault)
+	assert.Nil(t, createErr)
+	assert.Nil(t, job.Wait(context.Background()))
+	assert.True(t, vol.SizeBytes() >= 1000)
+	assert.Equal(t, uint64(0), uint64(vol.SizeBytes())%vol.BlockSize)
+	assert.Equal(t, vol.BlockSize*vol.NumOfBlocks, uint64(vol.SizeBytes()))
+
+	var resized, resizeJob, resizeErr = c.VolumeResizeSize(context.Background(), vol, vol.SizeBytes()+1)
+	assert.Nil(t, resizeErr)
+	assert.Nil(t, resizeJob.Wait(context.Background()))
+	assert.Equal(t, vol.SizeBytes()+lsm.Size(vol.BlockSize), resized.SizeBytes())
+
+	var fs, fsJob, fsErr = c.FsCreateSize(context.Background(), &pools[2], rs("lsm_go_fs_", 8), 100*lsm.MiB)
+	assert.Nil(t, fsErr)
+	assert.Nil(t, fsJob.Wait(context.Background()))
+	assert.Equal(t, uint64(100*lsm.MiB), fs.TotalSpace)
+	var grown, growJob, growErr = c.FsResizeSize(context.Background(), fs, 200*lsm.MiB)
+	assert.Nil(t, growErr)
+	assert.Nil(t, growJob.Wait(context.Background()))
+	assert.Equal(t, uint64(200*lsm.MiB), grown.TotalSpace)
+	var _, fsDelErr = c.FsDelete(grown, true)
+	assert.Nil(t, fsDelErr)
+
+	var _, delErr = c.VolumeDelete(resized, true)
+	assert.Nil(t, delErr)
+	assert.Equal(t, nil, c.Close())
+}
+
 func TestSystems(t *testing.T) {
 	var c, _ = lsm.Client(URI, PASSWORD, TMO)
 	var systems, sysError = c.Systems()
@@ -529,6 +1296,175 @@ func TestCapabilitiesSet(t *testing.T) {
 	assert.Equal(t, nil, c.Close())
 }
 
+func TestCapabilitiesBuilder(t *testing.T) {
+	var b lsm.CapabilitiesBuilder
+	var cap = b.Set(lsm.CapVolumes, lsm.CapVolumeCreate, lsm.CapFs, lsm.CapabilityType(4096)).Build()
+	assert.Equal(t, "Capabilities", cap.Class)
+	assert.True(t, cap.IsSupported(lsm.CapVolumeCreate))
+	assert.False(t, cap.IsSupported(lsm.CapVolumeDelete))
+	assert.False(t, cap.IsSupported(lsm.CapabilityType(4096)))
+	assert.Equal(t, []lsm.CapabilityType{lsm.CapVolumes, lsm.CapVolumeCreate, lsm.CapFs}, cap.Supported())
+
+	cap = b.Unset(lsm.CapFs).Build()
+	assert.Equal(t, []lsm.CapabilityType{lsm.CapVolumes, lsm.CapVolumeCreate}, cap.Supported())
+
+	// Short bitmaps don't support what lies beyond them
+	var short = lsm.Capabilities{Class: "Capabilities", Cap: "0001"}
+	assert.True(t, short.IsSupported(lsm.CapabilityType(1)))
+	assert.False(t, short.IsSupported(lsm.CapVolumes))
+	assert.False(t, short.IsSupportedSet([]lsm.CapabilityType{1, lsm.CapVolumes}))
+	assert.Equal(t, []lsm.CapabilityType{1}, short.Supported())
+	assert.Nil(t, (&lsm.Capabilities{}).Supported())
+
+	assert.Equal(t, "VolumeCreate", lsm.CapVolumeCreate.String())
+	assert.Equal(t, "DiskVpd83Get", lsm.CapDiskVpd83Get.String())
+	assert.Equal(t, "CapabilityType(1)", lsm.CapabilityType(1).String())
+
+	var c, _ = lsm.Client(URI, PASSWORD, TMO)
+	var systems, sysError = c.Systems()
+	assert.Nil(t, sysError)
+	var simCap, capErr = c.Capabilities(&systems[0])
+	assert.Nil(t, capErr)
+	for _, s := range simCap.Supported() {
+		assert.False(t, strings.HasPrefix(s.String(), "CapabilityType("))
+	}
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestDefaultCapabilities(t *testing.T) {
+	var sim = simulator.New()
+	var simCallbacks = sim.Callbacks()
+	var callbacks = &lsm.PluginCallBacks{
+		Mgmt: lsm.ManagementOps{
+			PluginRegister:   simCallbacks.Mgmt.PluginRegister,
+			PluginUnregister: simCallbacks.Mgmt.PluginUnregister,
+			Systems:          simCallbacks.Mgmt.Systems,
+		},
+		San: lsm.SanOps{
+			Volumes:         simCallbacks.San.Volumes,
+			VolumeReplicate: simCallbacks.San.VolumeReplicate,
+			VolIdentLedOn:   simCallbacks.San.VolIdentLedOn,
+		},
+	}
+
+	// Capabilities are derived when the plugin doesn't provide them
+	var c, err = lsm.ClientPipe(callbacks, simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+	var systems, sysError = c.Systems()
+	assert.Nil(t, sysError)
+	var cap, capErr = c.Capabilities(&systems[0])
+	assert.Nil(t, capErr)
+	assert.Equal(t, []lsm.CapabilityType{lsm.CapVolumes, lsm.CapVolumeCReplicate}, cap.Supported())
+	assert.Equal(t, nil, c.Close())
+
+	// and refined per system, the variants are opted into
+	callbacks.Mgmt.Capabilities = lsm.DefaultCapabilities(callbacks,
+		func(system *lsm.System, caps *lsm.CapabilitiesBuilder) error {
+			if system.ID != systems[0].ID {
+				return &errors.LsmError{Code: errors.NotFoundSystem, Message: "no system"}
+			}
+			caps.Set(lsm.CapVolumeCReplicateClone, lsm.CapVolumeCReplicateCopy)
+			return nil
+		})
+	c, err = lsm.ClientPipe(callbacks, simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+	cap, capErr = c.Capabilities(&systems[0])
+	assert.Nil(t, capErr)
+	assert.Equal(t, []lsm.CapabilityType{lsm.CapVolumes, lsm.CapVolumeCReplicate,
+		lsm.CapVolumeCReplicateClone, lsm.CapVolumeCReplicateCopy}, cap.Supported())
+	var _, refineErr = c.Capabilities(&lsm.System{ID: "missing"})
+	assert.Equal(t, errors.NotFoundSystem, refineErr.(*errors.LsmError).Code)
+	assert.Equal(t, nil, c.Close())
+
+	// The simulator advertises everything its callbacks serve, and the
+	// variants it handles
+	var derived = lsm.DerivedCapabilities(simCallbacks).Build()
+	var simCap, simErr = simCallbacks.Mgmt.Capabilities(&systems[0])
+	assert.Nil(t, simErr)
+	for _, s := range derived.Supported() {
+		assert.True(t, simCap.IsSupported(s), s.String())
+	}
+	assert.False(t, derived.IsSupported(lsm.CapFsExportCustomPath))
+	assert.True(t, simCap.IsSupported(lsm.CapFsExportCustomPath))
+	assert.False(t, simCap.IsSupported(lsm.CapFsChildDepRmSpecificFiles))
+}
+
+func TestCapabilityChecks(t *testing.T) {
+	var sim = simulator.New()
+	var callbacks = sim.Callbacks()
+	var calls = 0
+	var replicateRange = callbacks.San.VolumeReplicateRange
+	callbacks.San.VolumeReplicateRange = func(repType lsm.VolumeReplicateType, src *lsm.Volume,
+		dst *lsm.Volume, ranges []lsm.BlockRange) (*string, error) {
+		calls++
+		return replicateRange(repType, src, dst, ranges)
+	}
+	var listed = 0
+	var systemsCb = callbacks.Mgmt.Systems
+	callbacks.Mgmt.Systems = func() ([]lsm.System, error) {
+		listed++
+		return systemsCb()
+	}
+	callbacks.Mgmt.Capabilities = lsm.DefaultCapabilities(callbacks,
+		func(system *lsm.System, caps *lsm.CapabilitiesBuilder) error {
+			caps.Set(lsm.CapVolumeCopyRangeClone)
+			return nil
+		})
+
+	var c, err = lsm.ClientPipe(callbacks, simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+	var systems, sysError = c.Systems()
+	assert.Nil(t, sysError)
+
+	assert.Nil(t, c.Require(&systems[0]))
+	assert.Nil(t, c.Require(&systems[0], lsm.CapVolumeCreate, lsm.CapVolumeCopyRange))
+	var reqErr = c.Require(&systems[0], lsm.CapVolumeCreate, lsm.CapVolumeCopyRangeCopy)
+	assert.Equal(t, errors.NoSupport, reqErr.(*errors.LsmError).Code)
+	assert.True(t, strings.Contains(reqErr.Error(), "VolumeCopyRangeCopy"))
+
+	var volume = createVolume(t, c, rs("lsm_go_vol_", 8))
+	var ranges = []lsm.BlockRange{{BlkCount: 100, SrcBlkAddr: 10, DstBlkAddr: 400}}
+
+	// Off by default, the plugin is called
+	var _, repErr = c.VolumeReplicateRange(lsm.VolumeReplicateTypeCopy, volume, volume, ranges, true)
+	assert.Nil(t, repErr)
+	assert.Equal(t, 1, calls)
+
+	// On, the call fails without reaching the plugin
+	c.CapabilityChecks(true)
+	_, repErr = c.VolumeReplicateRange(lsm.VolumeReplicateTypeCopy, volume, volume, ranges, true)
+	assert.Equal(t, errors.NoSupport, repErr.(*errors.LsmError).Code)
+	assert.True(t, strings.Contains(repErr.Error(), "VolumeCopyRangeCopy"))
+	assert.Equal(t, 1, calls)
+
+	_, repErr = c.VolumeReplicateRange(lsm.VolumeReplicateTypeClone, volume, volume, ranges, true)
+	assert.Nil(t, repErr)
+	assert.Equal(t, 2, calls)
+
+	var _, _, mirrorErr = c.VolumeReplicate(nil, lsm.VolumeReplicateTypeMirrorSync, volume,
+		rs("lsm_go_vol_", 8), true)
+	assert.Equal(t, errors.NoSupport, mirrorErr.(*errors.LsmError).Code)
+
+	// A system which isn't listed is left to the plugin, and looked up again
+	// as it may appear later
+	listed = 0
+	var elsewhere = *volume
+	elsewhere.SystemID = "missing"
+	for i := 0; i < 2; i++ {
+		_, repErr = c.VolumeReplicateRange(lsm.VolumeReplicateTypeCopy, &elsewhere, &elsewhere, ranges, true)
+		assert.Nil(t, repErr)
+	}
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 2, listed)
+
+	var _, delErr = c.VolumeDelete(volume, true)
+	assert.Nil(t, delErr)
+	assert.Equal(t, nil, c.Close())
+}
+
 func TestRepBlockSize(t *testing.T) {
 	var c, err = lsm.Client(URI, PASSWORD, TMO)
 	assert.Nil(t, err)
@@ -679,6 +1615,923 @@ func TestJobWait(t *testing.T) {
 	assert.Equal(t, nil, c.Close())
 }
 
+func TestContextCancelled(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var _, sysErr = c.SystemsCtx(ctx)
+	assert.Equal(t, context.Canceled, sysErr)
+
+	// Nothing was sent, so the connection is still usable
+	var systems, sysErr2 = c.Systems()
+	assert.Nil(t, sysErr2)
+	assert.Equal(t, 1, len(systems))
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestJobWaitCtx(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var pools, poolError = c.Pools()
+	assert.Nil(t, poolError)
+
+	var volumeName = rs("lsm_go_vol_async_", 8)
+	var volume, jobID, errCreate = c.VolumeCreate(&pools[2], volumeName, 1024*1024*100, 2, false)
+	assert.Nil(t, errCreate)
+	assert.NotNil(t, jobID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	assert.Equal(t, context.DeadlineExceeded, c.JobWaitCtx(ctx, *jobID, &volume))
+
+	// Job is still around and can be waited on normally
+	assert.Nil(t, c.JobWaitCtx(context.Background(), *jobID, &volume))
+	assert.Equal(t, volumeName, volume.Name)
+
+	c.VolumeDelete(volume, true)
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestJobHandle(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var pools, poolError = c.Pools()
+	assert.Nil(t, poolError)
+
+	var jobs []*lsm.Job
+	var names []string
+	var volumes = make([]lsm.Volume, 3)
+	for i := range volumes {
+		names = append(names, rs("lsm_go_vol_job_", 8))
+		var _, jobID, errCreate = c.VolumeCreate(&pools[2], names[i], 1024*1024*10, 2, false)
+		assert.Nil(t, errCreate)
+		assert.NotNil(t, jobID)
+
+		var job = c.Job(*jobID, &volumes[i])
+		job.Backoff = lsm.Backoff{Initial: time.Millisecond * 10, Max: time.Millisecond * 100, Factor: 2}
+		jobs = append(jobs, job)
+	}
+
+	var index, anyErr = lsm.WaitAny(context.Background(), jobs...)
+	assert.Nil(t, anyErr)
+	assert.True(t, index >= 0 && index < len(jobs))
+	assert.NotEqual(t, "", volumes[index].ID)
+
+	assert.Nil(t, lsm.WaitAll(context.Background(), jobs...))
+
+	for i, job := range jobs {
+		var last uint8
+		for percent := range job.Progress() {
+			last = percent
+		}
+		assert.Equal(t, uint8(100), last)
+
+		// Finished jobs report straight away
+		assert.Nil(t, job.Wait(context.Background()))
+		assert.Equal(t, names[i], volumes[i].Name)
+		c.VolumeDelete(&volumes[i], true)
+	}
+
+	// The Job variants of the asynchronous calls fill in the item on Wait
+	var volume, created, createErr = c.VolumeCreateJob(
+		context.Background(), &pools[2], rs("lsm_go_vol_job_", 8), 1024*1024*10, 2)
+	assert.Nil(t, createErr)
+	assert.NotEqual(t, "", created.ID)
+	assert.Nil(t, created.Wait(context.Background()))
+	assert.NotEqual(t, "", volume.ID)
+
+	var status, percent, statusErr = created.Status(context.Background())
+	assert.Nil(t, statusErr)
+	assert.Equal(t, lsm.JobStatusComplete, status)
+	assert.Equal(t, uint8(100), percent)
+
+	var deleted, deleteErr = c.VolumeDeleteJob(context.Background(), volume)
+	assert.Nil(t, deleteErr)
+	assert.Nil(t, deleted.Wait(context.Background()))
+
+	// A call completed without a job returns a finished one
+	var finished = lsm.AsyncJob(c, nil, nil)
+	assert.Equal(t, "", finished.ID)
+	assert.Nil(t, finished.Wait(context.Background()))
+
+	var bogus = c.Job("bogus", nil)
+	assert.NotNil(t, bogus.Wait(context.Background()))
+	var _, open = <-bogus.Progress()
+	assert.False(t, open)
+
+	var _, noJobs = lsm.WaitAny(context.Background())
+	assert.NotNil(t, noJobs)
+
+	assert.Equal(t, nil, c.Close())
+}
+
+// volumeNames is an example of code written against one of the client
+// interfaces rather than *lsm.ClientConnection
+func volumeNames(c lsm.SanClient, poolID string) ([]string, error) {
+	var volumes, err = c.Volumes("pool_id", poolID)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, v := range volumes {
+		names = append(names, v.Name)
+	}
+	return names, nil
+}
+
+func TestClientInterfaces(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var storage lsm.StorageClient = c
+	var pools, poolError = storage.Pools()
+	assert.Nil(t, poolError)
+
+	var _, namesError = volumeNames(c, pools[0].ID)
+	assert.Nil(t, namesError)
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestFakeClient(t *testing.T) {
+	var f = fake.New()
+	f.VolumesFunc = func(ctx context.Context, search ...string) ([]lsm.Volume, error) {
+		return []lsm.Volume{{Name: "a"}, {Name: "b"}}, nil
+	}
+
+	var names, err = volumeNames(f, "POOL_ID_01")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, names)
+
+	var calls = f.CallsTo("Volumes")
+	assert.Equal(t, 1, len(calls))
+	assert.Equal(t, []interface{}{[]string{"pool_id", "POOL_ID_01"}}, calls[0].Args)
+
+	// Unset functions return zero values
+	var systems, sysErr = f.SystemsCtx(context.Background())
+	assert.Nil(t, systems)
+	assert.Nil(t, sysErr)
+
+	var boom = &errors.LsmError{Code: errors.NotFoundVolume, Message: "boom"}
+	f.VolumeDeleteFunc = func(ctx context.Context, vol *lsm.Volume, sync bool) (*string, error) {
+		return nil, boom
+	}
+	var _, delErr = f.VolumeDelete(&lsm.Volume{ID: "VOL_ID_0001"}, true)
+	assert.Equal(t, boom, delErr)
+
+	// Jobs complete unless JobStatusFunc says otherwise
+	assert.Nil(t, f.Job("JOB_1", nil).Wait(context.Background()))
+	assert.Equal(t, 1, len(f.CallsTo("JobFree")))
+
+	assert.Equal(t, 6, len(f.Calls()))
+	f.Reset()
+	assert.Equal(t, 0, len(f.Calls()))
+}
+
+func TestErrorCatalog(t *testing.T) {
+	assert.Equal(t, "NotFoundVolume", errors.Code(errors.NotFoundVolume).String())
+	assert.Equal(t, "TransPortComunication", errors.Code(errors.TransPortComunication).String())
+	assert.Equal(t, "Code(9999)", errors.Code(9999).String())
+
+	var e error = &errors.LsmError{Code: errors.NotFoundPool, Message: "no such pool"}
+	var wrapped = fmt.Errorf("listing volumes: %w", e)
+	assert.True(t, stderrors.Is(wrapped, errors.ErrNotFoundPool))
+	assert.False(t, stderrors.Is(wrapped, errors.ErrNotFoundVolume))
+	assert.True(t, errors.IsNotFound(wrapped))
+	assert.False(t, errors.IsRetryable(wrapped))
+
+	var code, ok = errors.CodeOf(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, errors.NotFoundPool, code)
+
+	_, ok = errors.CodeOf(fmt.Errorf("not an lsm error"))
+	assert.False(t, ok)
+	assert.False(t, errors.IsNotFound(nil))
+
+	assert.True(t, errors.IsRetryable(&errors.LsmError{Code: errors.TimeOut}))
+	assert.True(t, errors.IsRetryable(&errors.LsmError{Code: errors.NetworkHostDown}))
+	assert.False(t, errors.IsRetryable(&errors.LsmError{Code: errors.InvalidArgument}))
+
+	// Errors coming back from a plugin work the same way
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var _, delErr = c.VolumeDelete(&lsm.Volume{ID: "bogus"}, true)
+	assert.True(t, stderrors.Is(delErr, errors.ErrNotFoundVolume))
+	assert.True(t, errors.IsNotFound(delErr))
+
+	assert.Equal(t, nil, c.Close())
+}
+
+// servePlugin serves callbacks as the plugin called name until the returned
+// function is called.
+func servePlugin(t *testing.T, name string, callbacks *lsm.PluginCallBacks) func() {
+	var dir, err = ioutil.TempDir("", "lsm_go_plugin")
+	assert.Nil(t, err)
+
+	var l, lErr = net.Listen("unix", filepath.Join(dir, name))
+	assert.Nil(t, lErr)
+
+	go func() {
+		for {
+			var conn, err = l.Accept()
+			if err != nil {
+				return
+			}
+			go lsm.PluginInitConn(callbacks, conn, "test plugin", "0.0.1").Run()
+		}
+	}()
+
+	const KEY = "LSM_UDS_PATH"
+	var current = os.Getenv(KEY)
+	os.Setenv(KEY, dir)
+
+	return func() {
+		l.Close()
+		os.RemoveAll(dir)
+		os.Setenv(KEY, current)
+	}
+}
+
+// closeConn records the plugin closing its end of the connection.
+type closeConn struct {
+	net.Conn
+	closed chan struct{}
+}
+
+func (c *closeConn) Close() error {
+	close(c.closed)
+	return c.Conn.Close()
+}
+
+func TestPluginRunClose(t *testing.T) {
+	var stdout = os.Stdout
+	var r, w, pipeErr = os.Pipe()
+	assert.Nil(t, pipeErr)
+	os.Stdout = w
+
+	var clientEnd, pluginEnd = net.Pipe()
+	var conn = &closeConn{Conn: pluginEnd, closed: make(chan struct{})}
+	var done = make(chan struct{})
+	go func() {
+		lsm.PluginInitConn(simulator.New().Callbacks(), conn, simulator.Description, simulator.Version).Run()
+		close(done)
+	}()
+
+	var c, err = lsm.ClientConn(clientEnd, "simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+	var _, sysErr = c.Systems()
+	assert.Nil(t, sysErr)
+
+	// The client going away without unregistering ends Run quietly
+	clientEnd.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run didn't return once the client went away")
+	}
+	os.Stdout = stdout
+	w.Close()
+
+	var printed, _ = ioutil.ReadAll(r)
+	assert.Equal(t, "", string(printed))
+	select {
+	case <-conn.closed:
+	default:
+		t.Fatal("Run didn't close the connection")
+	}
+}
+
+func TestPluginPanic(t *testing.T) {
+	var jobs = lsm.NewJobManager()
+	var callbacks = &lsm.PluginCallBacks{
+		Mgmt: lsm.ManagementOps{
+			PluginRegister:   func(p *lsm.PluginRegister) error { return nil },
+			PluginUnregister: func() error { return nil },
+			Systems: func() ([]lsm.System, error) {
+				var systems []lsm.System
+				return []lsm.System{systems[1]}, nil
+			},
+			Pools: func(search ...string) ([]lsm.Pool, error) {
+				return nil, fmt.Errorf("not an lsm error")
+			},
+		},
+		San: lsm.SanOps{
+			VolumeCreate: func(pool *lsm.Pool, volumeName string, size uint64,
+				provisioning lsm.VolumeProvisionType) (*lsm.Volume, *string, error) {
+				return nil, jobs.Start(func(progress lsm.JobProgress) (interface{}, error) {
+					panic("job went wrong")
+				}), nil
+			},
+		},
+	}
+	jobs.Install(callbacks)
+
+	var stop = servePlugin(t, "panic", callbacks)
+	defer stop()
+
+	var c, err = lsm.Client("panic://", PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	// The panic is reported and the plugin carries on
+	var _, sysErr = c.Systems()
+	assert.NotNil(t, sysErr)
+	var e = sysErr.(*errors.LsmError)
+	assert.Equal(t, errors.PluginBug, e.Code)
+	assert.Contains(t, e.Message, "systems panicked")
+	assert.Contains(t, e.Data, "TestPluginPanic")
+
+	var _, poolErr = c.Pools()
+	assert.NotNil(t, poolErr)
+	assert.Equal(t, errors.PluginBug, poolErr.(*errors.LsmError).Code)
+	assert.Equal(t, "not an lsm error", poolErr.(*errors.LsmError).Message)
+
+	var _, _, volErr = c.VolumeCreate(&lsm.Pool{ID: "POOL"}, "vol", 1024*1024, lsm.VolumeProvisionTypeDefault, true)
+	assert.NotNil(t, volErr)
+	assert.Equal(t, errors.PluginBug, volErr.(*errors.LsmError).Code)
+	assert.Contains(t, volErr.(*errors.LsmError).Message, "job went wrong")
+
+	// PluginBug doesn't exit as a usage error
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 3, cli.Run([]string{"-u", "panic://", "-P", PASSWORD, "list", "-type", "systems"},
+		&stdout, &stderr))
+	assert.Contains(t, stderr.String(), "systems panicked")
+
+	assert.Equal(t, nil, c.Close())
+}
+
+// testCert returns a self signed certificate for 127.0.0.1 and a pool
+// trusting it.
+func testCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	var key, keyErr = ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	assert.Nil(t, keyErr)
+
+	var template = x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "lsm_go_test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	var der, derErr = x509.CreateCertificate(crand.Reader, &template, &template, &key.PublicKey, key)
+	assert.Nil(t, derErr)
+
+	var parsed, parseErr = x509.ParseCertificate(der)
+	assert.Nil(t, parseErr)
+	var pool = x509.NewCertPool()
+	pool.AddCert(parsed)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func TestClientPipe(t *testing.T) {
+	var sim = simulator.New()
+	var c, err = lsm.ClientPipe(sim.Callbacks(), simulator.Description, simulator.Version,
+		"simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var systems, sysError = c.Systems()
+	assert.Nil(t, sysError)
+	assert.Equal(t, simulator.SystemID, systems[0].ID)
+	assert.Equal(t, "simgo", c.PluginName)
+
+	// Cancelling a write works over the pipe as well
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	var _, ctxErr = c.PoolsCtx(ctx)
+	assert.Equal(t, context.Canceled, ctxErr)
+
+	var pools, poolErr = c.Pools()
+	assert.Nil(t, poolErr)
+	assert.Equal(t, 4, len(pools))
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestClientTLS(t *testing.T) {
+	var cert, pool = testCert(t)
+
+	var l, lErr = net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, lErr)
+	defer l.Close()
+
+	var sim = simulator.New()
+	go lsm.PluginServe(tls.NewListener(l, &tls.Config{Certificates: []tls.Certificate{cert}}),
+		sim.Callbacks, simulator.Description, simulator.Version)
+
+	var c, err = lsm.ClientTLS(l.Addr().String(), &tls.Config{RootCAs: pool}, "simgo://", PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var systems, sysError = c.Systems()
+	assert.Nil(t, sysError)
+	assert.Equal(t, simulator.SystemID, systems[0].ID)
+	assert.Equal(t, nil, c.Close())
+
+	// The server isn't trusted without the pool
+	var _, untrusted = lsm.ClientTLS(l.Addr().String(), &tls.Config{}, "simgo://", PASSWORD, TMO)
+	assert.NotNil(t, untrusted)
+}
+
+func TestProxy(t *testing.T) {
+	// The self signed certificate serves as CA, proxy and client certificate
+	var cert, pool = testCert(t)
+
+	var l, lErr = net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, lErr)
+	defer l.Close()
+
+	var server = proxy.Server{
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		},
+		Authorize: func(state tls.ConnectionState, plugin string) error {
+			if plugin == "simc" {
+				return fmt.Errorf("%s may not use %s", state.PeerCertificates[0].Subject.CommonName, plugin)
+			}
+			return nil
+		},
+	}
+	go server.Serve(l)
+
+	var addr = l.Addr().String()
+	var config = &tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: pool}
+
+	var c, err = proxy.Client(addr, config, URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+	if c != nil {
+		var systems, sysError = c.Systems()
+		assert.Nil(t, sysError)
+		assert.Equal(t, 1, len(systems))
+
+		var pools, poolError = c.Pools()
+		assert.Nil(t, poolError)
+		assert.Equal(t, 4, len(pools))
+		assert.Equal(t, nil, c.Close())
+	}
+
+	var _, missing = proxy.Client(addr, config, "nosuchthing://", PASSWORD, TMO)
+	assert.NotNil(t, missing)
+	assert.Equal(t, errors.PluginNotExist, missing.(*errors.LsmError).Code)
+
+	var _, refused = proxy.Client(addr, config, "simc://", PASSWORD, TMO)
+	assert.NotNil(t, refused)
+	assert.Equal(t, errors.PermissionDenied, refused.(*errors.LsmError).Code)
+
+	// No client certificate, no access
+	var _, noCert = proxy.Client(addr, &tls.Config{RootCAs: pool}, URI, PASSWORD, TMO)
+	assert.NotNil(t, noCert)
+
+	// Refuses to run without client certificates
+	assert.NotNil(t, (&proxy.Server{TLSConfig: &tls.Config{}}).Serve(l))
+}
+
+// restDo sends body as JSON and decodes the response into out, returning the
+// response status and Location header.
+func restDo(t *testing.T, method string, url string, body interface{}, out interface{}) (int, string) {
+	var reader = strings.NewReader("")
+	if body != nil {
+		var b, err = json.Marshal(body)
+		assert.Nil(t, err)
+		reader = strings.NewReader(string(b))
+	}
+
+	var req, reqErr = http.NewRequest(method, url, reader)
+	assert.Nil(t, reqErr)
+
+	var resp, err = http.DefaultClient.Do(req)
+	if !assert.Nil(t, err) {
+		return 0, ""
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode, resp.Header.Get("Location")
+}
+
+func TestRest(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+	defer c.Close()
+
+	var server = httptest.NewServer(rest.NewServer(c))
+	defer server.Close()
+	var url = server.URL
+
+	var systems []lsm.System
+	var status, _ = restDo(t, "GET", url+"/systems", nil, &systems)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(systems))
+
+	var pools []lsm.Pool
+	status, _ = restDo(t, "GET", url+"/pools?system_id="+systems[0].ID, nil, &pools)
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, len(pools) > 0)
+	var poolID = pools[0].ID
+
+	for _, collection := range []string{"disks", "target_ports", "batteries", "access_groups", "fs", "exports"} {
+		status, _ = restDo(t, "GET", url+"/"+collection, nil, nil)
+		assert.Equal(t, http.StatusOK, status, collection)
+	}
+
+	var lsmErr errors.LsmError
+	status, _ = restDo(t, "GET", url+"/volumes/nosuchvolume", nil, &lsmErr)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, errors.NotFoundVolume, lsmErr.Code)
+
+	status, _ = restDo(t, "GET", url+"/nosuchthing", nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = restDo(t, "POST", url+"/volumes", map[string]string{"color": "blue"}, &lsmErr)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, errors.InvalidArgument, lsmErr.Code)
+
+	// Waiting for the job answers with the volume
+	var volume lsm.Volume
+	var volumeName = rs("lsm_go_rest_", 4)
+	status, _ = restDo(t, "POST", url+"/volumes?wait=true", rest.VolumeCreateRequest{
+		PoolID: poolID, Name: volumeName, SizeBytes: 1024 * 1024 * 100}, &volume)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, volumeName, volume.Name)
+
+	var fetched lsm.Volume
+	status, _ = restDo(t, "GET", url+"/volumes/"+volume.ID, nil, &fetched)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, volume.ID, fetched.ID)
+
+	// Otherwise the job is polled until done, unless the plugin didn't need one
+	var created json.RawMessage
+	var jobVolume lsm.Volume
+	var jobVolumeName = rs("lsm_go_rest_", 4)
+	status, location := restDo(t, "POST", url+"/volumes", rest.VolumeCreateRequest{
+		PoolID: poolID, Name: jobVolumeName, SizeBytes: 1024 * 1024 * 100}, &created)
+
+	if status == http.StatusAccepted {
+		var job rest.JobResponse
+		assert.Nil(t, json.Unmarshal(created, &job))
+		assert.Equal(t, "/jobs/"+job.ID, location)
+		for job.Status == "inprogress" {
+			time.Sleep(100 * time.Millisecond)
+			status, _ = restDo(t, "GET", url+location, nil, &job)
+			assert.Equal(t, http.StatusOK, status)
+		}
+		assert.Equal(t, "complete", job.Status)
+		created = job.Result
+
+		status, _ = restDo(t, "DELETE", url+location, nil, nil)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		status, _ = restDo(t, "GET", url+location, nil, &lsmErr)
+		assert.Equal(t, http.StatusNotFound, status)
+	} else {
+		assert.Equal(t, http.StatusCreated, status)
+	}
+	assert.Nil(t, json.Unmarshal(created, &jobVolume))
+	assert.Equal(t, jobVolumeName, jobVolume.Name)
+
+	// Masking
+	var ag lsm.AccessGroup
+	status, _ = restDo(t, "POST", url+"/access_groups", rest.AccessGroupCreateRequest{
+		Name:     rs("lsm_go_rest_ag_", 4),
+		InitID:   fmt.Sprintf("iqn.1994-05.com.domain:01.89%s", rs("", 4)),
+		InitType: lsm.InitiatorTypeIscsiIqn,
+		SystemID: systems[0].ID}, &ag)
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, _ = restDo(t, "POST", url+"/volumes/"+volume.ID+"/access_groups",
+		rest.MaskRequest{AccessGroupID: ag.ID}, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	var masked []lsm.Volume
+	status, _ = restDo(t, "GET", url+"/access_groups/"+ag.ID+"/volumes", nil, &masked)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(masked))
+
+	status, _ = restDo(t, "DELETE", url+"/volumes/"+volume.ID, nil, &lsmErr)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, errors.IsMasked, lsmErr.Code)
+
+	status, _ = restDo(t, "DELETE", url+"/volumes/"+volume.ID+"/access_groups/"+ag.ID, nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	status, _ = restDo(t, "DELETE", url+"/access_groups/"+ag.ID, nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	for _, v := range []lsm.Volume{volume, jobVolume} {
+		status, _ = restDo(t, "DELETE", url+"/volumes/"+v.ID+"?wait=true", nil, nil)
+		assert.Equal(t, http.StatusNoContent, status)
+	}
+
+	// File systems and exports
+	var fs lsm.FileSystem
+	status, _ = restDo(t, "POST", url+"/fs?wait=true", rest.FsCreateRequest{
+		PoolID: poolID, Name: rs("lsm_go_rest_fs_", 4), SizeBytes: 1024 * 1024 * 100}, &fs)
+	assert.Equal(t, http.StatusCreated, status)
+
+	var export lsm.NfsExport
+	status, _ = restDo(t, "POST", url+"/exports", rest.ExportRequest{
+		FsID: fs.ID, Rw: []string{"192.168.1.1"}}, &export)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, fs.ID, export.FsID)
+
+	var exports []lsm.NfsExport
+	status, _ = restDo(t, "GET", url+"/exports?fs_id="+fs.ID, nil, &exports)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(exports))
+
+	status, _ = restDo(t, "DELETE", url+"/exports/"+export.ID, nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	status, _ = restDo(t, "DELETE", url+"/fs/"+fs.ID+"?wait=true", nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+}
+
+func TestGrpc(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+	defer c.Close()
+
+	var l, lErr = net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, lErr)
+
+	var server = grpc.NewServer()
+	lsmgrpc.RegisterStorageServer(server, lsmgrpc.NewServer(c))
+	go server.Serve(l)
+	defer server.Stop()
+
+	var conn, connErr = grpc.NewClient(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, connErr)
+	defer conn.Close()
+
+	var gc = lsmgrpc.NewStorageClient(conn)
+	var ctx = context.Background()
+
+	var systems, sysErr = gc.Systems(ctx, &lsmgrpc.Empty{})
+	assert.Nil(t, sysErr)
+	assert.Equal(t, 1, len(systems.Systems))
+
+	var pools, poolErr = gc.Pools(ctx, &lsmgrpc.SearchRequest{Key: "system_id", Value: systems.Systems[0].Id})
+	assert.Nil(t, poolErr)
+	assert.True(t, len(pools.Pools) > 0)
+	var poolID = pools.Pools[0].Id
+
+	var _, missing = gc.VolumeDelete(ctx, &lsmgrpc.VolumeDeleteRequest{VolumeId: "nosuchvolume"})
+	assert.Equal(t, codes.NotFound, status.Code(missing))
+	assert.Equal(t, errors.NotFoundVolume, lsmgrpc.LsmError(missing).Code)
+
+	var _, badSearch = gc.Volumes(ctx, &lsmgrpc.SearchRequest{Key: "color", Value: "blue"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(badSearch))
+
+	// Watch the create job through to the volume
+	var volumeName = rs("lsm_go_grpc_", 4)
+	var created, createErr = gc.VolumeCreate(ctx, &lsmgrpc.VolumeCreateRequest{
+		PoolId: poolID, Name: volumeName, SizeBytes: 1024 * 1024 * 100})
+	assert.Nil(t, createErr)
+
+	var volume = created.GetVolume()
+	if len(created.JobId) > 0 {
+		var stream, watchErr = gc.JobWatch(ctx, &lsmgrpc.JobRequest{JobId: created.JobId})
+		assert.Nil(t, watchErr)
+
+		var last *lsmgrpc.JobStatusReply
+		for {
+			var reply, recvErr = stream.Recv()
+			if recvErr != nil {
+				break
+			}
+			last = reply
+		}
+		assert.NotNil(t, last)
+		assert.Equal(t, lsmgrpc.JobStatus_JOB_STATUS_COMPLETE, last.Status)
+		assert.Equal(t, uint32(100), last.Percent)
+		volume = last.GetVolume()
+
+		// Watching frees the job
+		var _, freed = gc.JobStatus(ctx, &lsmgrpc.JobRequest{JobId: created.JobId})
+		assert.Equal(t, codes.NotFound, status.Code(freed))
+	}
+	assert.NotNil(t, volume)
+	assert.Equal(t, volumeName, volume.GetName())
+
+	var deleted, deleteErr = gc.VolumeDelete(ctx, &lsmgrpc.VolumeDeleteRequest{VolumeId: volume.GetId(), Sync: true})
+	assert.Nil(t, deleteErr)
+	assert.Equal(t, "", deleted.GetJobId())
+
+	var volumes, volErr = gc.Volumes(ctx, &lsmgrpc.SearchRequest{Key: "id", Value: volume.GetId()})
+	assert.Nil(t, volErr)
+	assert.Equal(t, 0, len(volumes.Volumes))
+}
+
+// watchStream is the server side of a JobWatch stream.
+type watchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *lsmgrpc.JobStatusReply
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(reply *lsmgrpc.JobStatusReply) error {
+	s.replies <- reply
+	return nil
+}
+
+func TestGrpcJobWatchCancel(t *testing.T) {
+	var f = fake.New()
+	f.JobStatusFunc = func(ctx context.Context, jobID string, returnedResult interface{}) (lsm.JobStatusType, uint8, error) {
+		return lsm.JobStatusInprogress, 10, nil
+	}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	var stream = &watchStream{ctx: ctx, replies: make(chan *lsmgrpc.JobStatusReply, 10)}
+	var done = make(chan error, 1)
+	go func() {
+		done <- lsmgrpc.NewServer(f).JobWatch(&lsmgrpc.JobRequest{JobId: "JOB_1"}, stream)
+	}()
+
+	var reply = <-stream.replies
+	assert.Equal(t, lsmgrpc.JobStatus_JOB_STATUS_INPROGRESS, reply.Status)
+	assert.Equal(t, uint32(10), reply.Percent)
+
+	// The job never finishes, the watch ends with the stream
+	cancel()
+	select {
+	case watchErr := <-done:
+		assert.Equal(t, codes.Canceled, status.Code(watchErr))
+	case <-time.After(5 * time.Second):
+		t.Fatal("JobWatch didn't return once the stream was cancelled")
+	}
+	assert.Equal(t, 0, len(f.CallsTo("JobFreeCtx")))
+}
+
+// lsmgo runs the command line tool against the test plugin.
+func lsmgo(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	var code = cli.Run(append([]string{"-u", URI, "-P", PASSWORD}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCli(t *testing.T) {
+	var code, out, _ = lsmgo("list", "-type", "systems")
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.HasPrefix(out, "id "))
+
+	var pools []lsm.Pool
+	code, out, _ = lsmgo("-o", "json", "list", "-type", "pools")
+	assert.Equal(t, 0, code)
+	assert.Nil(t, json.Unmarshal([]byte(out), &pools))
+	assert.True(t, len(pools) > 0)
+	var poolID = pools[0].ID
+
+	code, out, _ = lsmgo("-o", "csv", "list", "-type", "pools", "-id", poolID)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, 2, len(strings.Split(strings.TrimSpace(out), "\n")))
+
+	code, out, _ = lsmgo("inventory", "-dot")
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.Contains(out, "\"pool:"+poolID+"\""))
+
+	var stateFile, _ = ioutil.TempFile("", "lsm_go_state")
+	defer os.Remove(stateFile.Name())
+	fmt.Fprintf(stateFile, "volumes: [{name: lsm_go_cli_state, pool: %s, size: 1048576}]\n", poolID)
+	stateFile.Close()
+	code, out, _ = lsmgo("apply", "-file", stateFile.Name(), "-dry-run")
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.HasPrefix(out, "+ create volume lsm_go_cli_state (1MiB in pool "+poolID+")"))
+
+	code, out, _ = lsmgo("capabilities", "-sys", pools[0].SystemID)
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.Contains(out, "\nVolumeCreate\n"))
+
+	var sizeCode, _, sizeErr = lsmgo("volume-create", "-name", "x", "-pool", poolID, "-size", "10XB")
+	assert.NotEqual(t, 0, sizeCode)
+	assert.True(t, strings.Contains(sizeErr, `unknown unit "XB" in size "10XB"`), sizeErr)
+
+	// Sizes take units
+	var volume lsm.Volume
+	var name = rs("lsm_go_cli_", 4)
+	code, out, _ = lsmgo("-o", "json", "volume-create", "-name", name, "-pool", poolID, "-size", "100MiB")
+	assert.Equal(t, 0, code)
+	assert.Nil(t, json.Unmarshal([]byte(out), &volume))
+	assert.Equal(t, name, volume.Name)
+	assert.True(t, volume.BlockSize*volume.NumOfBlocks >= 100*1024*1024)
+
+	// Jobs are left running with -async, job-status exits with 7 until done
+	var asyncVolume lsm.Volume
+	var asyncName = rs("lsm_go_cli_", 4)
+	code, out, _ = lsmgo("-o", "json", "-async", "volume-create", "-name", asyncName, "-pool", poolID, "-size", "1GiB")
+	if code == int(errors.JobStarted) {
+		var job = strings.TrimSpace(out)
+		for code == int(errors.JobStarted) {
+			time.Sleep(100 * time.Millisecond)
+			code, out, _ = lsmgo("-o", "json", "job-status", "-job", job)
+		}
+	}
+	assert.Equal(t, 0, code)
+	assert.Nil(t, json.Unmarshal([]byte(out), &asyncVolume))
+	assert.Equal(t, asyncName, asyncVolume.Name)
+
+	code, out, _ = lsmgo("-o", "csv", "list", "-type", "volumes", "-pool", poolID, "-id", asyncVolume.ID)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, asyncName)
+
+	for _, v := range []lsm.Volume{volume, asyncVolume} {
+		code, _, _ = lsmgo("volume-delete", "-vol", v.ID)
+		assert.Equal(t, 0, code)
+	}
+
+	// Errors exit with the error code
+	var stderr string
+	code, _, stderr = lsmgo("volume-delete", "-vol", volume.ID)
+	assert.Equal(t, int(errors.NotFoundVolume), code)
+	assert.Contains(t, stderr, volume.ID)
+
+	code, _, stderr = lsmgo("volume-create", "-name", name)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "-pool is required")
+
+	code, _, _ = lsmgo("volume-create", "-name", name, "-pool", poolID, "-size", "10 parsecs")
+	assert.Equal(t, 2, code)
+
+	code, _, _ = lsmgo("list", "-type", "disks", "-pool", poolID)
+	assert.Equal(t, 2, code)
+
+	code, _, _ = lsmgo("nosuchcommand")
+	assert.Equal(t, 2, code)
+
+	code, out, _ = lsmgo("completion")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "complete -F _lsmgo lsmgo")
+	assert.Contains(t, out, "volume-raid-create)")
+}
+
+func TestConcurrentClient(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				switch (i + j) % 3 {
+				case 0:
+					var systems, sE = c.Systems()
+					assert.Nil(t, sE)
+					assert.Equal(t, 1, len(systems))
+					assert.Equal(t, "System", systems[0].Class)
+				case 1:
+					var pools, pE = c.Pools()
+					assert.Nil(t, pE)
+					assert.Equal(t, 4, len(pools))
+				case 2:
+					var _, vE = c.Volumes()
+					assert.Nil(t, vE)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, nil, c.Close())
+}
+
+func TestAbandonedReplies(t *testing.T) {
+	var c, err = lsm.Client(URI, PASSWORD, TMO)
+	assert.Nil(t, err)
+
+	// Give up on a bunch of calls, their replies will show up late
+	for i := 0; i < 50; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Microsecond)
+		c.PoolsCtx(ctx)
+		cancel()
+	}
+
+	// A late reply must not be mistaken for the answer to this call
+	var systems, sE = c.Systems()
+	assert.Nil(t, sE)
+	assert.Equal(t, 1, len(systems))
+	assert.Equal(t, "System", systems[0].Class)
+
+	assert.Equal(t, nil, c.Close())
+}
+
 func TestTmo(t *testing.T) {
 	var c, err = lsm.Client(URI, PASSWORD, TMO)
 	assert.Nil(t, err)
@@ -1297,6 +3150,27 @@ func TestSystemReadCachePct(t *testing.T) {
 func TestSystemStatusType(t *testing.T) {
 	assert.Equal(t, lsm.SystemStatusType(1<<0), lsm.SystemStatusUnknown)
 	assert.Equal(t, lsm.SystemStatusType(1<<5), lsm.SystemStatusOther)
+
+	var tests = []struct {
+		status lsm.SystemStatusType
+		str    string
+		text   string
+	}{
+		{lsm.SystemStatusOk, "OK", "OK"},
+		{lsm.SystemStatusOk | lsm.SystemStatusDegraded, "OK|Degraded", "OK|Degraded"},
+		{lsm.SystemStatusError | 1<<8, "Error|SystemStatusType(0x100)", "Error|0x100"},
+		{0, "SystemStatusType(0x0)", "0x0"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.str, test.status.String())
+		var text, err = test.status.MarshalText()
+		assert.Nil(t, err)
+		assert.Equal(t, test.text, string(text))
+
+		var status lsm.SystemStatusType
+		assert.Nil(t, status.UnmarshalText(text))
+		assert.Equal(t, test.status, status)
+	}
 }
 
 func TestSystemModeType(t *testing.T) {
@@ -1339,6 +3213,24 @@ func TestPoolStatusType(t *testing.T) {
 	assert.Equal(t, lsm.PoolStatusType(1<<9), lsm.PoolStatusStopped)
 	assert.Equal(t, lsm.PoolStatusType(1<<12), lsm.PoolStatusReconstructing)
 	assert.Equal(t, lsm.PoolStatusType(1<<15), lsm.PoolStatusGrowing)
+
+	var tests = []struct {
+		status lsm.PoolStatusType
+		text   string
+	}{
+		{lsm.PoolStatusOk, "OK"},
+		{lsm.PoolStatusDegraded | lsm.PoolStatusReconstructing, "Degraded|Reconstructing"},
+		{lsm.PoolStatusStopped | 1<<20, "Stopped|0x100000"},
+	}
+	for _, test := range tests {
+		var text, err = test.status.MarshalText()
+		assert.Nil(t, err)
+		assert.Equal(t, test.text, string(text))
+
+		var status lsm.PoolStatusType
+		assert.Nil(t, status.UnmarshalText(text))
+		assert.Equal(t, test.status, status)
+	}
 }
 
 func TestDiskType(t *testing.T) {
@@ -1442,6 +3334,85 @@ func TestDiskLedStatusBitField(t *testing.T) {
 	assert.Equal(t, lsm.DiskLedStatusBitField(0x0000000000000040), lsm.DiskLedStatusFaultUnknown)
 }
 
+func TestEnumText(t *testing.T) {
+	type enum interface {
+		fmt.Stringer
+		encoding.TextMarshaler
+	}
+	var tests = []struct {
+		value enum
+		name  string
+		into  encoding.TextUnmarshaler
+	}{
+		{lsm.SystemModeHardwareRaid, "HardwareRaid", new(lsm.SystemModeType)},
+		{lsm.SystemStatusOk | lsm.SystemStatusPredictiveFailure, "OK|PredictiveFailure", new(lsm.SystemStatusType)},
+		{lsm.JobStatusComplete, "Complete", new(lsm.JobStatusType)},
+		{lsm.VolumeReplicateTypeMirrorSync, "MirrorSync", new(lsm.VolumeReplicateType)},
+		{lsm.VolumeProvisionTypeThin, "Thin", new(lsm.VolumeProvisionType)},
+		{lsm.PoolElementTypeVolume | lsm.PoolElementTypeFs, "Volume|Fs", new(lsm.PoolElementType)},
+		{lsm.PoolUnsupportedVolumeGrow, "VolumeGrow", new(lsm.PoolUnsupportedType)},
+		{lsm.PoolStatusOk | lsm.PoolStatusReconstructing, "OK|Reconstructing", new(lsm.PoolStatusType)},
+		{lsm.DiskTypeNlSas, "NlSas", new(lsm.DiskType)},
+		{lsm.DiskLinkTypePciE, "PciE", new(lsm.DiskLinkType)},
+		{lsm.DiskStatusOk | lsm.DiskStatusSpareDisk, "OK|SpareDisk", new(lsm.DiskStatusType)},
+		{lsm.InitiatorTypeIscsiIqn, "IscsiIqn", new(lsm.InitiatorType)},
+		{lsm.PortTypeFCoE, "FCoE", new(lsm.PortType)},
+		{lsm.BatteryTypeChemical, "Chemical", new(lsm.BatteryType)},
+		{lsm.BatteryStatusOk | lsm.BatteryStatusCharging, "OK|Charging", new(lsm.BatteryStatus)},
+		{lsm.CapVolumeCreate, "VolumeCreate", new(lsm.CapabilityType)},
+		{lsm.Raid10, "RAID10", new(lsm.RaidType)},
+		{lsm.RaidJbod, "JBOD", new(lsm.RaidType)},
+		{lsm.MemberTypeDisk, "Disk", new(lsm.MemberType)},
+		{lsm.WriteCachePolicyWriteBack, "WriteBack", new(lsm.WriteCachePolicy)},
+		{lsm.WriteCacheStatusWriteThrough, "WriteThrough", new(lsm.WriteCacheStatus)},
+		{lsm.ReadCachePolicyEnabled, "Enabled", new(lsm.ReadCachePolicy)},
+		{lsm.ReadCacheStatusDisabled, "Disabled", new(lsm.ReadCacheStatus)},
+		{lsm.PhysicalDiskCacheUseDiskSetting, "UseDiskSetting", new(lsm.PhysicalDiskCache)},
+		{lsm.DiskHealthStatusWarn, "Warn", new(lsm.DiskHealthStatus)},
+		{lsm.DiskLedStatusIdentOn | lsm.DiskLedStatusFaultOff, "IdentOn|FaultOff", new(lsm.DiskLedStatusBitField)},
+		{lsm.DiskType(99), "DiskType(99)", new(lsm.DiskType)},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.name, test.value.String())
+
+		// Text round trips, unknown values as numbers
+		var text, err = test.value.MarshalText()
+		assert.Nil(t, err)
+		assert.Nil(t, test.into.UnmarshalText(text))
+		assert.Equal(t, test.value, reflect.ValueOf(test.into).Elem().Interface())
+	}
+
+	// Names are matched ignoring case and numbers are accepted
+	var status lsm.DiskStatusType
+	assert.Nil(t, status.UnmarshalText([]byte("ok | free")))
+	assert.Equal(t, lsm.DiskStatusOk|lsm.DiskStatusFree, status)
+	assert.Nil(t, status.UnmarshalText([]byte("2|0x4")))
+	assert.Equal(t, lsm.DiskStatusOk|lsm.DiskStatusOther, status)
+
+	var raid = lsm.Raid5
+	var badErr = raid.UnmarshalText([]byte("RAID7"))
+	assert.Equal(t, errors.InvalidArgument, badErr.(*errors.LsmError).Code)
+	assert.Equal(t, lsm.Raid5, raid)
+
+	// JSON stays numeric, names are accepted
+	var pool = lsm.Pool{Status: lsm.PoolStatusOk, ElementType: lsm.PoolElementTypeVolume}
+	var poolJSON, jsonErr = json.Marshal(&pool)
+	assert.Nil(t, jsonErr)
+	assert.True(t, strings.Contains(string(poolJSON), `"status":2`))
+
+	var config struct {
+		Raid   lsm.RaidType         `json:"raid"`
+		Policy lsm.WriteCachePolicy `json:"policy"`
+		Status lsm.PoolStatusType   `json:"status"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"raid": "raid6", "policy": 3, "status": "OK|Degraded"}`), &config))
+	assert.Equal(t, lsm.Raid6, config.Raid)
+	assert.Equal(t, lsm.WriteCachePolicyAuto, config.Policy)
+	assert.Equal(t, lsm.PoolStatusOk|lsm.PoolStatusDegraded, config.Status)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"raid": "RAID7"}`), &config))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"raid": true}`), &config))
+}
+
 func TestVolumeEnableSerDes(t *testing.T) {
 	var vol lsm.Volume
 	volJSON, err := json.Marshal(&vol)
@@ -1505,7 +3476,68 @@ func setup() {
 		c.Close()
 	}
 }
+
+// startSimulator serves the Go simulator in process when lsmd can't be
+// reached, returning a function to clean up afterwards.  Both plugin names
+// are served by the same simulated array.
+func startSimulator() func() {
+	if c, err := lsm.Client(URI, PASSWORD, TMO); err == nil {
+		c.Close()
+		return func() {}
+	}
+
+	dir, err := ioutil.TempDir("", "lsm_go_ipc")
+	if err != nil {
+		fmt.Printf("Unable to create socket folder %s\n", err)
+		os.Exit(1)
+	}
+
+	var sim = simulator.New()
+	var listeners []net.Listener
+	for _, name := range []string{"sim", "simc"} {
+		l, err := net.Listen("unix", filepath.Join(dir, name))
+		if err != nil {
+			fmt.Printf("Unable to listen for %s plugin %s\n", name, err)
+			os.Exit(1)
+		}
+		listeners = append(listeners, l)
+		go sim.Serve(l)
+	}
+
+	const KEY = "LSM_UDS_PATH"
+	var current = os.Getenv(KEY)
+	os.Setenv(KEY, dir)
+
+	return func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+		os.RemoveAll(dir)
+		os.Setenv(KEY, current)
+	}
+}
+
+// pluginVarName makes the test binary run as the simulator plugin when set,
+// so it can be started by ClientExec.
+const pluginVarName = "LSM_GO_TEST_PLUGIN"
+
+func runPlugin() {
+	var p, err = lsm.PluginInit(simulator.New().Callbacks(), os.Args,
+		simulator.Description, simulator.Version)
+	if err != nil {
+		fmt.Printf("Failed to initialize plugin, exiting! (%s)\n", err)
+		os.Exit(1)
+	}
+	p.Run()
+	os.Exit(0)
+}
+
 func TestMain(m *testing.M) {
+	if len(os.Getenv(pluginVarName)) > 0 {
+		runPlugin()
+	}
+
+	cleanup := startSimulator()
 	setup()
 
 	// This will allow us to reproduce the same sequence if needed
@@ -1524,6 +3556,6 @@ func TestMain(m *testing.M) {
 	}
 
 	code := m.Run()
-	//shutdown()
+	cleanup()
 	os.Exit(code)
 }
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: 0BSD
+
+package libstoragemgmt
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Besides the unix domain sockets of lsmd the framing used between client and
+// plugin works over any stream connection, see ClientConn and PluginInitConn.
+// These are the ready made ones.
+
+// ClientPipe serves callbacks in this process and returns a client connected to
+// them through an in-memory pipe, no sockets are involved.
+func ClientPipe(callbacks *PluginCallBacks, desc string, ver string,
+	uri string, password string, timeout uint32) (*ClientConnection, error) {
+
+	clientEnd, pluginEnd := net.Pipe()
+	go PluginInitConn(callbacks, pluginEnd, desc, ver).Run()
+	return ClientConn(clientEnd, uri, password, timeout)
+}
+
+// ClientTLS connects to a plugin served over TCP with TLS at addr, eg. by
+// PluginServeTLS on another host.
+func ClientTLS(addr string, config *tls.Config, uri string, password string, timeout uint32) (*ClientConnection, error) {
+	return ClientTLSCtx(context.Background(), addr, config, uri, password, timeout)
+}
+
+// ClientTLSCtx is ClientTLS with a context used for cancellation and deadlines
+// while connecting to and registering with the plugin.  The context is not
+// retained by the returned connection.
+func ClientTLSCtx(ctx context.Context, addr string, config *tls.Config,
+	uri string, password string, timeout uint32) (*ClientConnection, error) {
+
+	conn, tlsError := DialTLS(ctx, addr, config)
+	if tlsError != nil {
+		return nil, tlsError
+	}
+	return ClientConnCtx(ctx, conn, uri, password, timeout)
+}
+
+// DialTLS connects to addr over TCP and completes the TLS handshake.
+func DialTLS(ctx context.Context, addr string, config *tls.Config) (*tls.Conn, error) {
+	var d net.Dialer
+	raw, dialError := d.DialContext(ctx, "tcp", addr)
+	if dialError != nil {
+		return nil, &errors.LsmError{
+			Code:    errors.NetworkConRefused,
+			Message: fmt.Sprintf("unable to connect to %s: %v", addr, dialError)}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		raw.SetDeadline(deadline)
+	}
+
+	// As tls.Dial does, verify the server against the host dialed by default
+	if config == nil {
+		config = &tls.Config{}
+	}
+	if len(config.ServerName) == 0 && !config.InsecureSkipVerify {
+		if host, _, splitError := net.SplitHostPort(addr); splitError == nil {
+			config = config.Clone()
+			config.ServerName = host
+		}
+	}
+
+	conn := tls.Client(raw, config)
+	if handshakeError := conn.Handshake(); handshakeError != nil {
+		raw.Close()
+		return nil, &errors.LsmError{
+			Code:    errors.TransPortComunication,
+			Message: fmt.Sprintf("TLS handshake with %s failed: %v", addr, handshakeError)}
+	}
+	raw.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// PluginServe accepts connections on l and serves each with a plugin using the
+// callbacks returned by newCallbacks, until l is closed.
+func PluginServe(l net.Listener, newCallbacks func() *PluginCallBacks, desc string, ver string) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go PluginInitConn(newCallbacks(), conn, desc, ver).Run()
+	}
+}
+
+// PluginServeTLS is PluginServe for TCP connections with TLS on addr.
+func PluginServeTLS(addr string, config *tls.Config, newCallbacks func() *PluginCallBacks,
+	desc string, ver string) error {
+
+	l, err := tls.Listen("tcp", addr, config)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	return PluginServe(l, newCallbacks, desc, ver)
+}
@@ -0,0 +1,307 @@
+// SPDX-License-Identifier: 0BSD
+
+// Package workflow runs provisioning made of several calls as one, undoing
+// the steps taken when a later one fails so nothing is leaked, eg.
+//
+//	w := workflow.New(client)
+//	vol, err := w.VolumeCreate(ctx, pool, "data", size, lsm.VolumeProvisionTypeDefault)
+//	if err != nil {
+//		return err
+//	}
+//	ag, err := w.AccessGroupCreate(ctx, "host", iqn, lsm.InitiatorTypeIscsiIqn, system)
+//	if err != nil {
+//		return err // the volume is deleted
+//	}
+//	if err := w.VolumeMask(ctx, vol, ag); err != nil {
+//		return err // the access group and the volume are deleted
+//	}
+//	w.Commit()
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	lsm "github.com/libstorage/libstoragemgmt-golang"
+	errors "github.com/libstorage/libstoragemgmt-golang/errors"
+)
+
+// Func is a step or the compensating action undoing it.
+type Func func(ctx context.Context, client lsm.StorageClient) error
+
+type compensation struct {
+	name string
+	undo Func
+}
+
+// DefaultRollbackTimeout is the RollbackTimeout of new workflows.
+const DefaultRollbackTimeout = 5 * time.Minute
+
+// Workflow runs steps through the client, remembering how to undo each.  The
+// methods of a Workflow are safe for concurrent use by multiple goroutines,
+// the steps are run one at a time.
+type Workflow struct {
+	// RollbackTimeout bounds a rollback run once the context it was given
+	// is done, change it before the first step
+	RollbackTimeout time.Duration
+
+	client lsm.StorageClient
+
+	lock     sync.Mutex
+	undo     []compensation
+	finished string
+}
+
+// New returns a workflow running its steps through the client.
+func New(client lsm.StorageClient) *Workflow {
+	return &Workflow{RollbackTimeout: DefaultRollbackTimeout, client: client}
+}
+
+// StepError is the error of a failed step, it wraps the error the step
+// failed with, so errors.CodeOf and errors.Is see through it.
+type StepError struct {
+	Step string
+	Err  error
+
+	// Rollback is why undoing the steps taken failed, nil when they were
+	// all undone
+	Rollback error
+}
+
+func (e *StepError) Error() string {
+	result := "rolled back"
+	if e.Rollback != nil {
+		result = errors.MessageOf(e.Rollback)
+	}
+	return fmt.Sprintf("step %s failed: %s, %s", e.Step, errors.MessageOf(e.Err), result)
+}
+
+// Unwrap returns the error the step failed with.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Step runs do and once it succeeds registers undo, which may be nil for
+// steps with nothing to undo.  When do fails the steps taken so far are
+// undone in reverse order and a StepError says how that went, the workflow
+// is finished then and runs no more steps.
+//
+// Asynchronous calls are to wait for their jobs, so the next step and the
+// rollback find the step done.
+func (w *Workflow) Step(ctx context.Context, name string, do Func, undo Func) error {
+	return w.step(ctx, name, do, undo, nil)
+}
+
+// step is Step with wait run once undo is registered, so a step started but
+// not seen to the end, eg. as the context expired waiting for its job, is
+// undone too.
+func (w *Workflow) step(ctx context.Context, name string, do Func, undo Func, wait Func) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.finished) > 0 {
+		return &errors.LsmError{
+			Code:    errors.InvalidArgument,
+			Message: fmt.Sprintf("step %s: the workflow is %s", name, w.finished)}
+	}
+
+	if err := do(ctx, w.client); err != nil {
+		return &StepError{Step: name, Err: err, Rollback: w.rollback(ctx)}
+	}
+	if undo != nil {
+		w.undo = append(w.undo, compensation{name, undo})
+	}
+	if wait != nil {
+		if err := wait(ctx, w.client); err != nil {
+			return &StepError{Step: name, Err: err, Rollback: w.rollback(ctx)}
+		}
+	}
+	return nil
+}
+
+// Rollback undoes the steps taken so far in reverse order, eg. when
+// something other than a step failed, and finishes the workflow.  Every
+// compensating action is tried, the error names those failing.
+func (w *Workflow) Rollback(ctx context.Context) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.finished) > 0 {
+		return nil
+	}
+	return w.rollback(ctx)
+}
+
+// Commit keeps the steps taken and finishes the workflow.
+func (w *Workflow) Commit() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.finished) == 0 {
+		w.undo = nil
+		w.finished = "committed"
+	}
+}
+
+// rollback runs the compensating actions, the error has the code of the
+// first failing.  A context done, likely why a step failed, is replaced by
+// one of RollbackTimeout as it would leave the steps in place.
+func (w *Workflow) rollback(ctx context.Context) error {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), w.RollbackTimeout)
+		defer cancel()
+	}
+
+	var failed []string
+	var code int32
+	for i := len(w.undo) - 1; i >= 0; i-- {
+		if err := w.undo[i].undo(ctx, w.client); err != nil {
+			if len(failed) == 0 {
+				if code, _ = errors.CodeOf(err); code == 0 {
+					code = errors.LibBug
+				}
+			}
+			failed = append(failed, fmt.Sprintf("undoing %s failed: %s", w.undo[i].name, errors.MessageOf(err)))
+		}
+	}
+	w.undo = nil
+	w.finished = "rolled back"
+
+	if len(failed) > 0 {
+		return &errors.LsmError{
+			Code:    code,
+			Message: "rollback incomplete, " + strings.Join(failed, ", ")}
+	}
+	return nil
+}
+
+// created is the undo of a step creating through the job, it waits for the
+// job and deletes what it created.  A job which failed created nothing.
+func created(job **lsm.Job, del Func) Func {
+	return func(ctx context.Context, c lsm.StorageClient) error {
+		if err := (*job).Wait(ctx); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			return nil
+		}
+		return del(ctx, c)
+	}
+}
+
+// waitFor is the wait of a step creating through the job.
+func waitFor(job **lsm.Job) Func {
+	return func(ctx context.Context, c lsm.StorageClient) error {
+		return (*job).Wait(ctx)
+	}
+}
+
+// VolumeCreate creates a volume, deleted on rollback.  The volume is deleted
+// on rollback even if the context is done before its job finished.
+func (w *Workflow) VolumeCreate(ctx context.Context, pool *lsm.Pool, name string, size uint64,
+	provisioning lsm.VolumeProvisionType) (*lsm.Volume, error) {
+
+	var vol *lsm.Volume
+	var job *lsm.Job
+	err := w.step(ctx, "volume create "+name,
+		func(ctx context.Context, c lsm.StorageClient) (err error) {
+			vol, job, err = c.VolumeCreateJob(ctx, pool, name, size, provisioning)
+			return err
+		},
+		created(&job, func(ctx context.Context, c lsm.StorageClient) error {
+			_, err := c.VolumeDeleteCtx(ctx, vol, true)
+			return err
+		}),
+		waitFor(&job))
+	if err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
+// FsCreate creates a file system, deleted on rollback.  The file system is
+// deleted on rollback even if the context is done before its job finished.
+func (w *Workflow) FsCreate(ctx context.Context, pool *lsm.Pool, name string, size uint64) (*lsm.FileSystem, error) {
+	var fs *lsm.FileSystem
+	var job *lsm.Job
+	err := w.step(ctx, "fs create "+name,
+		func(ctx context.Context, c lsm.StorageClient) (err error) {
+			fs, job, err = c.FsCreateJob(ctx, pool, name, size)
+			return err
+		},
+		created(&job, func(ctx context.Context, c lsm.StorageClient) error {
+			_, err := c.FsDeleteCtx(ctx, fs, true)
+			return err
+		}),
+		waitFor(&job))
+	if err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// AccessGroupCreate creates an access group, deleted on rollback.
+func (w *Workflow) AccessGroupCreate(ctx context.Context, name string, initID string,
+	initType lsm.InitiatorType, system *lsm.System) (*lsm.AccessGroup, error) {
+
+	var ag *lsm.AccessGroup
+	err := w.Step(ctx, "access group create "+name,
+		func(ctx context.Context, c lsm.StorageClient) (err error) {
+			ag, err = c.AccessGroupCreateCtx(ctx, name, initID, initType, system)
+			return err
+		},
+		func(ctx context.Context, c lsm.StorageClient) error {
+			return c.AccessGroupDeleteCtx(ctx, ag)
+		})
+	return ag, err
+}
+
+// AccessGroupInitAdd adds an initiator to the access group, removed on
+// rollback.
+func (w *Workflow) AccessGroupInitAdd(ctx context.Context, ag *lsm.AccessGroup, initID string,
+	initType lsm.InitiatorType) (*lsm.AccessGroup, error) {
+
+	var updated *lsm.AccessGroup
+	err := w.Step(ctx, fmt.Sprintf("access group %s initiator add %s", ag.Name, initID),
+		func(ctx context.Context, c lsm.StorageClient) (err error) {
+			updated, err = c.AccessGroupInitAddCtx(ctx, ag, initID, initType)
+			return err
+		},
+		func(ctx context.Context, c lsm.StorageClient) error {
+			_, err := c.AccessGroupInitDeleteCtx(ctx, updated, initID, initType)
+			return err
+		})
+	return updated, err
+}
+
+// VolumeMask grants the access group access to the volume, revoked on
+// rollback.
+func (w *Workflow) VolumeMask(ctx context.Context, vol *lsm.Volume, ag *lsm.AccessGroup) error {
+	return w.Step(ctx, fmt.Sprintf("volume mask %s to %s", vol.Name, ag.Name),
+		func(ctx context.Context, c lsm.StorageClient) error {
+			return c.VolumeMaskCtx(ctx, vol, ag)
+		},
+		func(ctx context.Context, c lsm.StorageClient) error {
+			return c.VolumeUnMaskCtx(ctx, vol, ag)
+		})
+}
+
+// FsExport exports a file system over NFS, unexported on rollback.
+func (w *Workflow) FsExport(ctx context.Context, fs *lsm.FileSystem, exportPath *string,
+	access *lsm.NfsAccess, authType *string, options *string) (*lsm.NfsExport, error) {
+
+	var export *lsm.NfsExport
+	err := w.Step(ctx, "fs export "+fs.Name,
+		func(ctx context.Context, c lsm.StorageClient) (err error) {
+			export, err = c.FsExportCtx(ctx, fs, exportPath, access, authType, options)
+			return err
+		},
+		func(ctx context.Context, c lsm.StorageClient) error {
+			return c.FsUnExportCtx(ctx, export)
+		})
+	return export, err
+}